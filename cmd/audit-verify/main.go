@@ -0,0 +1,60 @@
+// audit-verify は監査ログのハッシュの連鎖を検証し、書き換え・削除・並べ替えられたエントリがあれば終了コード 1 で終了する。
+//
+//	go run ./cmd/audit-verify -file audit.jsonl -head <以前に控えた hash>
+//
+// 連鎖だけでは末尾のエントリの削除を検出できない。成功時に表示される hash を別の場所に控えておき、
+// 次回 -head に指定すると、そのエントリがまだ残っていることも確かめる。
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+)
+
+func main() {
+	file := flag.String("file", "audit.jsonl", "audit log file to verify")
+	expectedHead := flag.String("head", "", "hash of a previously verified entry that must still be in the log")
+	flag.Parse()
+
+	if err := run(context.Background(), *file, *expectedHead); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, file, expectedHead string) error {
+	auditLog := storage.NewFileAuditLog(file)
+	head, err := auditLog.Verify(ctx)
+	if err != nil {
+		return err
+	}
+
+	if expectedHead != "" && expectedHead != head.Hash {
+		entries, err := auditLog.Query(ctx, domain.AuditQuery{Limit: int(head.Seq) + 1})
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, e := range entries {
+			if e.Hash == expectedHead {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: entry with hash %s is missing", domain.ErrAuditTampered, expectedHead)
+		}
+	}
+
+	if head.Seq == 0 {
+		fmt.Println("OK: audit log is empty")
+		return nil
+	}
+	fmt.Printf("OK: %d entries, head %s\n", head.Seq, head.Hash)
+	return nil
+}
@@ -1,33 +1,204 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 	"net/http"
+	"os"
+	"time"
 
+	"github.com/k98a73/go-todo/internal/audit"
+	"github.com/k98a73/go-todo/internal/delta"
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/feed"
 	http_infra "github.com/k98a73/go-todo/internal/infra/http"
+	"github.com/k98a73/go-todo/internal/infra/job"
 	"github.com/k98a73/go-todo/internal/infra/storage"
+	"github.com/k98a73/go-todo/internal/search"
+	"github.com/k98a73/go-todo/internal/undo"
 	"github.com/k98a73/go-todo/internal/usecase"
+	"github.com/k98a73/go-todo/internal/web"
+	"github.com/k98a73/go-todo/internal/webhook"
 )
 
 func main() {
-	repo := storage.NewFileRepository("todos.json")
-	createUsecase := usecase.NewCreateTodoUsecase(repo)
-	listUsecase := usecase.NewListTodoUsecase(repo)
+	trashRetention := flag.Duration("trash-retention", 30*24*time.Hour, "how long deleted todos stay in the trash before being purged")
+	archiveAfter := flag.Duration("archive-after", 30*24*time.Hour, "how long after completion todos are moved to the archive")
+	storageKind := flag.String("storage", "file", "todo storage: file (todos.json) or events (append-only todos.events.jsonl with change history)")
+	undoLimit := flag.Int("undo-limit", undo.DefaultLimit, "how many operations each session can undo")
+	stateMachinePath := flag.String("state-machine", "", "JSON file defining the initial status, allowed status transitions and terminal statuses (default: todo, in_progress, done, cancelled)")
+	feedToken := flag.String("feed-token", "", "token that calendar apps use to read /calendar/todos.ics; the feed is disabled when empty (default: $TODO_FEED_TOKEN)")
+	flag.Parse()
+	// 既定値を環境変数から読むのは、-help でトークンを表示しないため
+	if *feedToken == "" {
+		*feedToken = os.Getenv("TODO_FEED_TOKEN")
+	}
+
+	machine := domain.DefaultStateMachine()
+	if *stateMachinePath != "" {
+		loaded, err := storage.LoadStateMachine(*stateMachinePath)
+		if err != nil {
+			log.Fatalf("Failed to load state machine: %v", err)
+		}
+		machine = loaded
+	}
+
+	ctx := context.Background()
+	var store domain.IRepository
+	var historyUsecase *usecase.TodoHistoryUsecase
+	switch *storageKind {
+	case "file":
+		defer claimFile("todos.json")()
+		store = storage.NewFileRepository("todos.json")
+	case "events":
+		defer claimFile("todos.events.jsonl")()
+		eventRepo := storage.NewEventRepository("todos.events.jsonl")
+		// 初回起動時は既存の todos.json を取り込む
+		todos, maxID, err := storage.NewFileRepository("todos.json").Export(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read todos.json: %v", err)
+		}
+		imported, err := eventRepo.Import(domain.WithActor(ctx, "import"), todos, maxID)
+		if err != nil {
+			log.Fatalf("Failed to import todos.json: %v", err)
+		}
+		if imported > 0 {
+			log.Printf("Imported %d todo(s) from todos.json", imported)
+		}
+		store = eventRepo
+		historyUsecase = usecase.NewTodoHistoryUsecase(eventRepo)
+	default:
+		log.Fatalf("Unknown storage %q", *storageKind)
+	}
+
+	indexedRepo, err := search.NewIndexedRepository(ctx, store)
+	if err != nil {
+		log.Fatalf("Failed to build search index: %v", err)
+	}
+	changeIndex := storage.NewFileChangeIndex("sync.json")
+	trackingRepo := delta.NewTrackingRepository(indexedRepo, changeIndex)
+	auditLog := storage.NewFileAuditLog("audit.jsonl")
+	auditedRepo := audit.NewAuditedRepository(trackingRepo, auditLog, domain.AuditResourceTodo)
+	bus := feed.NewBus(feed.DefaultReplaySize)
+	publishingRepo := feed.NewPublishingRepository(auditedRepo, bus)
+	webhookRepo := storage.NewFileWebhookRepository("webhooks.json")
+	deliveryRepo := storage.NewFileDeliveryRepository("webhook_deliveries.json")
+	notifyingRepo := webhook.NewNotifyingRepository(publishingRepo, webhookRepo, deliveryRepo)
+	// バックグラウンドジョブの変更はセッションを持たないため、取り消しの対象にならない
+	repo := undo.NewUndoableRepository(notifyingRepo, *undoLimit)
+	archiveRepo := audit.NewAuditedRepository(storage.NewFileRepository("archive.json"), auditLog, domain.AuditResourceArchive)
+	viewRepo := audit.NewAuditedViewRepository(storage.NewFileViewRepository("views.json"), auditLog)
+	createUsecase := usecase.NewCreateTodoUsecase(repo, machine)
+	listUsecase := usecase.NewListTodoUsecase(repo, machine)
 	findByIDUsecase := usecase.NewFindByIDTodoUsecase(repo)
-	updateUsecase := usecase.NewUpdateTodoUsecase(repo)
+	updateUsecase := usecase.NewUpdateTodoUsecase(repo, machine)
 	deleteUsecase := usecase.NewDeleteTodoUsecase(repo)
+	changeStatusUsecase := usecase.NewChangeStatusTodoUsecase(repo, machine)
+	listTrashUsecase := usecase.NewListTrashTodoUsecase(repo)
+	restoreUsecase := usecase.NewRestoreTodoUsecase(repo)
+	purgeUsecase := usecase.NewPurgeTodoUsecase(repo)
+	purgeExpiredUsecase := usecase.NewPurgeExpiredTodoUsecase(repo, *trashRetention)
+	archiveUsecase := usecase.NewArchiveTodoUsecase(repo, archiveRepo)
+	archiveCompletedUsecase := usecase.NewArchiveCompletedTodoUsecase(repo, archiveRepo, *archiveAfter)
+	listArchiveUsecase := usecase.NewListArchiveTodoUsecase(archiveRepo)
+	unarchiveUsecase := usecase.NewUnarchiveTodoUsecase(repo, archiveRepo)
+	moveUsecase := usecase.NewMoveTodoUsecase(repo)
+	bulkUsecase := usecase.NewBulkTodoUsecase(repo, machine)
+	searchUsecase := usecase.NewSearchTodoUsecase(indexedRepo)
+	createViewUsecase := usecase.NewCreateViewUsecase(viewRepo, machine)
+	listViewUsecase := usecase.NewListViewUsecase(viewRepo)
+	findViewUsecase := usecase.NewFindViewUsecase(viewRepo)
+	updateViewUsecase := usecase.NewUpdateViewUsecase(viewRepo, machine)
+	deleteViewUsecase := usecase.NewDeleteViewUsecase(viewRepo)
+	listViewTodoUsecase := usecase.NewListViewTodoUsecase(viewRepo, repo, machine)
+	undoUsecase := usecase.NewUndoTodoUsecase(repo)
+	redoUsecase := usecase.NewRedoTodoUsecase(repo)
+	listAuditUsecase := usecase.NewListAuditUsecase(auditLog)
+	subscribeTodoEventsUsecase := usecase.NewSubscribeTodoEventsUsecase(bus, viewRepo, machine)
+	createWebhookUsecase := usecase.NewCreateWebhookUsecase(webhookRepo)
+	listWebhookUsecase := usecase.NewListWebhookUsecase(webhookRepo)
+	findWebhookUsecase := usecase.NewFindWebhookUsecase(webhookRepo)
+	updateWebhookUsecase := usecase.NewUpdateWebhookUsecase(webhookRepo)
+	deleteWebhookUsecase := usecase.NewDeleteWebhookUsecase(webhookRepo, deliveryRepo)
+	listWebhookDeliveryUsecase := usecase.NewListWebhookDeliveryUsecase(webhookRepo, deliveryRepo)
+	syncUsecase := usecase.NewSyncTodoUsecase(repo, changeIndex, machine)
+	importUsecase := usecase.NewImportTodoUsecase(repo, machine)
+	deliverWebhookUsecase := usecase.NewDeliverWebhookUsecase(webhookRepo, deliveryRepo, webhook.NewHTTPSender(webhook.DefaultTimeout))
 	todoHandler := http_infra.NewTodoHandler(createUsecase, listUsecase, findByIDUsecase, updateUsecase, deleteUsecase)
+	statusHandler := http_infra.NewStatusHandler(changeStatusUsecase)
+	trashHandler := http_infra.NewTrashHandler(listTrashUsecase, restoreUsecase, purgeUsecase)
+	archiveHandler := http_infra.NewArchiveHandler(archiveUsecase, listArchiveUsecase, unarchiveUsecase)
+	moveHandler := http_infra.NewMoveHandler(moveUsecase)
+	bulkHandler := http_infra.NewBulkHandler(bulkUsecase)
+	searchHandler := http_infra.NewSearchHandler(searchUsecase)
+	viewHandler := http_infra.NewViewHandler(createViewUsecase, listViewUsecase, findViewUsecase, updateViewUsecase, deleteViewUsecase, listViewTodoUsecase)
+	undoHandler := http_infra.NewUndoHandler(undoUsecase, redoUsecase)
+	auditHandler := http_infra.NewAuditHandler(listAuditUsecase)
+	eventsHandler := http_infra.NewEventsHandler(subscribeTodoEventsUsecase)
+	syncHandler := http_infra.NewSyncHandler(syncUsecase)
+	webhookHandler := http_infra.NewWebhookHandler(createWebhookUsecase, listWebhookUsecase, findWebhookUsecase, updateWebhookUsecase, deleteWebhookUsecase, listWebhookDeliveryUsecase)
+	calendarHandler := http_infra.NewCalendarHandler(listUsecase, listViewTodoUsecase, importUsecase, *feedToken)
+	webHandler := web.NewHandler(createUsecase, listUsecase, findByIDUsecase, updateUsecase, deleteUsecase, changeStatusUsecase)
 
-	mux := http.NewServeMux()
+	jobCtx := domain.WithActor(ctx, "system")
+	go job.RunPeriodic(jobCtx, "purge-expired-trash", time.Hour, func(ctx context.Context) error {
+		purged, err := purgeExpiredUsecase.Execute(ctx, time.Now())
+		if purged > 0 {
+			log.Printf("Purged %d expired todo(s) from trash", purged)
+		}
+		return err
+	})
+	go job.RunPeriodic(jobCtx, "archive-completed", time.Hour, func(ctx context.Context) error {
+		archived, err := archiveCompletedUsecase.Execute(ctx, time.Now())
+		if archived > 0 {
+			log.Printf("Archived %d completed todo(s)", archived)
+		}
+		return err
+	})
+	go job.RunPeriodic(jobCtx, "deliver-webhooks", 5*time.Second, func(ctx context.Context) error {
+		delivered, err := deliverWebhookUsecase.Execute(ctx, time.Now())
+		if delivered > 0 {
+			log.Printf("Delivered %d webhook(s)", delivered)
+		}
+		return err
+	})
 
-	mux.HandleFunc("POST /todo", todoHandler.CreateTodo)
-	mux.HandleFunc("GET /todo/list", todoHandler.ListTodo)
-	mux.HandleFunc("GET /todo/{id}", todoHandler.FindByIDTodo)
-	mux.HandleFunc("PUT /todo/{id}", todoHandler.UpdateTodo)
-	mux.HandleFunc("DELETE /todo/{id}", todoHandler.DeleteTodo)
+	var historyHandler *http_infra.HistoryHandler
+	if historyUsecase != nil {
+		historyHandler = http_infra.NewHistoryHandler(historyUsecase)
+	}
+	router := newRouter(handlers{
+		todo:     todoHandler,
+		status:   statusHandler,
+		trash:    trashHandler,
+		archive:  archiveHandler,
+		move:     moveHandler,
+		bulk:     bulkHandler,
+		search:   searchHandler,
+		history:  historyHandler,
+		view:     viewHandler,
+		undo:     undoHandler,
+		audit:    auditHandler,
+		events:   eventsHandler,
+		sync:     syncHandler,
+		webhook:  webhookHandler,
+		calendar: calendarHandler,
+		web:      webHandler,
+	}, machine)
 
 	log.Println("Starting server on :8080")
-	if err := http.ListenAndServe(":8080", mux); err != nil {
+	if err := http.ListenAndServe(":8080", http_infra.RequestIDMiddleware(http_infra.ActorMiddleware(http_infra.SessionMiddleware(router)))); err != nil {
 		log.Fatalf("Server failed to start: %v", err)
 	}
 }
+
+// claimFile は起動している間 path を占有し、todo コマンドのローカルモードや別のサーバーが同じファイルを書き換えないようにする。
+// 返す関数は占有を解除する。ロックを持つファイルが閉じられないよう、main の終わりまで参照しておく。
+func claimFile(path string) func() {
+	release, err := storage.ClaimFile(path)
+	if err != nil {
+		log.Fatalf("Failed to claim %s (is another server or todo -file running?): %v", path, err)
+	}
+	return release
+}
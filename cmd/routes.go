@@ -0,0 +1,279 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	http_infra "github.com/k98a73/go-todo/internal/infra/http"
+	"github.com/k98a73/go-todo/internal/openapi"
+	"github.com/k98a73/go-todo/internal/web"
+)
+
+// handlers はルートに登録するハンドラー。history は events ストレージの場合だけ設定する。
+type handlers struct {
+	todo     *http_infra.TodoHandler
+	status   *http_infra.StatusHandler
+	trash    *http_infra.TrashHandler
+	archive  *http_infra.ArchiveHandler
+	move     *http_infra.MoveHandler
+	bulk     *http_infra.BulkHandler
+	search   *http_infra.SearchHandler
+	history  *http_infra.HistoryHandler
+	view     *http_infra.ViewHandler
+	undo     *http_infra.UndoHandler
+	audit    *http_infra.AuditHandler
+	events   *http_infra.EventsHandler
+	sync     *http_infra.SyncHandler
+	webhook  *http_infra.WebhookHandler
+	calendar *http_infra.CalendarHandler
+	web      *web.Handler
+}
+
+var (
+	todoID    = openapi.Path("id", "integer", "todo ID")
+	viewID    = openapi.Path("id", "string", "view ID")
+	webhookID = openapi.Path("id", "string", "webhook ID")
+	filter    = openapi.Query("q", "string", `filter expression, e.g. status:open "release notes"`)
+	limit     = openapi.Query("limit", "integer", "maximum number of results")
+	format    = openapi.Query("format", "string", "json, csv, markdown or text; overrides the Accept header")
+
+	todoErrors       = []int{http.StatusBadRequest, http.StatusNotFound}
+	negotiatedErrors = []int{http.StatusBadRequest, http.StatusNotFound, http.StatusNotAcceptable}
+	transitionErrors = []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict}
+	viewErrors       = []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound}
+	webhookErrors    = []int{http.StatusBadRequest, http.StatusNotFound}
+)
+
+// 旧 API（/todo・/todo/list・/todo/{id}）は legacySunset まで提供し、/v1/todos に移行してもらう。
+var (
+	legacyDeprecatedAt = time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	legacySunset       = time.Date(2027, 4, 18, 0, 0, 0, 0, time.UTC)
+)
+
+func legacy(next http.HandlerFunc, successor string) http.HandlerFunc {
+	return http_infra.Deprecate(next, legacyDeprecatedAt, legacySunset, successor)
+}
+
+// message は削除などで返す {"message": "..."}。
+type message map[string]string
+
+// newRouter はすべてのルートを登録する。登録した内容から GET /openapi.json の文書を作る。
+// status の取りうる値は machine に定義されたステータスとして載せる。
+func newRouter(h handlers, machine *domain.StateMachine) *openapi.Router {
+	r := openapi.NewRouter("go-todo API", "1.0.0")
+	r.SetErrorBody(http_infra.ErrorResponse{})
+	// completed は MarshalJSON が status から導出して出力する
+	r.Customize(domain.Todo{}, func(s *openapi.Schema) {
+		s.Properties["completed"] = &openapi.Schema{Type: "boolean", Description: "true when status is done"}
+		s.Required = append(s.Required, "completed")
+	})
+	r.Customize(domain.Status(""), func(s *openapi.Schema) {
+		s.Enum = nil
+		for _, status := range machine.Statuses() {
+			s.Enum = append(s.Enum, status)
+		}
+	})
+
+	v1 := h.todo.V1()
+	formats := h.todo.Formats().ContentTypes()
+	r.HandleFunc("GET /v1/todos", v1.ListTodo, openapi.Operation{
+		ID: "ListTodosV1", Summary: "List todos",
+		Params:   []openapi.Param{filter, openapi.Query("sort", "string", "position, created_at or updated_at"), limit, format},
+		Response: http_infra.TodoListV1{}, Alternatives: formats, Errors: []int{http.StatusBadRequest, http.StatusNotAcceptable},
+	})
+	r.HandleFunc("POST /v1/todos", v1.CreateTodo, openapi.Operation{
+		ID: "CreateTodoV1", Summary: "Create a todo; the Location header points to it",
+		Request: http_infra.CreateTodoRequest{}, Response: http_infra.TodoV1{}, Status: http.StatusCreated,
+	})
+	r.HandleFunc("GET /v1/todos/{id}", v1.FindByIDTodo, openapi.Operation{
+		ID: "GetTodoV1", Summary: "Get a todo", Params: []openapi.Param{todoID, format},
+		Response: http_infra.TodoV1{}, Alternatives: formats, Errors: negotiatedErrors,
+	})
+	r.HandleFunc("PUT /v1/todos/{id}", v1.UpdateTodo, openapi.Operation{
+		ID: "UpdateTodoV1", Summary: "Update the title and completion of a todo", Params: []openapi.Param{todoID},
+		Request: http_infra.UpdateTodoRequest{}, Response: http_infra.TodoV1{}, Errors: transitionErrors,
+	})
+	r.HandleFunc("DELETE /v1/todos/{id}", v1.DeleteTodo, openapi.Operation{
+		ID: "DeleteTodoV1", Summary: "Move a todo to the trash", Params: []openapi.Param{todoID}, Status: http.StatusNoContent, Errors: todoErrors,
+	})
+
+	// バージョンのない旧 API。/v1/todos と同じ処理で、レスポンスの形だけを保つ
+	r.HandleFunc("POST /todo", legacy(h.todo.CreateTodo, "/v1/todos"), openapi.Operation{
+		ID: "CreateTodo", Summary: "Create a todo (use POST /v1/todos)", Deprecated: true,
+		Request: http_infra.CreateTodoRequest{}, Response: domain.Todo{}, Status: http.StatusCreated,
+	})
+	r.HandleFunc("POST /todo/bulk", h.bulk.BulkTodo, openapi.Operation{
+		Summary: "Apply several operations atomically or best-effort", Request: http_infra.BulkTodoRequest{}, Response: http_infra.BulkTodoResponse{},
+		Errors: []int{http.StatusBadRequest},
+	})
+	r.HandleFunc("GET /todo/list", legacy(h.todo.ListTodo, "/v1/todos"), openapi.Operation{
+		ID: "ListTodo", Summary: "List todos (use GET /v1/todos)", Deprecated: true,
+		Params:   []openapi.Param{filter, openapi.Query("sort", "string", "position, created_at or updated_at"), limit, format},
+		Response: []domain.Todo{}, Alternatives: formats, Errors: []int{http.StatusBadRequest, http.StatusNotAcceptable},
+	})
+	r.HandleFunc("GET /todo/search", h.search.SearchTodo, openapi.Operation{
+		Summary: "Full-text search ranked by relevance", Params: []openapi.Param{openapi.Query("q", "string", "search words"), limit},
+		Response: []domain.SearchHit{}, Errors: []int{http.StatusBadRequest},
+	})
+	r.HandleFunc("GET /todo/{id}", legacy(h.todo.FindByIDTodo, "/v1/todos/{id}"), openapi.Operation{
+		ID: "FindByIDTodo", Summary: "Get a todo (use GET /v1/todos/{id})", Deprecated: true, Params: []openapi.Param{todoID, format},
+		Response: domain.Todo{}, Alternatives: formats, Errors: negotiatedErrors,
+	})
+	r.HandleFunc("PUT /todo/{id}", legacy(h.todo.UpdateTodo, "/v1/todos/{id}"), openapi.Operation{
+		ID: "UpdateTodo", Summary: "Update a todo (use PUT /v1/todos/{id})", Deprecated: true, Params: []openapi.Param{todoID},
+		Request: http_infra.UpdateTodoRequest{}, Response: domain.Todo{}, Errors: transitionErrors,
+	})
+	r.HandleFunc("DELETE /todo/{id}", legacy(h.todo.DeleteTodo, "/v1/todos/{id}"), openapi.Operation{
+		ID: "DeleteTodo", Summary: "Move a todo to the trash (use DELETE /v1/todos/{id})", Deprecated: true, Params: []openapi.Param{todoID}, Response: message{}, Errors: todoErrors,
+	})
+	r.HandleFunc("POST /todo/{id}/status", h.status.ChangeStatus, openapi.Operation{
+		Summary: "Change the status of a todo", Params: []openapi.Param{todoID},
+		Request: http_infra.ChangeStatusRequest{}, Response: domain.Todo{}, Errors: transitionErrors,
+	})
+	r.HandleFunc("POST /todo/{id}/move", h.move.MoveTodo, openapi.Operation{
+		Summary: "Move a todo between two others", Params: []openapi.Param{todoID},
+		Request: http_infra.MoveTodoRequest{}, Response: domain.Todo{}, Errors: todoErrors,
+	})
+	r.HandleFunc("POST /todo/{id}/restore", h.trash.RestoreTodo, openapi.Operation{
+		Summary: "Restore a todo from the trash", Params: []openapi.Param{todoID}, Response: domain.Todo{}, Errors: todoErrors,
+	})
+	if h.history != nil {
+		r.HandleFunc("GET /todo/{id}/history", h.history.TodoHistory, openapi.Operation{
+			Summary: "List the change history of a todo (events storage only)", Params: []openapi.Param{todoID},
+			Response: []domain.Event{}, Errors: todoErrors,
+		})
+	}
+	r.HandleFunc("GET /trash", h.trash.ListTrash, openapi.Operation{
+		Summary: "List todos in the trash", Response: []domain.Todo{},
+	})
+	r.HandleFunc("DELETE /trash/{id}", h.trash.PurgeTodo, openapi.Operation{
+		Summary: "Permanently delete a todo in the trash", Params: []openapi.Param{todoID}, Response: message{}, Errors: todoErrors,
+	})
+	r.HandleFunc("POST /todo/{id}/archive", h.archive.ArchiveTodo, openapi.Operation{
+		Summary: "Archive a done or cancelled todo", Params: []openapi.Param{todoID}, Response: domain.Todo{}, Errors: transitionErrors,
+	})
+	r.HandleFunc("GET /archive", h.archive.ListArchive, openapi.Operation{
+		Summary: "List archived todos", Params: []openapi.Param{openapi.Query("q", "string", "case-insensitive substring of the title; the filter language is not supported")}, Response: []domain.Todo{},
+	})
+	r.HandleFunc("POST /archive/{id}/unarchive", h.archive.UnarchiveTodo, openapi.Operation{
+		Summary: "Move an archived todo back to the list", Params: []openapi.Param{todoID}, Response: domain.Todo{}, Errors: transitionErrors,
+	})
+	r.HandleFunc("POST /views", h.view.CreateView, openapi.Operation{
+		Summary: "Save a view", Request: http_infra.ViewRequest{}, Response: domain.View{}, Status: http.StatusCreated, Errors: viewErrors,
+	})
+	r.HandleFunc("GET /views", h.view.ListViews, openapi.Operation{
+		Summary: "List saved and built-in views", Response: []domain.View{},
+	})
+	r.HandleFunc("GET /views/{id}", h.view.FindView, openapi.Operation{
+		Summary: "Get a view", Params: []openapi.Param{viewID}, Response: domain.View{}, Errors: viewErrors,
+	})
+	r.HandleFunc("PUT /views/{id}", h.view.UpdateView, openapi.Operation{
+		Summary: "Update a saved view", Params: []openapi.Param{viewID}, Request: http_infra.ViewRequest{}, Response: domain.View{}, Errors: viewErrors,
+	})
+	r.HandleFunc("DELETE /views/{id}", h.view.DeleteView, openapi.Operation{
+		Summary: "Delete a saved view", Params: []openapi.Param{viewID}, Response: message{}, Errors: viewErrors,
+	})
+	r.HandleFunc("GET /views/{id}/todos", h.view.ListViewTodos, openapi.Operation{
+		Summary: "List todos matching a view", Params: []openapi.Param{viewID}, Response: []domain.Todo{}, Errors: viewErrors,
+	})
+	r.HandleFunc("POST /undo", h.undo.Undo, openapi.Operation{
+		Summary: "Undo the last operation of the session", Response: []domain.Change{}, Errors: []int{http.StatusNotFound, http.StatusConflict},
+	})
+	r.HandleFunc("POST /redo", h.undo.Redo, openapi.Operation{
+		Summary: "Redo the last undone operation of the session", Response: []domain.Change{}, Errors: []int{http.StatusNotFound, http.StatusConflict},
+	})
+	r.HandleFunc("GET /audit", h.audit.ListAudit, openapi.Operation{
+		Summary: "List audit log entries, newest first",
+		Params: []openapi.Param{
+			openapi.Query("actor", "string", ""), openapi.Query("request_id", "string", ""), openapi.Query("resource", "string", ""),
+			openapi.Query("resource_id", "string", ""), openapi.Query("action", "string", ""),
+			openapi.Query("since", "string", "RFC 3339 time"), openapi.Query("until", "string", "RFC 3339 time"), limit,
+		},
+		Response: []domain.AuditEntry{}, Errors: []int{http.StatusBadRequest},
+	})
+	r.HandleFunc("GET /events", h.events.StreamEvents, openapi.Operation{
+		Summary: "Stream todo changes as Server-Sent Events",
+		Params: []openapi.Param{
+			filter, openapi.Query("view", "string", "view ID"), openapi.Query("last_event_id", "integer", "resume after this event"),
+			openapi.Header(http_infra.LastEventIDHeader, "integer", "resume after this event"),
+		},
+		ContentType: "text/event-stream", Errors: []int{http.StatusBadRequest, http.StatusNotFound},
+	})
+	r.HandleFunc("GET /sync", h.sync.PullChanges, openapi.Operation{
+		Summary: "Pull changes since a sync token", Params: []openapi.Param{openapi.Query("token", "string", "token from the previous sync; empty for a snapshot")},
+		Response: domain.SyncResponse{}, Errors: []int{http.StatusBadRequest, http.StatusGone},
+	})
+	r.HandleFunc("POST /sync", h.sync.Sync, openapi.Operation{
+		Summary: "Push offline changes and pull changes since a sync token",
+		Request: http_infra.SyncRequest{}, Response: domain.SyncResponse{}, Errors: []int{http.StatusBadRequest, http.StatusGone},
+	})
+	r.HandleFunc("POST /webhooks", h.webhook.CreateWebhook, openapi.Operation{
+		Summary: "Register a webhook", Request: http_infra.WebhookRequest{}, Response: domain.Webhook{}, Status: http.StatusCreated, Errors: webhookErrors,
+	})
+	r.HandleFunc("GET /webhooks", h.webhook.ListWebhooks, openapi.Operation{
+		Summary: "List webhooks (secrets are not returned)", Response: []domain.Webhook{},
+	})
+	r.HandleFunc("GET /webhooks/{id}", h.webhook.FindWebhook, openapi.Operation{
+		Summary: "Get a webhook", Params: []openapi.Param{webhookID}, Response: domain.Webhook{}, Errors: webhookErrors,
+	})
+	r.HandleFunc("PUT /webhooks/{id}", h.webhook.UpdateWebhook, openapi.Operation{
+		Summary: "Update a webhook", Params: []openapi.Param{webhookID}, Request: http_infra.WebhookRequest{}, Response: domain.Webhook{}, Errors: webhookErrors,
+	})
+	r.HandleFunc("DELETE /webhooks/{id}", h.webhook.DeleteWebhook, openapi.Operation{
+		Summary: "Delete a webhook and its deliveries", Params: []openapi.Param{webhookID}, Response: message{}, Errors: webhookErrors,
+	})
+	r.HandleFunc("GET /webhooks/{id}/deliveries", h.webhook.ListDeliveries, openapi.Operation{
+		Summary: "List recent deliveries of a webhook", Params: []openapi.Param{webhookID, limit},
+		Response: []domain.WebhookDelivery{}, Errors: webhookErrors,
+	})
+
+	// カレンダーアプリ向け。フィードは -feed-token を指定したときだけ有効
+	r.HandleFunc("GET /calendar/todos.ics", h.calendar.ExportFeed, openapi.Operation{
+		Summary: "iCalendar feed of todos as VTODO",
+		Params: []openapi.Param{
+			openapi.Query("token", "string", "feed token; may also be sent as a Bearer token"),
+			openapi.Query("view", "string", "only todos in this view"),
+		},
+		Response: "", ContentType: "text/calendar", Errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound},
+	})
+	r.HandleFunc("POST /calendar/import", h.calendar.ImportTodos, openapi.Operation{
+		Summary: "Import VTODO entries as todos; todos exported by this server are skipped while they exist",
+		Request: "", RequestContentType: "text/calendar", Response: domain.ImportResult{}, Status: http.StatusCreated,
+		Errors: []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType},
+	})
+
+	// ブラウザ向けの画面。HTML を返し、フォームは application/x-www-form-urlencoded で送る
+	r.HandleFunc("GET /ui", h.web.Index, openapi.Operation{
+		Summary: "Web UI: list todos", Params: []openapi.Param{filter}, ContentType: "text/html",
+	})
+	r.HandleFunc("GET /ui/list", h.web.List, openapi.Operation{
+		Summary: "Web UI: list fragment", Params: []openapi.Param{filter}, ContentType: "text/html", Errors: []int{http.StatusBadRequest},
+	})
+	r.HandleFunc("POST /ui/todos", h.web.CreateTodo, openapi.Operation{
+		Summary: "Web UI: create form", Status: http.StatusSeeOther, Errors: []int{http.StatusBadRequest, http.StatusForbidden},
+	})
+	r.HandleFunc("GET /ui/todos/{id}/edit", h.web.EditPage, openapi.Operation{
+		Summary: "Web UI: edit form", Params: []openapi.Param{todoID}, ContentType: "text/html", Errors: []int{http.StatusNotFound},
+	})
+	r.HandleFunc("POST /ui/todos/{id}", h.web.UpdateTodo, openapi.Operation{
+		Summary: "Web UI: submit edit form", Params: []openapi.Param{todoID}, Status: http.StatusSeeOther,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict},
+	})
+	r.HandleFunc("POST /ui/todos/{id}/toggle", h.web.ToggleTodo, openapi.Operation{
+		Summary: "Web UI: complete or reopen a todo", Params: []openapi.Param{todoID}, Status: http.StatusSeeOther,
+		Errors: []int{http.StatusBadRequest, http.StatusForbidden, http.StatusNotFound, http.StatusConflict},
+	})
+	r.HandleFunc("POST /ui/todos/{id}/delete", h.web.DeleteTodo, openapi.Operation{
+		Summary: "Web UI: move a todo to the trash", Params: []openapi.Param{todoID}, Status: http.StatusSeeOther,
+		Errors: []int{http.StatusForbidden, http.StatusNotFound},
+	})
+	r.Handle("GET /ui/static/{path...}", h.web.Static(), openapi.Operation{
+		ID: "Static", Summary: "Web UI: scripts and stylesheets", ContentType: "*/*", Errors: []int{http.StatusNotFound},
+	})
+
+	r.HandleFunc("GET /openapi.json", r.ServeDocument, openapi.Operation{
+		Summary: "This OpenAPI document", ContentType: "application/json",
+	})
+	return r
+}
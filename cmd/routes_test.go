@@ -0,0 +1,232 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	http_infra "github.com/k98a73/go-todo/internal/infra/http"
+	"github.com/k98a73/go-todo/internal/openapi"
+	"github.com/k98a73/go-todo/internal/web"
+)
+
+// newTestRouter はすべてのルートを登録する。ハンドラーは呼び出さないため usecase は空でよい。
+func newTestRouter() *openapi.Router {
+	return newRouter(handlers{todo: http_infra.NewTodoHandler(nil, nil, nil, nil, nil), history: &http_infra.HistoryHandler{}, web: &web.Handler{}}, domain.DefaultStateMachine())
+}
+
+// registeredPatterns は cmd のソースで HandleFunc / Handle に渡しているパターンをすべて返す。
+// mux に直接登録したルートも拾い、文書から漏れたルートを見つけられるようにする。
+func registeredPatterns(t *testing.T) []string {
+	t.Helper()
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var patterns []string
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (sel.Sel.Name != "HandleFunc" && sel.Sel.Name != "Handle") {
+				return true
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				t.Errorf("%s: route pattern must be a string literal", fset.Position(call.Pos()))
+				return true
+			}
+			pattern, _ := strconv.Unquote(lit.Value)
+			patterns = append(patterns, pattern)
+			return true
+		})
+	}
+	return patterns
+}
+
+func TestOpenAPI_CoversAllRoutes(t *testing.T) {
+	// Given: cmd で登録しているルート
+	patterns := registeredPatterns(t)
+	if len(patterns) < 40 {
+		t.Fatalf("Expected all routes to be found, got %d", len(patterns))
+	}
+
+	// When:  文書を作る
+	doc := newTestRouter().Document()
+
+	// Then:  すべてのルートが文書にある
+	for _, pattern := range patterns {
+		method, path := openapi.SplitPattern(pattern)
+		item, ok := doc.Paths[path]
+		if !ok || (*item)[strings.ToLower(method)] == nil {
+			t.Errorf("Route %q is missing from the OpenAPI document", pattern)
+		}
+	}
+}
+
+func TestOpenAPI_RefsResolve(t *testing.T) {
+	// Given: 文書の JSON
+	data, err := json.Marshal(newTestRouter().Document())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	json.Unmarshal(data, &doc)
+
+	// When:  $ref をすべて探す
+	refs := strings.Split(string(data), `"$ref":"#/components/schemas/`)
+
+	// Then:  参照先がすべて components にある
+	for _, ref := range refs[1:] {
+		name := ref[:strings.Index(ref, `"`)]
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Errorf("Unresolved $ref %s", name)
+		}
+	}
+}
+
+func TestOpenAPI_Serve(t *testing.T) {
+	// Given: ルートを登録したサーバー
+	server := httptest.NewServer(newTestRouter())
+	defer server.Close()
+
+	// When:  GET /openapi.json を呼び出す
+	resp, err := http.Get(server.URL + "/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var doc map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+
+	// Then:  OpenAPI 3.1 の文書が返り、実装にないフィールドは載っていない
+	if resp.StatusCode != http.StatusOK || doc["openapi"] != "3.1.0" {
+		t.Fatalf("Unexpected response: %d %v", resp.StatusCode, doc["openapi"])
+	}
+	todo := doc["components"].(map[string]any)["schemas"].(map[string]any)["Todo"].(map[string]any)["properties"].(map[string]any)
+	for _, name := range []string{"id", "title", "status", "completed"} {
+		if _, ok := todo[name]; !ok {
+			t.Errorf("Expected Todo.%s", name)
+		}
+	}
+	for _, name := range []string{"description", "due_date"} {
+		if _, ok := todo[name]; ok {
+			t.Errorf("Unexpected Todo.%s", name)
+		}
+	}
+}
+
+func TestOpenAPI_LegacyRoutesDeprecated(t *testing.T) {
+	// Given: ルートを登録した文書
+	doc := newTestRouter().Document()
+
+	// When:  旧 API と /v1 の GET を調べる
+	deprecated := map[string]bool{"/todo/list": true, "/todo/{id}": true, "/v1/todos": false, "/v1/todos/{id}": false}
+
+	// Then:  旧 API だけが deprecated になっている
+	for path, want := range deprecated {
+		item := doc.Paths[path]
+		if item == nil || (*item)["get"] == nil {
+			t.Errorf("Expected GET %s", path)
+			continue
+		}
+		if got := (*item)["get"].Deprecated; got != want {
+			t.Errorf("Expected GET %s deprecated %v, got %v", path, want, got)
+		}
+	}
+}
+
+func TestOpenAPI_CalendarImportErrors(t *testing.T) {
+	// Given: ルートを登録した文書
+	doc := newTestRouter().Document()
+
+	// When:  POST /calendar/import を調べる
+	item := doc.Paths["/calendar/import"]
+	if item == nil || (*item)["post"] == nil {
+		t.Fatal("Expected POST /calendar/import")
+	}
+
+	// Then:  本文の誤り・大きすぎる本文・対応しない Content-Type のレスポンスが載っている
+	for _, code := range []string{"400", "413", "415"} {
+		if (*item)["post"].Responses[code] == nil {
+			t.Errorf("Expected %s response", code)
+		}
+	}
+}
+
+func TestOpenAPI_ArchiveQuery(t *testing.T) {
+	// Given: ルートを登録した文書
+	doc := newTestRouter().Document()
+
+	// When:  GET /archive を調べる
+	item := doc.Paths["/archive"]
+	if item == nil || (*item)["get"] == nil {
+		t.Fatal("Expected GET /archive")
+	}
+	op := (*item)["get"]
+
+	// Then:  q はタイトルの部分一致として載り、フィルタ式の誤りによる 400 は載っていない
+	if len(op.Parameters) != 1 || op.Parameters[0].Name != "q" || !strings.Contains(op.Parameters[0].Description, "substring of the title") {
+		t.Errorf("Unexpected parameters: %+v", op.Parameters)
+	}
+	if op.Responses["400"] != nil {
+		t.Error("Unexpected 400 response")
+	}
+}
+
+func TestOpenAPI_StatusEnum(t *testing.T) {
+	// Given: review を含むワークフローで登録した文書
+	machine, err := domain.NewStateMachine(domain.StatusTodo, map[domain.Status][]domain.Status{
+		domain.StatusTodo:       {domain.Status("review")},
+		domain.Status("review"): {domain.StatusDone},
+		domain.StatusDone:       {},
+	}, []domain.Status{domain.StatusDone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := newRouter(handlers{todo: http_infra.NewTodoHandler(nil, nil, nil, nil, nil), history: &http_infra.HistoryHandler{}, web: &web.Handler{}}, machine).Document()
+
+	// When:  Todo.status のスキーマを調べる
+	status := doc.Components.Schemas["Todo"].Properties["status"]
+	if status == nil {
+		t.Fatal("Expected Todo.status")
+	}
+
+	// Then:  ワークフローに定義したステータスだけが載っている
+	want := []any{domain.StatusTodo, domain.StatusDone, domain.Status("review")}
+	if len(status.Enum) != len(want) {
+		t.Fatalf("Expected enum %v, got %v", want, status.Enum)
+	}
+	for i := range want {
+		if status.Enum[i] != want[i] {
+			t.Errorf("Expected enum %v, got %v", want, status.Enum)
+			break
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// todo は Todo サーバーを操作するコマンドラインクライアント。
+//
+//	todo add Buy milk
+//	todo ls -q "status:open"
+//	todo done 3
+//
+// 接続先とトークンは設定ファイル（既定は <ユーザー設定ディレクトリ>/todo/config.json）に書ける。
+//
+//	{"server": "http://localhost:8080", "token": "...", "actor": "alice"}
+//
+// -file を指定するとサーバーを介さずに Todo のファイルを直接操作する。サーバーが同じファイルを使っていても安全に併用できる。
+//
+//	todo -file todos.json ls
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/k98a73/go-todo/internal/cli"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := cli.Run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
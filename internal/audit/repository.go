@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// AuditedRepository は repo への変更を監査ログに記録するデコレータ。
+// 1回の WithinTx でコミットされた変更を、変更された Todo ごとに1件として記録する。
+type AuditedRepository struct {
+	domain.IRepository
+	log      domain.IAuditLog
+	resource string
+}
+
+// NewAuditedRepository は resource（domain.AuditResourceTodo など）として記録するリポジトリを返す。
+func NewAuditedRepository(repo domain.IRepository, log domain.IAuditLog, resource string) *AuditedRepository {
+	return &AuditedRepository{IRepository: repo, log: log, resource: resource}
+}
+
+func (r *AuditedRepository) Create(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Create(ctx, todo)
+	})
+}
+
+func (r *AuditedRepository) Insert(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Insert(ctx, todo)
+	})
+}
+
+func (r *AuditedRepository) Update(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Update(ctx, todo)
+	})
+}
+
+func (r *AuditedRepository) Delete(ctx context.Context, id int) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Delete(ctx, id)
+	})
+}
+
+// WithinTx は fn が成功したら、repo のトランザクションの中で変更を記録する。
+// 記録に失敗すれば変更もロールバックされるため、監査ログに残らない変更は保存されない。
+// 記録した後で repo のコミットが失敗した場合は、保存されなかった変更の記録が残る。
+func (r *AuditedRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	return r.IRepository.WithinTx(ctx, func(tx domain.IRepository) error {
+		rec := domain.NewChangeRecorder(tx)
+		if err := fn(rec); err != nil {
+			return err
+		}
+		return r.record(ctx, rec.Changes())
+	})
+}
+
+func (r *AuditedRepository) record(ctx context.Context, changes []domain.Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	now := time.Now()
+	entries := make([]*domain.AuditEntry, 0, len(changes))
+	for _, c := range changes {
+		diff, err := domain.DiffJSON(c.Before, c.After)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, newEntry(ctx, now, r.resource, strconv.Itoa(c.ID), domain.TodoAuditAction(c, now), diff))
+	}
+	return r.log.Append(ctx, entries)
+}
+
+func newEntry(ctx context.Context, now time.Time, resource, id, action string, diff []domain.FieldChange) *domain.AuditEntry {
+	return &domain.AuditEntry{
+		At:         now,
+		Actor:      domain.ActorFromContext(ctx),
+		RequestID:  domain.RequestIDFromContext(ctx),
+		Resource:   resource,
+		ResourceID: id,
+		Action:     action,
+		Diff:       diff,
+	}
+}
@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+)
+
+func newTestRepository(t *testing.T) (*AuditedRepository, *storage.FileAuditLog) {
+	t.Helper()
+	dir := t.TempDir()
+	log := storage.NewFileAuditLog(filepath.Join(dir, "audit.jsonl"))
+	repo := NewAuditedRepository(storage.NewFileRepository(filepath.Join(dir, "todos.json")), log, domain.AuditResourceTodo)
+	return repo, log
+}
+
+func queryAll(t *testing.T, log *storage.FileAuditLog) []*domain.AuditEntry {
+	t.Helper()
+	entries, err := log.Query(context.Background(), domain.AuditQuery{})
+	if err != nil {
+		t.Fatalf("Failed to query: %v", err)
+	}
+	// 古い順に並べ直す
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries
+}
+
+func TestAuditedRepository_Mutations(t *testing.T) {
+	// Given: alice のリクエスト
+	repo, log := newTestRepository(t)
+	ctx := domain.WithRequestID(domain.WithActor(context.Background(), "alice"), "req-1")
+
+	// When:  作成・タイトル変更・ゴミ箱への移動・削除を行う
+	todo := &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}
+	if err := repo.Create(ctx, todo); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	todo.Title = "Buy oat milk"
+	if err := repo.Update(ctx, todo); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	now := time.Now()
+	todo.DeletedAt = &now
+	if err := repo.Update(ctx, todo); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	if err := repo.Delete(ctx, todo.ID); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+
+	// Then:  操作ごとに利用者・リクエスト ID・操作名・差分が記録される
+	entries := queryAll(t, log)
+	wantActions := []string{"todo_created", "title_changed", "todo_trashed", "todo_deleted"}
+	if len(entries) != len(wantActions) {
+		t.Fatalf("Expected %d entries, got %d", len(wantActions), len(entries))
+	}
+	for i, e := range entries {
+		if e.Action != wantActions[i] || e.Actor != "alice" || e.RequestID != "req-1" || e.Resource != domain.AuditResourceTodo || e.ResourceID != "1" {
+			t.Errorf("Unexpected entry %d: %+v", i, e)
+		}
+	}
+	diff := entries[1].Diff
+	if len(diff) != 1 || diff[0].Field != "title" || string(diff[0].From) != `"Buy milk"` || string(diff[0].To) != `"Buy oat milk"` {
+		t.Errorf("Unexpected diff: %+v", diff)
+	}
+	if _, err := log.Verify(context.Background()); err != nil {
+		t.Errorf("Expected log to verify, got %v", err)
+	}
+}
+
+func TestAuditedRepository_WithinTx(t *testing.T) {
+	// Given: 1つのトランザクションで2件作成する
+	repo, log := newTestRepository(t)
+	ctx := domain.WithActor(context.Background(), "alice")
+	err := repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		if err := tx.Create(ctx, &domain.Todo{Title: "First", Status: domain.StatusTodo}); err != nil {
+			return err
+		}
+		return tx.Create(ctx, &domain.Todo{Title: "Second", Status: domain.StatusTodo})
+	})
+	if err != nil {
+		t.Fatalf("Failed to run tx: %v", err)
+	}
+
+	// When:  エラーで終わるトランザクションを実行する
+	_ = repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		if err := tx.Delete(ctx, 1); err != nil {
+			return err
+		}
+		return errors.New("failed")
+	})
+
+	// Then:  コミットされた2件だけが Todo ごとに記録される
+	entries := queryAll(t, log)
+	if len(entries) != 2 || entries[0].ResourceID != "1" || entries[1].ResourceID != "2" {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+}
+
+type failingLog struct {
+	domain.IAuditLog
+}
+
+func (failingLog) Append(ctx context.Context, entries []*domain.AuditEntry) error {
+	return errors.New("disk full")
+}
+
+func TestAuditedRepository_AppendFailure(t *testing.T) {
+	// Given: 記録に失敗する監査ログと、1件の Todo
+	path := filepath.Join(t.TempDir(), "todos.json")
+	store := storage.NewFileRepository(path)
+	if err := store.Create(context.Background(), &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	repo := NewAuditedRepository(store, failingLog{}, domain.AuditResourceTodo)
+
+	// When:  削除する
+	err := repo.Delete(context.Background(), 1)
+
+	// Then:  エラーになり、記録されなかった削除は保存されない
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if _, err := store.FindByID(context.Background(), 1); err != nil {
+		t.Errorf("Expected todo to remain, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package audit
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// AuditedViewRepository は保存したビューへの変更を監査ログに記録するデコレータ。
+// ビューのリポジトリにはトランザクションがないため、変更を保存した後で記録する。
+// 記録に失敗しても変更は保存済みなので、エラーは返さずにログに残す。
+type AuditedViewRepository struct {
+	domain.IViewRepository
+	log domain.IAuditLog
+}
+
+func NewAuditedViewRepository(repo domain.IViewRepository, log domain.IAuditLog) *AuditedViewRepository {
+	return &AuditedViewRepository{IViewRepository: repo, log: log}
+}
+
+func (r *AuditedViewRepository) Create(ctx context.Context, view *domain.View) error {
+	if err := r.IViewRepository.Create(ctx, view); err != nil {
+		return err
+	}
+	r.record(ctx, view.ID, domain.AuditActionViewCreated, nil, view)
+	return nil
+}
+
+func (r *AuditedViewRepository) Update(ctx context.Context, view *domain.View) error {
+	before, err := r.IViewRepository.FindByID(ctx, view.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.IViewRepository.Update(ctx, view); err != nil {
+		return err
+	}
+	r.record(ctx, view.ID, domain.AuditActionViewUpdated, before, view)
+	return nil
+}
+
+func (r *AuditedViewRepository) Delete(ctx context.Context, id string) error {
+	before, err := r.IViewRepository.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.IViewRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.record(ctx, id, domain.AuditActionViewDeleted, before, nil)
+	return nil
+}
+
+func (r *AuditedViewRepository) record(ctx context.Context, id, action string, before, after *domain.View) {
+	diff, err := domain.DiffJSON(before, after)
+	if err == nil {
+		err = r.log.Append(ctx, []*domain.AuditEntry{newEntry(ctx, time.Now(), domain.AuditResourceView, id, action, diff)})
+	}
+	if err != nil {
+		log.Printf("Failed to record audit entry for view %s: %v", id, err)
+	}
+}
@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+)
+
+func TestAuditedViewRepository(t *testing.T) {
+	// Given: 監査ログを記録するビューのリポジトリ
+	dir := t.TempDir()
+	log := storage.NewFileAuditLog(filepath.Join(dir, "audit.jsonl"))
+	repo := NewAuditedViewRepository(storage.NewFileViewRepository(filepath.Join(dir, "views.json")), log)
+	ctx := domain.WithActor(context.Background(), "alice")
+
+	// When:  作成・更新・削除を行う
+	view := &domain.View{Name: "Work", Query: domain.ListQuery{Filter: "status:open"}}
+	if err := repo.Create(ctx, view); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	updated := *view
+	updated.Name = "Office"
+	if err := repo.Update(ctx, &updated); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	if err := repo.Delete(ctx, view.ID); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+
+	// Then:  それぞれの操作と差分が記録される
+	entries, err := log.Query(ctx, domain.AuditQuery{Resource: domain.AuditResourceView})
+	if err != nil {
+		t.Fatalf("Failed to query: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[2].Action != domain.AuditActionViewCreated || entries[1].Action != domain.AuditActionViewUpdated || entries[0].Action != domain.AuditActionViewDeleted {
+		t.Errorf("Unexpected actions: %s, %s, %s", entries[2].Action, entries[1].Action, entries[0].Action)
+	}
+	diff := entries[1].Diff
+	if len(diff) != 1 || diff[0].Field != "name" || string(diff[0].To) != `"Office"` {
+		t.Errorf("Unexpected diff: %+v", diff)
+	}
+}
+
+func TestAuditedViewRepository_AppendFailure(t *testing.T) {
+	// Given: 記録に失敗する監査ログ
+	views := storage.NewFileViewRepository(filepath.Join(t.TempDir(), "views.json"))
+	repo := NewAuditedViewRepository(views, failingLog{})
+	ctx := context.Background()
+
+	// When:  ビューを作成する
+	view := &domain.View{Name: "Work", Query: domain.ListQuery{Filter: "status:open"}}
+	err := repo.Create(ctx, view)
+
+	// Then:  保存済みの変更はエラーにならない
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := views.FindByID(ctx, view.ID); err != nil {
+		t.Errorf("Expected view to be saved, got %v", err)
+	}
+}
@@ -0,0 +1,380 @@
+// Package cli は todo コマンドの実装。cmd/todo から Run を呼び出す。
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/tui"
+	"github.com/k98a73/go-todo/pkg/client"
+)
+
+// 終了コード。API のエラーはステータスコードの種類ごとに分け、スクリプトから原因を区別できるようにする。
+const (
+	ExitOK       = 0
+	ExitError    = 1
+	ExitUsage    = 2
+	ExitNotFound = 3
+	ExitInvalid  = 4
+	ExitServer   = 5
+)
+
+const usage = `Usage: todo [global flags] <command> [flags] [args]
+
+Commands:
+  add <title>              create a todo
+  ls                       list todos (-q filter, -sort order, -limit n)
+  show <id>                show a todo
+  edit <id> -title <title> change the title of a todo
+  done <id>                mark a todo as done
+  undone <id>              mark a todo as not done
+  rm <id>                  move a todo to the trash
+  tui                      open the interactive terminal UI (-refresh interval)
+
+Global flags:
+  -config <path>   config file (default: $TODO_CONFIG or <user config dir>/todo/config.json)
+  -server <url>    server URL (overrides config and $TODO_SERVER)
+  -token <token>   API token (overrides config and $TODO_TOKEN)
+  -file <path>     operate directly on a todo file (e.g. todos.json) instead of a server;
+                   the server using that file must be stopped
+  -o <format>      output format: table, json or plain (default: table)
+`
+
+// usageError は引数の誤り。使い方を表示して ExitUsage で終了する。
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// backend は todo コマンドの操作先。サーバーの API（*client.Client）か、ローカルのファイル（localBackend）。
+type backend interface {
+	CreateTodo(ctx context.Context, title string) (*client.Todo, error)
+	ListTodos(ctx context.Context, opts client.ListOptions) ([]*client.Todo, error)
+	GetTodo(ctx context.Context, id int) (*client.Todo, error)
+	UpdateTodo(ctx context.Context, id int, title string, completed bool) (*client.Todo, error)
+	DeleteTodo(ctx context.Context, id int) error
+	ChangeStatus(ctx context.Context, id int, status string) (*client.Todo, error)
+}
+
+type app struct {
+	backend backend
+	format  OutputFormat
+	stdout  io.Writer
+}
+
+// Run は args（プログラム名を除く）を実行し、終了コードを返す。
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	err := run(ctx, args, stdout)
+	if err == nil {
+		return ExitOK
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprint(stderr, usage)
+		return ExitOK
+	}
+
+	fmt.Fprintf(stderr, "todo: %v\n", err)
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		fmt.Fprint(stderr, usage)
+	}
+	return exitCode(err)
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	global := flag.NewFlagSet("todo", flag.ContinueOnError)
+	global.SetOutput(io.Discard)
+	configPath := global.String("config", DefaultConfigPath(), "")
+	server := global.String("server", "", "")
+	token := global.String("token", "", "")
+	file := global.String("file", "", "")
+	format := global.String("o", string(OutputTable), "")
+	if err := global.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &usageError{msg: err.Error()}
+	}
+	if global.NArg() == 0 {
+		return &usageError{msg: "no command given"}
+	}
+
+	config, err := LoadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	a := &app{format: OutputFormat(*format), stdout: stdout}
+	if *file != "" {
+		local, release, err := openLocalBackend(*file)
+		if err != nil {
+			return err
+		}
+		defer release()
+		a.backend = local
+		ctx = domain.WithActor(ctx, localActor(config))
+	} else {
+		if *server != "" {
+			config.Server = *server
+		}
+		if *token != "" {
+			config.Token = *token
+		}
+
+		c := client.New(config.Server)
+		c.Token = config.Token
+		c.Actor = config.Actor
+		a.backend = c
+	}
+	if !a.format.valid() {
+		return &usageError{msg: fmt.Sprintf("unknown output format %q", *format)}
+	}
+
+	command, commandArgs := global.Arg(0), global.Args()[1:]
+	switch command {
+	case "add":
+		return a.add(ctx, commandArgs)
+	case "ls":
+		return a.list(ctx, commandArgs)
+	case "show":
+		return a.show(ctx, commandArgs)
+	case "edit":
+		return a.edit(ctx, commandArgs)
+	case "done":
+		return a.changeStatus(ctx, command, "done", commandArgs)
+	case "undone":
+		return a.changeStatus(ctx, command, "todo", commandArgs)
+	case "rm":
+		return a.remove(ctx, commandArgs)
+	case "tui":
+		return a.tui(ctx, commandArgs)
+	case "help":
+		return flag.ErrHelp
+	default:
+		return &usageError{msg: fmt.Sprintf("unknown command %q", command)}
+	}
+}
+
+// newFlagSet はサブコマンドのフラグを作る。-o はグローバルと同じくサブコマンドの後にも書ける。
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Func("o", "", func(v string) error {
+		a.format = OutputFormat(v)
+		if !a.format.valid() {
+			return fmt.Errorf("unknown output format %q", v)
+		}
+		return nil
+	})
+	return fs
+}
+
+// parseFlags は引数の間に書かれたフラグも読み（todo done 3 -o json）、残りの引数を返す。
+// "--" より後はすべて引数として扱う。
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, &usageError{msg: fmt.Sprintf("%s: %v", fs.Name(), err)}
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// parseID はサブコマンドの唯一の引数を ID として読む。
+func parseID(command string, args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, &usageError{msg: fmt.Sprintf("%s: expected exactly one todo ID", command)}
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id < 1 {
+		return 0, &usageError{msg: fmt.Sprintf("%s: invalid todo ID %q", command, args[0])}
+	}
+	return id, nil
+}
+
+func (a *app) add(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("add")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	title := strings.Join(args, " ")
+	if title == "" {
+		return &usageError{msg: "add: title is required"}
+	}
+
+	todo, err := a.backend.CreateTodo(ctx, title)
+	if err != nil {
+		return err
+	}
+	return printTodo(a.stdout, a.format, todo)
+}
+
+func (a *app) list(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("ls")
+	var opts client.ListOptions
+	fs.StringVar(&opts.Query, "q", "", "")
+	fs.StringVar(&opts.Sort, "sort", "", "")
+	fs.IntVar(&opts.Limit, "limit", 0, "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return &usageError{msg: "ls: unexpected arguments"}
+	}
+
+	todos, err := a.backend.ListTodos(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return printTodos(a.stdout, a.format, todos)
+}
+
+func (a *app) show(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("show")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(fs.Name(), args)
+	if err != nil {
+		return err
+	}
+
+	todo, err := a.backend.GetTodo(ctx, id)
+	if err != nil {
+		return err
+	}
+	return printTodo(a.stdout, a.format, todo)
+}
+
+// edit は PUT が完了状態も必要とするため、現在の状態を読んでからタイトルだけを変える。
+func (a *app) edit(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("edit")
+	title := fs.String("title", "", "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(fs.Name(), args)
+	if err != nil {
+		return err
+	}
+	if *title == "" {
+		return &usageError{msg: "edit: -title is required"}
+	}
+
+	current, err := a.backend.GetTodo(ctx, id)
+	if err != nil {
+		return err
+	}
+	todo, err := a.backend.UpdateTodo(ctx, id, *title, current.Completed)
+	if err != nil {
+		return err
+	}
+	return printTodo(a.stdout, a.format, todo)
+}
+
+func (a *app) changeStatus(ctx context.Context, command, status string, args []string) error {
+	fs := a.newFlagSet(command)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(fs.Name(), args)
+	if err != nil {
+		return err
+	}
+
+	todo, err := a.backend.ChangeStatus(ctx, id, status)
+	if err != nil {
+		return err
+	}
+	return printTodo(a.stdout, a.format, todo)
+}
+
+func (a *app) remove(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("rm")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(fs.Name(), args)
+	if err != nil {
+		return err
+	}
+
+	if err := a.backend.DeleteTodo(ctx, id); err != nil {
+		return err
+	}
+	return printMessage(a.stdout, a.format, fmt.Sprintf("Moved todo %d to the trash", id), map[string]any{"id": id, "deleted": true})
+}
+
+// tui は端末 UI を開く。端末でなければ raw モードにできずエラーになる。
+func (a *app) tui(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("tui")
+	refresh := fs.Duration("refresh", tui.DefaultRefresh, "")
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return &usageError{msg: "tui: unexpected arguments"}
+	}
+	if *refresh <= 0 {
+		return &usageError{msg: "tui: -refresh must be positive"}
+	}
+
+	return tui.Run(ctx, a.backend, os.Stdin, a.stdout, *refresh)
+}
+
+// exitCode は API のエラーをステータスコードで、ローカルモードのエラーをサーバーが返すステータスコードと同じ分類で終了コードにする。
+func exitCode(err error) int {
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return ExitUsage
+	}
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusNotFound:
+			return ExitNotFound
+		case apiErr.StatusCode >= 500:
+			return ExitServer
+		default:
+			return ExitInvalid
+		}
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrTodoNotFound):
+		return ExitNotFound
+	case errors.Is(err, domain.ErrInvalidFilter), errors.Is(err, domain.ErrInvalidSort), errors.Is(err, domain.ErrInvalidLimit),
+		errors.Is(err, domain.ErrInvalidStatus), errors.Is(err, domain.ErrInvalidTransition),
+		err.Error() == "title cannot be empty", err.Error() == "title too long":
+		return ExitInvalid
+	default:
+		return ExitError
+	}
+}
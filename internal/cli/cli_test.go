@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	http_infra "github.com/k98a73/go-todo/internal/infra/http"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+	"github.com/k98a73/go-todo/internal/usecase"
+	"github.com/k98a73/go-todo/pkg/client"
+)
+
+// newTestServer は一時ファイルに保存する本物のハンドラーでサーバーを起動する。
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return newFileServer(t, filepath.Join(t.TempDir(), "todos.json"))
+}
+
+// newFileServer は file に保存するサーバーを起動する。
+func newFileServer(t *testing.T, file string) *httptest.Server {
+	t.Helper()
+	repo := storage.NewFileRepository(file)
+	machine := domain.DefaultStateMachine()
+	todoHandler := http_infra.NewTodoHandler(
+		usecase.NewCreateTodoUsecase(repo, machine),
+		usecase.NewListTodoUsecase(repo, machine),
+		usecase.NewFindByIDTodoUsecase(repo),
+		usecase.NewUpdateTodoUsecase(repo, machine),
+		usecase.NewDeleteTodoUsecase(repo),
+	)
+	statusHandler := http_infra.NewStatusHandler(usecase.NewChangeStatusTodoUsecase(repo, machine))
+
+	v1 := todoHandler.V1()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/todos", v1.CreateTodo)
+	mux.HandleFunc("GET /v1/todos", v1.ListTodo)
+	mux.HandleFunc("GET /v1/todos/{id}", v1.FindByIDTodo)
+	mux.HandleFunc("PUT /v1/todos/{id}", v1.UpdateTodo)
+	mux.HandleFunc("DELETE /v1/todos/{id}", v1.DeleteTodo)
+	mux.HandleFunc("POST /todo/{id}/status", statusHandler.ChangeStatus)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// runCLI は設定ファイルを読まずに server に接続して実行する。
+func runCLI(t *testing.T, server *httptest.Server, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	base := []string{"-config", "", "-server", server.URL}
+	code := Run(context.Background(), append(base, args...), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Workflow(t *testing.T) {
+	// Given: 空のサーバー
+	server := newTestServer(t)
+
+	// When:  追加・編集・完了・一覧・削除を順に実行する
+	if code, _, stderr := runCLI(t, server, "add", "Buy", "milk"); code != ExitOK {
+		t.Fatalf("add failed with %d: %s", code, stderr)
+	}
+	if code, _, stderr := runCLI(t, server, "add", "Read book"); code != ExitOK {
+		t.Fatalf("add failed with %d: %s", code, stderr)
+	}
+	code, stdout, _ := runCLI(t, server, "-o", "json", "edit", "-title", "Buy oat milk", "1")
+	var edited client.Todo
+	if code != ExitOK || json.Unmarshal([]byte(stdout), &edited) != nil || edited.Title != "Buy oat milk" {
+		t.Fatalf("Unexpected edit result %d: %s", code, stdout)
+	}
+	if code, stdout, _ := runCLI(t, server, "done", "1", "-o", "plain"); code != ExitOK || stdout != "1\tdone\tBuy oat milk\n" {
+		t.Errorf("Unexpected done result %d: %q", code, stdout)
+	}
+	if code, stdout, _ := runCLI(t, server, "undone", "-o", "plain", "1"); code != ExitOK || stdout != "1\ttodo\tBuy oat milk\n" {
+		t.Errorf("Unexpected undone result %d: %q", code, stdout)
+	}
+	if code, _, _ := runCLI(t, server, "rm", "2"); code != ExitOK {
+		t.Errorf("rm failed with %d", code)
+	}
+	code, stdout, _ = runCLI(t, server, "ls")
+
+	// Then:  ゴミ箱に移動していない Todo だけが表で表示される
+	if code != ExitOK {
+		t.Fatalf("ls failed with %d", code)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[1], "Buy oat milk") {
+		t.Errorf("Unexpected table:\n%s", stdout)
+	}
+}
+
+func TestRun_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{name: "help", args: []string{"help"}, wantCode: ExitOK},
+		{name: "no command", args: nil, wantCode: ExitUsage},
+		{name: "unknown command", args: []string{"frobnicate"}, wantCode: ExitUsage},
+		{name: "missing title", args: []string{"add"}, wantCode: ExitUsage},
+		{name: "invalid id", args: []string{"show", "abc"}, wantCode: ExitUsage},
+		{name: "unknown format", args: []string{"-o", "xml", "ls"}, wantCode: ExitUsage},
+		{name: "not found", args: []string{"show", "99"}, wantCode: ExitNotFound},
+		{name: "invalid filter", args: []string{"ls", "-q", "status:"}, wantCode: ExitInvalid},
+	}
+
+	server := newTestServer(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runCLI(t, server, tt.args...)
+
+			if code != tt.wantCode {
+				t.Errorf("Expected exit code %d, got %d: %s", tt.wantCode, code, stderr)
+			}
+		})
+	}
+}
+
+func TestRun_ServerErrors(t *testing.T) {
+	// Given: 常に 500 を返すサーバーと、停止したサーバー
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	stopped := httptest.NewServer(http.NotFoundHandler())
+	stopped.Close()
+
+	// When:  一覧を取得する
+	failingCode, _, _ := runCLI(t, failing, "ls")
+	stoppedCode, _, stderr := runCLI(t, stopped, "ls")
+
+	// Then:  サーバーのエラーと通信エラーが区別される
+	if failingCode != ExitServer {
+		t.Errorf("Expected %d, got %d", ExitServer, failingCode)
+	}
+	if stoppedCode != ExitError || !strings.HasPrefix(stderr, "todo: ") {
+		t.Errorf("Expected %d with message, got %d: %s", ExitError, stoppedCode, stderr)
+	}
+}
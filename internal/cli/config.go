@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+const DefaultServer = "http://localhost:8080"
+
+// Config は接続先の設定。設定ファイル・環境変数・フラグの順に後のものが優先される。
+type Config struct {
+	Server string `json:"server"`
+	Token  string `json:"token"`
+	Actor  string `json:"actor"`
+}
+
+// DefaultConfigPath は TODO_CONFIG、なければユーザー設定ディレクトリの todo/config.json を返す。
+func DefaultConfigPath() string {
+	if path := os.Getenv("TODO_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "todo", "config.json")
+}
+
+// LoadConfig は path の設定ファイルを読み、環境変数で上書きする。ファイルがなければ既定値を使う。
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{Server: DefaultServer}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, config); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if v := os.Getenv("TODO_SERVER"); v != "" {
+		config.Server = v
+	}
+	if v := os.Getenv("TODO_TOKEN"); v != "" {
+		config.Token = v
+	}
+	if v := os.Getenv("TODO_ACTOR"); v != "" {
+		config.Actor = v
+	}
+	return config, nil
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	// Given: 設定ファイルと、接続先を上書きする環境変数
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"server":"http://todo.example.com","token":"file-token","actor":"alice"}`), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	t.Setenv("TODO_SERVER", "http://localhost:9090")
+	t.Setenv("TODO_TOKEN", "")
+	t.Setenv("TODO_ACTOR", "")
+
+	// When:  設定を読み込む
+	config, err := LoadConfig(path)
+
+	// Then:  環境変数が設定ファイルより優先される
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := Config{Server: "http://localhost:9090", Token: "file-token", Actor: "alice"}
+	if *config != want {
+		t.Errorf("Expected %+v, got %+v", want, *config)
+	}
+}
+
+func TestLoadConfig_Missing(t *testing.T) {
+	t.Setenv("TODO_SERVER", "")
+
+	config, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json"))
+
+	if err != nil || config.Server != DefaultServer {
+		t.Errorf("Expected default config, got %+v, %v", config, err)
+	}
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/k98a73/go-todo/internal/audit"
+	"github.com/k98a73/go-todo/internal/delta"
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+	"github.com/k98a73/go-todo/internal/usecase"
+	"github.com/k98a73/go-todo/pkg/client"
+)
+
+// localBackend はサーバーを介さずに Todo のファイルを直接操作する。サーバーと同じ usecase を使うため検証も同じになる。
+// 監査ログはサーバーと同じファイルに記録するが、検索インデックス・SSE・Webhook には変更が伝わらないため、同じファイルを使うサーバーが動いている間は使えない。
+type localBackend struct {
+	create       *usecase.CreateTodoUsecase
+	list         *usecase.ListTodoUsecase
+	findByID     *usecase.FindByIDTodoUsecase
+	update       *usecase.UpdateTodoUsecase
+	del          *usecase.DeleteTodoUsecase
+	changeStatus *usecase.ChangeStatusTodoUsecase
+}
+
+// openLocalBackend は file を占有してから newLocalBackend を返す。返す関数で占有を解除する。
+// サーバーが file を使っていれば、サーバーを止めるか -file を付けずにサーバー経由で操作するよう促すエラーを返す。
+func openLocalBackend(file string) (*localBackend, func(), error) {
+	release, err := storage.ClaimFile(file)
+	if errors.Is(err, storage.ErrFileInUse) {
+		return nil, nil, fmt.Errorf("%s is in use by a running server; stop the server or omit -file to go through it", file)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return newLocalBackend(file), release, nil
+}
+
+// defaultLocalActor は設定ファイルにも環境変数にも利用者がないとき、ファイルを直接操作した記録に残す利用者。
+const defaultLocalActor = "local"
+
+// localActor は監査ログに残す利用者を返す。サーバー経由で X-Actor に送るのと同じ config.Actor を使う。
+func localActor(config *Config) string {
+	if config.Actor != "" {
+		return config.Actor
+	}
+	return defaultLocalActor
+}
+
+// newLocalBackend は file の Todo を操作する。サーバーと同じく、同じディレクトリの sync.json に変更番号を振り、audit.jsonl に監査ログを残す。
+func newLocalBackend(file string) *localBackend {
+	dir := filepath.Dir(file)
+	index := storage.NewFileChangeIndex(filepath.Join(dir, "sync.json"))
+	auditLog := storage.NewFileAuditLog(filepath.Join(dir, "audit.jsonl"))
+	repo := audit.NewAuditedRepository(
+		delta.NewTrackingRepository(storage.NewFileRepository(file), index),
+		auditLog, domain.AuditResourceTodo,
+	)
+	machine := domain.DefaultStateMachine()
+	return &localBackend{
+		create:       usecase.NewCreateTodoUsecase(repo, machine),
+		list:         usecase.NewListTodoUsecase(repo, machine),
+		findByID:     usecase.NewFindByIDTodoUsecase(repo),
+		update:       usecase.NewUpdateTodoUsecase(repo, machine),
+		del:          usecase.NewDeleteTodoUsecase(repo),
+		changeStatus: usecase.NewChangeStatusTodoUsecase(repo, machine),
+	}
+}
+
+func (b *localBackend) CreateTodo(ctx context.Context, title string) (*client.Todo, error) {
+	return toClientTodo(b.create.Execute(ctx, title))
+}
+
+func (b *localBackend) ListTodos(ctx context.Context, opts client.ListOptions) ([]*client.Todo, error) {
+	todos, err := b.list.Execute(ctx, domain.ListQuery{Filter: opts.Query, Sort: domain.SortOrder(opts.Sort), Limit: opts.Limit})
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*client.Todo, len(todos))
+	for i, todo := range todos {
+		if list[i], err = toClientTodo(todo, nil); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func (b *localBackend) GetTodo(ctx context.Context, id int) (*client.Todo, error) {
+	return toClientTodo(b.findByID.Execute(ctx, id))
+}
+
+func (b *localBackend) UpdateTodo(ctx context.Context, id int, title string, completed bool) (*client.Todo, error) {
+	return toClientTodo(b.update.Execute(ctx, id, title, completed))
+}
+
+func (b *localBackend) DeleteTodo(ctx context.Context, id int) error {
+	return b.del.Execute(ctx, id)
+}
+
+func (b *localBackend) ChangeStatus(ctx context.Context, id int, status string) (*client.Todo, error) {
+	return toClientTodo(b.changeStatus.Execute(ctx, id, domain.Status(status)))
+}
+
+// toClientTodo は API のレスポンスと同じ JSON を経由して変換し、サーバーに接続したときと同じ出力にする。
+func toClientTodo(todo *domain.Todo, err error) (*client.Todo, error) {
+	if err != nil {
+		return nil, err
+	}
+	if todo == nil {
+		return nil, domain.ErrTodoNotFound
+	}
+	data, err := json.Marshal(todo)
+	if err != nil {
+		return nil, err
+	}
+	var c client.Todo
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+	"github.com/k98a73/go-todo/pkg/client"
+)
+
+// runLocal は file を直接操作するモードで実行する。
+func runLocal(t *testing.T, file string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := Run(context.Background(), append([]string{"-file", file}, args...), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Local(t *testing.T) {
+	// Given: 空の Todo のファイル
+	file := filepath.Join(t.TempDir(), "todos.json")
+
+	// When:  ファイルを直接操作して追加・完了し、JSON で表示する
+	if code, _, stderr := runLocal(t, file, "add", "Buy milk"); code != ExitOK {
+		t.Fatalf("add failed with %d: %s", code, stderr)
+	}
+	if code, _, stderr := runLocal(t, file, "done", "1"); code != ExitOK {
+		t.Fatalf("done failed with %d: %s", code, stderr)
+	}
+	code, stdout, _ := runLocal(t, file, "-o", "json", "show", "1")
+
+	// Then:  サーバーと同じ形式で出力され、同期用の変更番号も振られる
+	var todo client.Todo
+	if code != ExitOK || json.Unmarshal([]byte(stdout), &todo) != nil {
+		t.Fatalf("Unexpected show result %d: %s", code, stdout)
+	}
+	if todo.Status != "done" || !todo.Completed || todo.CompletedAt == nil {
+		t.Errorf("Unexpected todo: %+v", todo)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(file), "sync.json")); err != nil {
+		t.Errorf("Expected sync.json, got %v", err)
+	}
+}
+
+func TestRun_Local_Audit(t *testing.T) {
+	// Given: 空の Todo のファイルと、環境変数で指定した利用者
+	dir := t.TempDir()
+	file := filepath.Join(dir, "todos.json")
+	t.Setenv("TODO_ACTOR", "alice")
+
+	// When:  ファイルを直接操作して追加・完了する
+	for _, args := range [][]string{{"add", "Buy milk"}, {"done", "1"}} {
+		args = append([]string{"-config", filepath.Join(dir, "config.json")}, args...)
+		if code, _, stderr := runLocal(t, file, args...); code != ExitOK {
+			t.Fatalf("%v failed with %d: %s", args, code, stderr)
+		}
+	}
+
+	// Then:  サーバーと同じ audit.jsonl に利用者付きで記録され、連鎖の検証に成功する
+	auditLog := storage.NewFileAuditLog(filepath.Join(dir, "audit.jsonl"))
+	entries, err := auditLog.Query(context.Background(), domain.AuditQuery{})
+	if err != nil {
+		t.Fatalf("Failed to query: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	for _, e := range entries {
+		if e.Actor != "alice" || e.Resource != domain.AuditResourceTodo || e.ResourceID != "1" {
+			t.Errorf("Unexpected entry: %+v", e)
+		}
+	}
+	if _, err := auditLog.Verify(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestRun_Local_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+	}{
+		{name: "not found", args: []string{"show", "99"}, wantCode: ExitNotFound},
+		{name: "invalid transition", args: []string{"done", "2"}, wantCode: ExitInvalid},
+		{name: "title too long", args: []string{"edit", "-title", string(bytes.Repeat([]byte("a"), 256)), "1"}, wantCode: ExitInvalid},
+		{name: "invalid filter", args: []string{"ls", "-q", "status:"}, wantCode: ExitInvalid},
+	}
+
+	// Given: 未完了の Todo と中止した Todo
+	file := filepath.Join(t.TempDir(), "todos.json")
+	runLocal(t, file, "add", "Buy milk")
+	runLocal(t, file, "add", "Old task")
+	server := newFileServer(t, file)
+	if _, err := client.New(server.URL).ChangeStatus(context.Background(), 2, "cancelled"); err != nil {
+		t.Fatalf("Failed to cancel: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runLocal(t, file, tt.args...)
+
+			if code != tt.wantCode {
+				t.Errorf("Expected exit code %d, got %d: %s", tt.wantCode, code, stderr)
+			}
+		})
+	}
+}
+
+func TestRun_Local_WithRunningServer(t *testing.T) {
+	// Given: 同じファイルを占有しているサーバー
+	file := filepath.Join(t.TempDir(), "todos.json")
+	release, err := storage.ClaimFile(file)
+	if err != nil {
+		t.Fatalf("Failed to claim: %v", err)
+	}
+
+	// When:  ローカルモードで追加する
+	code, _, stderr := runLocal(t, file, "add", "Buy milk")
+
+	// Then:  サーバーを止めるよう促して失敗し、ファイルは変更されない
+	if code != ExitError || !strings.Contains(stderr, "running server") {
+		t.Errorf("Expected exit code %d with running server error, got %d: %s", ExitError, code, stderr)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("Expected file not to be written, got %v", err)
+	}
+
+	// When:  サーバーが止まってから追加する
+	release()
+	code, _, stderr = runLocal(t, file, "add", "Buy milk")
+
+	// Then:  追加できる
+	if code != ExitOK {
+		t.Errorf("Expected exit code %d, got %d: %s", ExitOK, code, stderr)
+	}
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/k98a73/go-todo/pkg/client"
+)
+
+type OutputFormat string
+
+const (
+	// OutputTable は見出し付きの表。人が読むための既定の形式。
+	OutputTable OutputFormat = "table"
+	OutputJSON  OutputFormat = "json"
+	// OutputPlain は見出しのないタブ区切りで、1行に1件を出力する。シェルスクリプトで扱うための形式。
+	OutputPlain OutputFormat = "plain"
+)
+
+func (f OutputFormat) valid() bool {
+	return f == OutputTable || f == OutputJSON || f == OutputPlain
+}
+
+func printTodos(w io.Writer, format OutputFormat, todos []*client.Todo) error {
+	switch format {
+	case OutputJSON:
+		if todos == nil {
+			todos = []*client.Todo{}
+		}
+		return printJSON(w, todos)
+	case OutputPlain:
+		for _, todo := range todos {
+			if _, err := fmt.Fprintf(w, "%d\t%s\t%s\n", todo.ID, todo.Status, todo.Title); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tSTATUS\tTITLE\tUPDATED")
+		for _, todo := range todos {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", todo.ID, todo.Status, todo.Title, todo.UpdatedAt.Local().Format("2006-01-02 15:04"))
+		}
+		return tw.Flush()
+	}
+}
+
+func printTodo(w io.Writer, format OutputFormat, todo *client.Todo) error {
+	if format == OutputJSON {
+		return printJSON(w, todo)
+	}
+	return printTodos(w, format, []*client.Todo{todo})
+}
+
+// printMessage は JSON では value を、それ以外では message を出力する。
+func printMessage(w io.Writer, format OutputFormat, message string, value any) error {
+	if format == OutputJSON {
+		return printJSON(w, value)
+	}
+	_, err := fmt.Fprintln(w, message)
+	return err
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
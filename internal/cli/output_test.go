@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/pkg/client"
+)
+
+func TestPrintTodos(t *testing.T) {
+	todos := []*client.Todo{
+		{ID: 1, Title: "Buy milk", Status: "todo", UpdatedAt: time.Date(2026, 1, 2, 3, 4, 0, 0, time.Local)},
+		{ID: 12, Title: "Read book", Status: "done", UpdatedAt: time.Date(2026, 1, 2, 3, 4, 0, 0, time.Local)},
+	}
+
+	tests := []struct {
+		name   string
+		format OutputFormat
+		todos  []*client.Todo
+		want   string
+	}{
+		{
+			name:   "table",
+			format: OutputTable,
+			todos:  todos,
+			want: "ID  STATUS  TITLE      UPDATED\n" +
+				"1   todo    Buy milk   2026-01-02 03:04\n" +
+				"12  done    Read book  2026-01-02 03:04\n",
+		},
+		{name: "plain", format: OutputPlain, todos: todos, want: "1\ttodo\tBuy milk\n12\tdone\tRead book\n"},
+		{name: "empty json", format: OutputJSON, todos: nil, want: "[]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := printTodos(&buf, tt.format, tt.todos); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if buf.String() != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestPrintMessage(t *testing.T) {
+	var text, data bytes.Buffer
+
+	printMessage(&text, OutputPlain, "Moved todo 3 to the trash", map[string]any{"id": 3})
+	printMessage(&data, OutputJSON, "Moved todo 3 to the trash", map[string]any{"id": 3})
+
+	if text.String() != "Moved todo 3 to the trash\n" {
+		t.Errorf("Unexpected text: %q", text.String())
+	}
+	if !strings.Contains(data.String(), `"id": 3`) {
+		t.Errorf("Unexpected JSON: %q", data.String())
+	}
+}
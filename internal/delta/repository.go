@@ -0,0 +1,59 @@
+package delta
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// TrackingRepository は repo の変更された Todo に変更番号を振るデコレータ。
+// 変更番号はコミット前にトランザクションの中で振る。コミットに失敗すると変更のない Todo の番号が進むが、
+// 同期するクライアントは現在の状態を受け取り直すだけなので問題ない。逆に番号を振り損ねると変更が伝わらない。
+type TrackingRepository struct {
+	domain.IRepository
+	index domain.IChangeIndex
+}
+
+func NewTrackingRepository(repo domain.IRepository, index domain.IChangeIndex) *TrackingRepository {
+	return &TrackingRepository{IRepository: repo, index: index}
+}
+
+func (r *TrackingRepository) Create(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Create(ctx, todo)
+	})
+}
+
+func (r *TrackingRepository) Insert(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Insert(ctx, todo)
+	})
+}
+
+func (r *TrackingRepository) Update(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Update(ctx, todo)
+	})
+}
+
+func (r *TrackingRepository) Delete(ctx context.Context, id int) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Delete(ctx, id)
+	})
+}
+
+func (r *TrackingRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	return r.IRepository.WithinTx(ctx, func(tx domain.IRepository) error {
+		rec := domain.NewChangeRecorder(tx)
+		if err := fn(rec); err != nil {
+			return err
+		}
+
+		changes := rec.Changes()
+		ids := make([]int, len(changes))
+		for i, c := range changes {
+			ids[i] = c.ID
+		}
+		return r.index.Bump(ctx, ids)
+	})
+}
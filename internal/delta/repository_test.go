@@ -0,0 +1,114 @@
+package delta
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+	"github.com/k98a73/go-todo/internal/usecase"
+)
+
+func newTestRepository(t *testing.T) (*TrackingRepository, *storage.FileChangeIndex) {
+	t.Helper()
+	dir := t.TempDir()
+	index := storage.NewFileChangeIndex(filepath.Join(dir, "sync.json"))
+	return NewTrackingRepository(storage.NewFileRepository(filepath.Join(dir, "todos.json")), index), index
+}
+
+func TestTrackingRepository_Bump(t *testing.T) {
+	// Given: 変更番号を振るリポジトリ
+	repo, index := newTestRepository(t)
+	ctx := context.Background()
+
+	// When:  作成・更新し、失敗するトランザクションで更新する
+	todo := &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}
+	if err := repo.Create(ctx, todo); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	todo.Title = "Buy oat milk"
+	if err := repo.Update(ctx, todo); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	failure := errors.New("failure")
+	err := repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		todo.Title = "Discarded"
+		if err := tx.Update(ctx, todo); err != nil {
+			return err
+		}
+		return failure
+	})
+
+	// Then:  コミットした変更にだけ番号が振られる
+	if !errors.Is(err, failure) {
+		t.Fatalf("Expected failure, got %v", err)
+	}
+	head, _ := index.Head(ctx)
+	if seq, _ := index.Version(ctx, todo.ID); head.Seq != 2 || seq != 2 {
+		t.Errorf("Expected seq 2, got head %d and todo %d", head.Seq, seq)
+	}
+}
+
+func TestTrackingRepository_Sync(t *testing.T) {
+	// Given: 初回の同期を済ませたクライアント
+	repo, index := newTestRepository(t)
+	ctx := context.Background()
+	syncUsecase := usecase.NewSyncTodoUsecase(repo, index, domain.DefaultStateMachine())
+	milk := &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}
+	book := &domain.Todo{Title: "Read book", Status: domain.StatusTodo}
+	for _, todo := range []*domain.Todo{milk, book} {
+		if err := repo.Create(ctx, todo); err != nil {
+			t.Fatalf("Failed to create: %v", err)
+		}
+	}
+	first, err := syncUsecase.Execute(ctx, "", nil)
+	if err != nil || len(first.Changes) != 2 {
+		t.Fatalf("Unexpected first sync: %+v, %v", first, err)
+	}
+
+	// When:  クライアントがオフラインの間にサーバー側で1件更新・1件削除し、
+	//        クライアントは古い番号を元に更新と作成を送る
+	bookSeq := first.Changes[1].Seq
+	book.Title = "Read paper"
+	if err := repo.Update(ctx, book); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	if err := repo.Delete(ctx, milk.ID); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+	second, err := syncUsecase.Execute(ctx, first.Token, []domain.SyncPush{
+		{BulkOperation: domain.BulkOperation{Op: domain.BulkUpdate, ID: book.ID, Title: "Read novel"}, BaseSeq: bookSeq},
+		{BulkOperation: domain.BulkOperation{Op: domain.BulkCreate, Title: "Call mom"}, ClientID: "tmp-1"},
+	})
+
+	// Then:  更新は衝突し、作成は適用され、削除は墓標として届く
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if r := second.Results[0]; r.Status != domain.SyncConflict || r.Todo.Title != "Read paper" {
+		t.Errorf("Unexpected conflict result: %+v", r)
+	}
+	created := second.Results[1]
+	if created.Status != domain.SyncApplied || created.ClientID != "tmp-1" || created.Seq == 0 {
+		t.Errorf("Unexpected create result: %+v", created)
+	}
+	if len(second.Changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %+v", second.Changes)
+	}
+	if c := second.Changes[1]; c.ID != milk.ID || !c.Deleted {
+		t.Errorf("Expected tombstone for %d, got %+v", milk.ID, c)
+	}
+	if c := second.Changes[2]; c.ID != created.ID || c.Seq != created.Seq {
+		t.Errorf("Expected created todo, got %+v", c)
+	}
+
+	// When:  新しいトークンでもう一度同期する
+	third, err := syncUsecase.Execute(ctx, second.Token, nil)
+
+	// Then:  変更はない
+	if err != nil || len(third.Changes) != 0 || third.Token != second.Token {
+		t.Errorf("Expected no changes, got %+v, %v", third, err)
+	}
+}
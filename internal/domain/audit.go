@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
+const (
+	AuditResourceTodo    = "todo"
+	AuditResourceArchive = "archive"
+	AuditResourceView    = "view"
+
+	AuditActionViewCreated = "view_created"
+	AuditActionViewUpdated = "view_updated"
+	AuditActionViewDeleted = "view_deleted"
+
+	DefaultAuditLimit = 100
+)
+
+var (
+	ErrInvalidAuditQuery = errors.New("invalid audit query")
+	ErrAuditTampered     = errors.New("audit log has been tampered with")
+)
+
+// AuditEntry は監査ログの1件。誰が（Actor）どのリクエストで（RequestID）何を（Resource / ResourceID）
+// どう変えたか（Action / Diff）を記録する。
+// Hash は PrevHash を含むこのエントリ全体のハッシュで、前のエントリと鎖のようにつながる。
+// そのため途中のエントリを書き換えたり削除したりすると、以降の検証に失敗する。
+type AuditEntry struct {
+	Seq        int64         `json:"seq"`
+	At         time.Time     `json:"at"`
+	Actor      string        `json:"actor"`
+	RequestID  string        `json:"request_id,omitempty"`
+	Resource   string        `json:"resource"`
+	ResourceID string        `json:"resource_id"`
+	Action     string        `json:"action"`
+	Diff       []FieldChange `json:"diff"`
+	PrevHash   string        `json:"prev_hash"`
+	Hash       string        `json:"hash"`
+}
+
+// FieldChange は JSON のフィールド1つの変更前後の値。存在しない値は省略する。
+type FieldChange struct {
+	Field string          `json:"field"`
+	From  json.RawMessage `json:"from,omitempty"`
+	To    json.RawMessage `json:"to,omitempty"`
+}
+
+// ComputeHash は Hash を除いたエントリの SHA-256 を返す。
+func (e *AuditEntry) ComputeHash() (string, error) {
+	c := *e
+	c.Hash = ""
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// DiffJSON は before と after を JSON にしたときに値が異なるフィールドを、フィールド名の順に返す。
+// 作成や削除のように一方が nil の場合は、もう一方のすべてのフィールドを返す。
+func DiffJSON(before, after any) ([]FieldChange, error) {
+	from, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(from)+len(to))
+	for name := range from {
+		names = append(names, name)
+	}
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diff := []FieldChange{}
+	for _, name := range names {
+		if !bytes.Equal(from[name], to[name]) {
+			diff = append(diff, FieldChange{Field: name, From: from[name], To: to[name]})
+		}
+	}
+	return diff, nil
+}
+
+func jsonFields(v any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if string(data) == "null" {
+		return fields, nil
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	// 比較できるように空白を取り除いた形にそろえる
+	for name, value := range fields {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, value); err != nil {
+			return nil, err
+		}
+		fields[name] = buf.Bytes()
+	}
+	return fields, nil
+}
+
+// TodoAuditAction は Todo の変更を表す操作名を返す。変更が複数の種類にまたがる場合は todo_updated。
+func TodoAuditAction(c Change, now time.Time) string {
+	switch {
+	case c.Before == nil:
+		return string(EventTodoCreated)
+	case c.After == nil:
+		return string(EventTodoDeleted)
+	}
+	events := ChangeEvents(c.Before, c.After, now)
+	if len(events) == 1 {
+		return string(events[0].Type)
+	}
+	return string(EventTodoUpdated)
+}
+
+// AuditQuery は監査ログの絞り込み条件。空の条件は絞り込まない。
+// Since / Until は At がその範囲（Until は含まない）にあるものを表す。Limit が 0 の場合は DefaultAuditLimit 件。
+type AuditQuery struct {
+	Actor      string
+	RequestID  string
+	Resource   string
+	ResourceID string
+	Action     string
+	Since      *time.Time
+	Until      *time.Time
+	Limit      int
+}
+
+func ValidateAuditQuery(q AuditQuery) error {
+	if q.Limit < 0 {
+		return ErrInvalidAuditQuery
+	}
+	if q.Since != nil && q.Until != nil && !q.Since.Before(*q.Until) {
+		return ErrInvalidAuditQuery
+	}
+	return nil
+}
+
+// Match は e が q の条件に一致するかを返す。Limit は考慮しない。
+func (q AuditQuery) Match(e *AuditEntry) bool {
+	switch {
+	case q.Actor != "" && e.Actor != q.Actor,
+		q.RequestID != "" && e.RequestID != q.RequestID,
+		q.Resource != "" && e.Resource != q.Resource,
+		q.ResourceID != "" && e.ResourceID != q.ResourceID,
+		q.Action != "" && e.Action != q.Action,
+		q.Since != nil && e.At.Before(*q.Since),
+		q.Until != nil && !e.At.Before(*q.Until):
+		return false
+	}
+	return true
+}
+
+type IAuditLog interface {
+	// Append は entries に Seq・PrevHash・Hash を設定して追記する。
+	Append(ctx context.Context, entries []*AuditEntry) error
+	// Query は条件に一致するエントリを新しい順に最大 q.Limit 件返す。
+	Query(ctx context.Context, q AuditQuery) ([]*AuditEntry, error)
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDiffJSON(t *testing.T) {
+	// Given: タイトルとステータスが異なる2つの Todo
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := &Todo{ID: 1, Title: "Buy milk", Status: StatusTodo, CreatedAt: created}
+	after := &Todo{ID: 1, Title: "Buy eggs", Status: StatusInProgress, CreatedAt: created}
+
+	// When:  差分を求める
+	diff, err := DiffJSON(before, after)
+
+	// Then:  異なるフィールドだけがフィールド名の順に返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(diff) != 2 || diff[0].Field != "status" || diff[1].Field != "title" {
+		t.Fatalf("Unexpected diff: %+v", diff)
+	}
+	if string(diff[1].From) != `"Buy milk"` || string(diff[1].To) != `"Buy eggs"` {
+		t.Errorf("Unexpected title diff: %s -> %s", diff[1].From, diff[1].To)
+	}
+}
+
+func TestDiffJSON_Created(t *testing.T) {
+	// Given: 作成された Todo
+	// When:  nil との差分を求める
+	// Then:  すべてのフィールドが変更後の値だけを持つ
+	diff, err := DiffJSON(nil, &Todo{ID: 1, Title: "Buy milk"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(diff) == 0 {
+		t.Fatal("Expected diff, got none")
+	}
+	for _, c := range diff {
+		if c.From != nil || c.To == nil {
+			t.Errorf("Unexpected change: %+v", c)
+		}
+	}
+}
+
+func TestAuditEntry_ComputeHash(t *testing.T) {
+	// Given: ハッシュを設定したエントリ
+	e := &AuditEntry{Seq: 1, Actor: "alice", Action: "todo_created", PrevHash: ""}
+	hash, err := e.ComputeHash()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	e.Hash = hash
+
+	// When:  ハッシュを再計算する
+	// Then:  Hash 自体は計算に含まれないため一致し、内容を変えると一致しなくなる
+	if got, _ := e.ComputeHash(); got != hash {
+		t.Errorf("Expected '%s', got '%s'", hash, got)
+	}
+	e.Actor = "mallory"
+	if got, _ := e.ComputeHash(); got == hash {
+		t.Error("Expected hash to change")
+	}
+}
+
+func TestTodoAuditAction(t *testing.T) {
+	now := time.Now()
+	todo := &Todo{ID: 1, Title: "Buy milk", Status: StatusTodo}
+	renamed := &Todo{ID: 1, Title: "Buy eggs", Status: StatusTodo}
+	trashed := &Todo{ID: 1, Title: "Buy milk", Status: StatusTodo, DeletedAt: &now}
+	renamedAndTrashed := &Todo{ID: 1, Title: "Buy eggs", Status: StatusTodo, DeletedAt: &now}
+
+	tests := []struct {
+		name   string
+		change Change
+		want   string
+	}{
+		{name: "created", change: Change{ID: 1, After: todo}, want: "todo_created"},
+		{name: "deleted", change: Change{ID: 1, Before: todo}, want: "todo_deleted"},
+		{name: "title", change: Change{ID: 1, Before: todo, After: renamed}, want: "title_changed"},
+		{name: "trashed", change: Change{ID: 1, Before: todo, After: trashed}, want: "todo_trashed"},
+		{name: "multiple", change: Change{ID: 1, Before: todo, After: renamedAndTrashed}, want: "todo_updated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TodoAuditAction(tt.change, now); got != tt.want {
+				t.Errorf("Expected '%s', got '%s'", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateAuditQuery(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		query   AuditQuery
+		wantErr bool
+	}{
+		{name: "empty", query: AuditQuery{}},
+		{name: "period", query: AuditQuery{Since: &jan, Until: &feb}},
+		{name: "negative limit", query: AuditQuery{Limit: -1}, wantErr: true},
+		{name: "reversed period", query: AuditQuery{Since: &feb, Until: &jan}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAuditQuery(tt.query)
+			if tt.wantErr && !errors.Is(err, ErrInvalidAuditQuery) {
+				t.Errorf("Expected ErrInvalidAuditQuery, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAuditQuery_Match(t *testing.T) {
+	// Given: 1月2日の alice の操作
+	at := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	e := &AuditEntry{At: at, Actor: "alice", Resource: AuditResourceTodo, ResourceID: "1", Action: "todo_created"}
+	jan2 := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	jan3 := time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query AuditQuery
+		want  bool
+	}{
+		{name: "no condition", query: AuditQuery{}, want: true},
+		{name: "actor", query: AuditQuery{Actor: "alice", ResourceID: "1"}, want: true},
+		{name: "other actor", query: AuditQuery{Actor: "bob"}, want: false},
+		{name: "in period", query: AuditQuery{Since: &jan2, Until: &jan3}, want: true},
+		{name: "until is exclusive", query: AuditQuery{Until: &jan2}, want: false},
+		{name: "other action", query: AuditQuery{Action: "todo_deleted"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.Match(e); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import "errors"
+
+type BulkMode string
+
+const (
+	BulkAtomic     BulkMode = "atomic"
+	BulkBestEffort BulkMode = "best_effort"
+)
+
+type BulkOp string
+
+const (
+	BulkCreate   BulkOp = "create"
+	BulkUpdate   BulkOp = "update"
+	BulkDelete   BulkOp = "delete"
+	BulkComplete BulkOp = "complete"
+)
+
+type BulkResultStatus string
+
+const (
+	BulkOK         BulkResultStatus = "ok"
+	BulkFailed     BulkResultStatus = "failed"
+	BulkRolledBack BulkResultStatus = "rolled_back"
+	BulkSkipped    BulkResultStatus = "skipped"
+)
+
+var (
+	ErrInvalidBulkMode = errors.New("invalid bulk mode")
+	ErrInvalidBulkOp   = errors.New("invalid bulk operation")
+	ErrBulkRolledBack  = errors.New("bulk operation rolled back")
+)
+
+type BulkOperation struct {
+	Op        BulkOp `json:"op"`
+	ID        int    `json:"id,omitempty"`
+	Title     string `json:"title,omitempty"`
+	Completed *bool  `json:"completed,omitempty"`
+}
+
+type BulkResult struct {
+	Index  int              `json:"index"`
+	Op     BulkOp           `json:"op"`
+	Status BulkResultStatus `json:"status"`
+	Todo   *Todo            `json:"todo,omitempty"`
+	Error  string           `json:"error,omitempty"`
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// Change は1つの Todo の変更前後の状態。作成前・削除後のように存在しない状態は nil。
+type Change struct {
+	ID     int   `json:"id"`
+	Before *Todo `json:"before"`
+	After  *Todo `json:"after"`
+}
+
+// ChangeRecorder は WithinTx の tx を包み、Todo ごとに最初の状態と最後の状態を記録する。
+type ChangeRecorder struct {
+	IRepository
+	changes []Change
+	index   map[int]int
+}
+
+func NewChangeRecorder(tx IRepository) *ChangeRecorder {
+	return &ChangeRecorder{IRepository: tx, index: make(map[int]int)}
+}
+
+// before は id の Todo の変更前の状態を返す。すでに記録済みなら最初の状態が残っているため読み込まない。
+func (r *ChangeRecorder) before(ctx context.Context, id int) (*Todo, error) {
+	if _, ok := r.index[id]; ok {
+		return nil, nil
+	}
+	todo, err := r.IRepository.FindByID(ctx, id)
+	if errors.Is(err, ErrTodoNotFound) {
+		return nil, nil
+	}
+	return todo, err
+}
+
+// record は変更に成功した後に呼び、初めて変更した Todo なら before も記録する。
+func (r *ChangeRecorder) record(id int, before, after *Todo) {
+	i, ok := r.index[id]
+	if !ok {
+		i = len(r.changes)
+		r.index[id] = i
+		r.changes = append(r.changes, Change{ID: id, Before: before})
+	}
+	if after != nil {
+		after = after.Clone()
+	}
+	r.changes[i].After = after
+}
+
+func (r *ChangeRecorder) Create(ctx context.Context, todo *Todo) error {
+	if err := r.IRepository.Create(ctx, todo); err != nil {
+		return err
+	}
+	r.record(todo.ID, nil, todo)
+	return nil
+}
+
+func (r *ChangeRecorder) Insert(ctx context.Context, todo *Todo) error {
+	before, err := r.before(ctx, todo.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.IRepository.Insert(ctx, todo); err != nil {
+		return err
+	}
+	r.record(todo.ID, before, todo)
+	return nil
+}
+
+func (r *ChangeRecorder) Update(ctx context.Context, todo *Todo) error {
+	before, err := r.before(ctx, todo.ID)
+	if err != nil {
+		return err
+	}
+	if err := r.IRepository.Update(ctx, todo); err != nil {
+		return err
+	}
+	r.record(todo.ID, before, todo)
+	return nil
+}
+
+func (r *ChangeRecorder) Delete(ctx context.Context, id int) error {
+	before, err := r.before(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.IRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.record(id, before, nil)
+	return nil
+}
+
+func (r *ChangeRecorder) WithinTx(ctx context.Context, fn func(tx IRepository) error) error {
+	return fn(r)
+}
+
+// Changes は実質的に変化のなかった Todo（作成してすぐ削除したものなど）を除いた変更を、最初に変更した順に返す。
+func (r *ChangeRecorder) Changes() []Change {
+	var changes []Change
+	for _, c := range r.changes {
+		if !SameTodo(c.Before, c.After) {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+// memRepository はテスト用のメモリ上のリポジトリ。
+type memRepository struct {
+	todos map[int]*Todo
+	maxID int
+}
+
+func newMemRepository(todos ...*Todo) *memRepository {
+	r := &memRepository{todos: make(map[int]*Todo)}
+	for _, t := range todos {
+		r.todos[t.ID] = t.Clone()
+		if t.ID > r.maxID {
+			r.maxID = t.ID
+		}
+	}
+	return r
+}
+
+func (r *memRepository) Create(ctx context.Context, todo *Todo) error {
+	r.maxID++
+	todo.ID = r.maxID
+	r.todos[todo.ID] = todo.Clone()
+	return nil
+}
+
+func (r *memRepository) Insert(ctx context.Context, todo *Todo) error {
+	if _, ok := r.todos[todo.ID]; ok {
+		return ErrTodoConflict
+	}
+	r.todos[todo.ID] = todo.Clone()
+	return nil
+}
+
+func (r *memRepository) List(ctx context.Context) ([]*Todo, error) {
+	todos := make([]*Todo, 0, len(r.todos))
+	for _, t := range r.todos {
+		todos = append(todos, t.Clone())
+	}
+	return todos, nil
+}
+
+func (r *memRepository) FindByID(ctx context.Context, id int) (*Todo, error) {
+	if t, ok := r.todos[id]; ok {
+		return t.Clone(), nil
+	}
+	return nil, ErrTodoNotFound
+}
+
+func (r *memRepository) Update(ctx context.Context, todo *Todo) error {
+	if _, ok := r.todos[todo.ID]; !ok {
+		return ErrTodoNotFound
+	}
+	r.todos[todo.ID] = todo.Clone()
+	return nil
+}
+
+func (r *memRepository) Delete(ctx context.Context, id int) error {
+	if _, ok := r.todos[id]; !ok {
+		return ErrTodoNotFound
+	}
+	delete(r.todos, id)
+	return nil
+}
+
+func (r *memRepository) WithinTx(ctx context.Context, fn func(tx IRepository) error) error {
+	return fn(r)
+}
+
+func TestChangeRecorder(t *testing.T) {
+	// Given: 既存の Todo が2件あるリポジトリ
+	ctx := context.Background()
+	rec := NewChangeRecorder(newMemRepository(
+		&Todo{ID: 1, Title: "Buy milk", Status: StatusTodo},
+		&Todo{ID: 2, Title: "Buy eggs", Status: StatusTodo},
+	))
+
+	// When:  1件を2回更新し、1件を削除し、1件を作成してすぐ削除し、1件を作成する
+	updated := &Todo{ID: 1, Title: "Buy oat milk", Status: StatusTodo}
+	_ = rec.Update(ctx, updated)
+	updated.Status = StatusDone
+	_ = rec.Update(ctx, updated)
+	_ = rec.Delete(ctx, 2)
+	temporary := &Todo{Title: "Temporary", Status: StatusTodo}
+	_ = rec.Create(ctx, temporary)
+	_ = rec.Delete(ctx, temporary.ID)
+	_ = rec.Create(ctx, &Todo{Title: "Buy bread", Status: StatusTodo})
+
+	// Then:  Todo ごとに最初と最後の状態が記録され、作成してすぐ削除したものは含まれない
+	changes := rec.Changes()
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %d", len(changes))
+	}
+	if changes[0].ID != 1 || changes[0].Before.Title != "Buy milk" || changes[0].After.Status != StatusDone {
+		t.Errorf("Unexpected update change: %+v", changes[0])
+	}
+	if changes[1].ID != 2 || changes[1].Before == nil || changes[1].After != nil {
+		t.Errorf("Unexpected delete change: %+v", changes[1])
+	}
+	if changes[2].Before != nil || changes[2].After.Title != "Buy bread" {
+		t.Errorf("Unexpected create change: %+v", changes[2])
+	}
+}
+
+func TestChangeRecorder_FailedOperation(t *testing.T) {
+	// Given: 空のリポジトリ
+	rec := NewChangeRecorder(newMemRepository())
+
+	// When:  存在しない Todo を更新しようとして失敗する
+	err := rec.Update(context.Background(), &Todo{ID: 1, Title: "Buy milk"})
+
+	// Then:  変更は記録されない
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+	if changes := rec.Changes(); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
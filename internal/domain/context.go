@@ -0,0 +1,42 @@
+package domain
+
+import "context"
+
+type (
+	actorKey     struct{}
+	sessionKey   struct{}
+	requestIDKey struct{}
+)
+
+// WithActor は操作を行った利用者を ctx に設定する。履歴などの記録に使う。
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext は ctx に設定された利用者を返す。設定されていなければ空文字。
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// WithSession は操作を行ったセッションを ctx に設定する。元に戻す操作はセッションごとに管理する。
+func WithSession(ctx context.Context, session string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+// SessionFromContext は ctx に設定されたセッションを返す。設定されていなければ空文字。
+func SessionFromContext(ctx context.Context) string {
+	session, _ := ctx.Value(sessionKey{}).(string)
+	return session
+}
+
+// WithRequestID は操作を行ったリクエストの ID を ctx に設定する。監査ログに記録する。
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext は ctx に設定されたリクエスト ID を返す。設定されていなければ空文字。
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
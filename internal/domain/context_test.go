@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestActorFromContext(t *testing.T) {
+	// Given: 利用者を設定した ctx と設定していない ctx
+	// When:  ActorFromContext を呼び出す
+	// Then:  設定した利用者、または空文字が返る
+	ctx := WithActor(context.Background(), "alice")
+
+	if got := ActorFromContext(ctx); got != "alice" {
+		t.Errorf("Expected 'alice', got '%s'", got)
+	}
+	if got := ActorFromContext(context.Background()); got != "" {
+		t.Errorf("Expected empty actor, got '%s'", got)
+	}
+}
+
+func TestSessionFromContext(t *testing.T) {
+	// Given: セッションを設定した ctx と設定していない ctx
+	// When:  SessionFromContext を呼び出す
+	// Then:  設定したセッション、または空文字が返る
+	ctx := WithSession(context.Background(), "tab-1")
+
+	if got := SessionFromContext(ctx); got != "tab-1" {
+		t.Errorf("Expected 'tab-1', got '%s'", got)
+	}
+	if got := SessionFromContext(context.Background()); got != "" {
+		t.Errorf("Expected empty session, got '%s'", got)
+	}
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	// Given: リクエスト ID を設定した ctx と設定していない ctx
+	// When:  RequestIDFromContext を呼び出す
+	// Then:  設定したリクエスト ID、または空文字が返る
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	if got := RequestIDFromContext(ctx); got != "req-1" {
+		t.Errorf("Expected 'req-1', got '%s'", got)
+	}
+	if got := RequestIDFromContext(context.Background()); got != "" {
+		t.Errorf("Expected empty request ID, got '%s'", got)
+	}
+}
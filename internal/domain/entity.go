@@ -2,16 +2,103 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 )
 
 type Todo struct {
-	ID        int       `json:"id"`
-	Title     string    `json:"title"`
-	Completed bool      `json:"completed"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            int                `json:"id"`
+	Title         string             `json:"title"`
+	Position      string             `json:"position,omitempty"`
+	Status        Status             `json:"status"`
+	StatusHistory []StatusTransition `json:"status_history,omitempty"`
+	CompletedAt   *time.Time         `json:"completed_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	DeletedAt     *time.Time         `json:"deleted_at,omitempty"`
+}
+
+var (
+	ErrTodoNotFound  = errors.New("todo not found")
+	ErrTodoConflict  = errors.New("todo already exists")
+	ErrNotArchivable = errors.New("only done or cancelled todos can be archived")
+)
+
+func (t *Todo) IsCompleted() bool {
+	return t.Status == StatusDone
+}
+
+func (t *Todo) IsFinished() bool {
+	return t.Status == StatusDone || t.Status == StatusCancelled
+}
+
+func (t *Todo) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
+// Clone は保持しているポインタやスライスも含めて複製した Todo を返す。
+func (t *Todo) Clone() *Todo {
+	c := *t
+	c.StatusHistory = append([]StatusTransition(nil), t.StatusHistory...)
+	if t.CompletedAt != nil {
+		completedAt := *t.CompletedAt
+		c.CompletedAt = &completedAt
+	}
+	if t.DeletedAt != nil {
+		deletedAt := *t.DeletedAt
+		c.DeletedAt = &deletedAt
+	}
+	return &c
+}
+
+// SameTodo は保存される JSON が同じかどうかで比較する（time.Time のロケーションなどの違いは無視する）。
+// どちらも nil の場合も同じとみなす。
+func SameTodo(a, b *Todo) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
+}
+
+// todoJSON は MarshalJSON / UnmarshalJSON の再帰呼び出しを避けるためのメソッドなしの型。
+type todoJSON Todo
+
+// completed は後方互換のため Status から導出して出力する。
+func (t Todo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		todoJSON
+		Completed bool `json:"completed"`
+	}{
+		todoJSON:  todoJSON(t),
+		Completed: t.IsCompleted(),
+	})
+}
+
+// status を持たない旧形式のデータは completed から status を復元する。
+func (t *Todo) UnmarshalJSON(data []byte) error {
+	var v struct {
+		todoJSON
+		Completed bool `json:"completed"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*t = Todo(v.todoJSON)
+	if t.Status == "" {
+		t.Status = StatusTodo
+		if v.Completed {
+			t.Status = StatusDone
+			if !t.UpdatedAt.IsZero() {
+				completedAt := t.UpdatedAt
+				t.CompletedAt = &completedAt
+			}
+		}
+	}
+	return nil
 }
 
 func ValidateTodo(t *Todo) error {
@@ -26,8 +113,11 @@ func ValidateTodo(t *Todo) error {
 
 type IRepository interface {
 	Create(ctx context.Context, todo *Todo) error
+	Insert(ctx context.Context, todo *Todo) error
 	List(ctx context.Context) ([]*Todo, error)
 	FindByID(ctx context.Context, id int) (*Todo, error)
 	Update(ctx context.Context, todo *Todo) error
 	Delete(ctx context.Context, id int) error
+	// WithinTx は fn 内の tx に対する操作をまとめて反映する。fn がエラーを返した場合は何も反映しない。
+	WithinTx(ctx context.Context, fn func(tx IRepository) error) error
 }
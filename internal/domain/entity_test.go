@@ -1,8 +1,10 @@
 package domain
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidateTodo(t *testing.T) {
@@ -44,3 +46,129 @@ func TestValidateTodo(t *testing.T) {
 		})
 	}
 }
+
+func TestTodo_MarshalJSON_Completed(t *testing.T) {
+	tests := []struct {
+		name   string
+		status Status
+		want   bool
+	}{
+		{name: "todo", status: StatusTodo, want: false},
+		{name: "in_progress", status: StatusInProgress, want: false},
+		{name: "done", status: StatusDone, want: true},
+		{name: "cancelled", status: StatusCancelled, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(&Todo{ID: 1, Title: "Buy milk", Status: tt.status})
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if got["completed"] != tt.want {
+				t.Errorf("Expected completed %v, got %v", tt.want, got["completed"])
+			}
+			if got["status"] != string(tt.status) {
+				t.Errorf("Expected status %q, got %v", tt.status, got["status"])
+			}
+		})
+	}
+}
+
+func TestTodo_UnmarshalJSON_Legacy(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		wantStatus      Status
+		wantCompletedAt bool
+	}{
+		{
+			name:       "legacy incomplete",
+			data:       `{"id":1,"title":"Buy milk","completed":false}`,
+			wantStatus: StatusTodo,
+		},
+		{
+			name:            "legacy completed",
+			data:            `{"id":1,"title":"Buy milk","completed":true,"updated_at":"2026-01-17T15:30:00Z"}`,
+			wantStatus:      StatusDone,
+			wantCompletedAt: true,
+		},
+		{
+			name:       "status takes precedence",
+			data:       `{"id":1,"title":"Buy milk","status":"in_progress","completed":false}`,
+			wantStatus: StatusInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var todo Todo
+			if err := json.Unmarshal([]byte(tt.data), &todo); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if todo.Status != tt.wantStatus {
+				t.Errorf("Expected status %q, got %q", tt.wantStatus, todo.Status)
+			}
+			if (todo.CompletedAt != nil) != tt.wantCompletedAt {
+				t.Errorf("Expected CompletedAt set = %v, got %v", tt.wantCompletedAt, todo.CompletedAt)
+			}
+		})
+	}
+}
+
+func TestTodo_Clone(t *testing.T) {
+	// Given: ポインタとスライスを持つ Todo
+	now := time.Now()
+	todo := &Todo{
+		ID:            1,
+		Title:         "Buy milk",
+		Status:        StatusDone,
+		StatusHistory: []StatusTransition{{From: StatusTodo, To: StatusDone, At: now}},
+		CompletedAt:   &now,
+		DeletedAt:     &now,
+	}
+
+	// When:  Clone して複製側を書き換える
+	c := todo.Clone()
+	c.Title = "Changed"
+	c.StatusHistory[0].To = StatusCancelled
+	*c.CompletedAt = now.Add(time.Hour)
+	*c.DeletedAt = now.Add(time.Hour)
+
+	// Then:  元の Todo は変化しない
+	if todo.Title != "Buy milk" || todo.StatusHistory[0].To != StatusDone {
+		t.Errorf("Expected original todo to be unchanged, got %+v", todo)
+	}
+	if !todo.CompletedAt.Equal(now) || !todo.DeletedAt.Equal(now) {
+		t.Errorf("Expected original timestamps to be unchanged, got %v and %v", todo.CompletedAt, todo.DeletedAt)
+	}
+}
+
+func TestSameTodo(t *testing.T) {
+	at := time.Date(2026, 1, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	tests := []struct {
+		name string
+		a    *Todo
+		b    *Todo
+		want bool
+	}{
+		{name: "both nil", want: true},
+		{name: "one nil", a: &Todo{ID: 1}, want: false},
+		{name: "same", a: &Todo{ID: 1, Title: "Buy milk", CreatedAt: at}, b: &Todo{ID: 1, Title: "Buy milk", CreatedAt: at}, want: true},
+		{name: "different title", a: &Todo{ID: 1, Title: "Buy milk"}, b: &Todo{ID: 1, Title: "Buy eggs"}, want: false},
+		{name: "same instant in another location", a: &Todo{ID: 1, CreatedAt: at}, b: &Todo{ID: 1, CreatedAt: at.In(time.FixedZone("", 9*60*60))}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameTodo(tt.a, tt.b); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
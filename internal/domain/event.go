@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTodoCreated     EventType = "todo_created"
+	EventTodoInserted    EventType = "todo_inserted"
+	EventTitleChanged    EventType = "title_changed"
+	EventStatusChanged   EventType = "status_changed"
+	EventTodoCompleted   EventType = "todo_completed"
+	EventPositionChanged EventType = "position_changed"
+	EventTodoTrashed     EventType = "todo_trashed"
+	EventTodoRestored    EventType = "todo_restored"
+	EventTodoUpdated     EventType = "todo_updated"
+	EventTodoDeleted     EventType = "todo_deleted"
+	// EventIDsReserved は MaxID までの ID を使用済みにする。どの Todo にも属さないため TodoID は 0。
+	EventIDsReserved EventType = "ids_reserved"
+)
+
+// Event は Todo に対する1つの変更。Type に応じて必要なフィールドだけを持つ。
+// At は変更が起きた時刻で、ステータスの変更では遷移の時刻、ゴミ箱への移動では DeletedAt と一致する。
+type Event struct {
+	Seq       int64      `json:"seq"`
+	Type      EventType  `json:"type"`
+	TodoID    int        `json:"todo_id"`
+	At        time.Time  `json:"at"`
+	Actor     string     `json:"actor,omitempty"`
+	Todo      *Todo      `json:"todo,omitempty"`
+	Title     string     `json:"title,omitempty"`
+	From      Status     `json:"from,omitempty"`
+	To        Status     `json:"to,omitempty"`
+	Position  string     `json:"position,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	MaxID     int        `json:"max_id,omitempty"`
+}
+
+// Apply は todo に e を適用した結果を返す。todo は変更しない。削除の場合は nil を返す。
+func (e *Event) Apply(todo *Todo) *Todo {
+	switch e.Type {
+	case EventTodoCreated, EventTodoInserted, EventTodoUpdated:
+		return e.Todo.Clone()
+	case EventTodoDeleted:
+		return nil
+	}
+
+	t := todo.Clone()
+	switch e.Type {
+	case EventTitleChanged:
+		t.Title = e.Title
+	case EventStatusChanged, EventTodoCompleted:
+		at := e.At
+		t.StatusHistory = append(t.StatusHistory, StatusTransition{From: e.From, To: e.To, At: at})
+		t.Status = e.To
+		if e.To == StatusDone {
+			t.CompletedAt = &at
+		} else {
+			t.CompletedAt = nil
+		}
+	case EventPositionChanged:
+		t.Position = e.Position
+	case EventTodoTrashed:
+		at := e.At
+		t.DeletedAt = &at
+	case EventTodoRestored:
+		t.DeletedAt = nil
+	}
+	if e.UpdatedAt != nil {
+		t.UpdatedAt = *e.UpdatedAt
+	}
+	return t
+}
+
+// ChangeEvents は before を after に変える Event を返す。
+// 個別の Event で表しきれない変更は、after 全体を持つ todo_updated 1件にまとめる。
+func ChangeEvents(before, after *Todo, now time.Time) []*Event {
+	var events []*Event
+	if before.Title != after.Title {
+		events = append(events, &Event{Type: EventTitleChanged, At: now, Title: after.Title})
+	}
+	if len(after.StatusHistory) > len(before.StatusHistory) {
+		for _, tr := range after.StatusHistory[len(before.StatusHistory):] {
+			eventType := EventStatusChanged
+			if tr.To == StatusDone {
+				eventType = EventTodoCompleted
+			}
+			events = append(events, &Event{Type: eventType, At: tr.At, From: tr.From, To: tr.To})
+		}
+	}
+	if before.Position != after.Position {
+		events = append(events, &Event{Type: EventPositionChanged, At: now, Position: after.Position})
+	}
+	if !before.IsDeleted() && after.IsDeleted() {
+		events = append(events, &Event{Type: EventTodoTrashed, At: *after.DeletedAt})
+	}
+	if before.IsDeleted() && !after.IsDeleted() {
+		events = append(events, &Event{Type: EventTodoRestored, At: now})
+	}
+
+	for _, e := range events {
+		e.TodoID = after.ID
+		updatedAt := after.UpdatedAt
+		e.UpdatedAt = &updatedAt
+	}
+
+	applied := before
+	for _, e := range events {
+		applied = e.Apply(applied)
+	}
+	if SameTodo(applied, after) {
+		return events
+	}
+	return []*Event{{Type: EventTodoUpdated, TodoID: after.ID, At: now, Todo: after.Clone()}}
+}
+
+type IHistoryRepository interface {
+	// History は Todo に対する Event を古い順に返す。Event が1件もなければ ErrTodoNotFound を返す。
+	History(ctx context.Context, todoID int) ([]*Event, error)
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChangeEvents(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := base.Add(time.Hour)
+	newTodo := func() *Todo {
+		return &Todo{ID: 1, Title: "Buy milk", Position: "i", Status: StatusTodo, CreatedAt: base, UpdatedAt: base}
+	}
+
+	tests := []struct {
+		name      string
+		change    func(todo *Todo)
+		wantTypes []EventType
+	}{
+		{name: "no change", change: func(todo *Todo) {}, wantTypes: nil},
+		{name: "title", change: func(todo *Todo) {
+			todo.Title = "Buy oat milk"
+			todo.UpdatedAt = later
+		}, wantTypes: []EventType{EventTitleChanged}},
+		{name: "completed", change: func(todo *Todo) {
+			DefaultStateMachine().Transition(todo, StatusDone, later)
+			todo.UpdatedAt = later
+		}, wantTypes: []EventType{EventTodoCompleted}},
+		{name: "status and title", change: func(todo *Todo) {
+			todo.Title = "Buy oat milk"
+			DefaultStateMachine().Transition(todo, StatusInProgress, later)
+			todo.UpdatedAt = later
+		}, wantTypes: []EventType{EventTitleChanged, EventStatusChanged}},
+		{name: "two transitions", change: func(todo *Todo) {
+			DefaultStateMachine().Transition(todo, StatusDone, later)
+			DefaultStateMachine().Transition(todo, StatusTodo, later.Add(time.Minute))
+			todo.UpdatedAt = later
+		}, wantTypes: []EventType{EventTodoCompleted, EventStatusChanged}},
+		{name: "position", change: func(todo *Todo) {
+			todo.Position = "r"
+			todo.UpdatedAt = later
+		}, wantTypes: []EventType{EventPositionChanged}},
+		{name: "trashed", change: func(todo *Todo) {
+			todo.DeletedAt = &later
+		}, wantTypes: []EventType{EventTodoTrashed}},
+		{name: "only updated_at", change: func(todo *Todo) {
+			todo.UpdatedAt = later
+		}, wantTypes: []EventType{EventTodoUpdated}},
+		{name: "created_at rewritten", change: func(todo *Todo) {
+			todo.Title = "Buy oat milk"
+			todo.CreatedAt = later
+		}, wantTypes: []EventType{EventTodoUpdated}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := newTodo()
+			after := newTodo()
+			tt.change(after)
+
+			events := ChangeEvents(before, after, later)
+
+			var types []EventType
+			for _, e := range events {
+				types = append(types, e.Type)
+				if e.TodoID != 1 {
+					t.Errorf("Expected TodoID 1, got %d", e.TodoID)
+				}
+			}
+			if len(types) != len(tt.wantTypes) {
+				t.Fatalf("Expected %v, got %v", tt.wantTypes, types)
+			}
+			for i := range types {
+				if types[i] != tt.wantTypes[i] {
+					t.Errorf("Expected %v, got %v", tt.wantTypes, types)
+				}
+			}
+
+			// 得られた Event を適用し直すと after と同じ状態になる
+			replayed := before
+			for _, e := range events {
+				replayed = e.Apply(replayed)
+			}
+			if !SameTodo(replayed, after) {
+				t.Errorf("Expected replayed todo %+v, got %+v", after, replayed)
+			}
+		})
+	}
+}
+
+func TestEvent_Apply_Restored(t *testing.T) {
+	// Given: ゴミ箱にある Todo
+	deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	todo := &Todo{ID: 1, Title: "Buy milk", Status: StatusTodo, DeletedAt: &deletedAt}
+
+	// When:  todo_restored を適用する
+	restored := (&Event{Type: EventTodoRestored, TodoID: 1}).Apply(todo)
+
+	// Then:  DeletedAt がなくなり、元の Todo は変更されない
+	if restored.IsDeleted() {
+		t.Error("Expected restored todo not to be deleted")
+	}
+	if !todo.IsDeleted() {
+		t.Error("Expected original todo to be unchanged")
+	}
+}
+
+func TestEvent_Apply_Deleted(t *testing.T) {
+	// Given: Todo
+	todo := &Todo{ID: 1, Title: "Buy milk"}
+
+	// When:  todo_deleted を適用する
+	// Then:  nil が返る
+	if got := (&Event{Type: EventTodoDeleted, TodoID: 1}).Apply(todo); got != nil {
+		t.Errorf("Expected nil, got %+v", got)
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type FeedEventType string
+
+const (
+	FeedTodoCreated FeedEventType = "created"
+	FeedTodoUpdated FeedEventType = "updated"
+	FeedTodoDeleted FeedEventType = "deleted"
+	// FeedReset は要求された位置から再開できないことを表す。受け取ったら一覧を取得し直す。
+	FeedReset FeedEventType = "reset"
+)
+
+// FeedEvent は変更通知の1件。ID は発行順に増加し、再接続時の再開位置に使う。
+// Todo は変更後の状態で、削除の場合は削除前の状態。Previous は絞り込みのために使う変更前の状態。
+type FeedEvent struct {
+	ID       int64         `json:"id"`
+	Type     FeedEventType `json:"type"`
+	Todo     *Todo         `json:"todo,omitempty"`
+	Previous *Todo         `json:"-"`
+	At       time.Time     `json:"at"`
+}
+
+// FeedEventFromChange は Change を通知の種類に変換する。ID は発行時に設定する。
+func FeedEventFromChange(c Change, at time.Time) FeedEvent {
+	switch {
+	case c.Before == nil:
+		return FeedEvent{Type: FeedTodoCreated, Todo: c.After, At: at}
+	case c.After == nil:
+		return FeedEvent{Type: FeedTodoDeleted, Todo: c.Before, Previous: c.Before, At: at}
+	}
+	return FeedEvent{Type: FeedTodoUpdated, Todo: c.After, Previous: c.Before, At: at}
+}
+
+// FeedQuery は購読する変更の絞り込み条件。Filter は一覧と同じ検索式、ViewID は保存したビュー。
+type FeedQuery struct {
+	Filter string
+	ViewID string
+}
+
+type IFeed interface {
+	// Subscribe は lastEventID より後の変更を流すチャネルを返す。lastEventID が 0 の場合はこれからの変更だけを流す。
+	// チャネルは ctx が終了したとき、または受信が追いつかなくなったときに閉じられる。
+	Subscribe(ctx context.Context, lastEventID int64) <-chan FeedEvent
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFeedEventFromChange(t *testing.T) {
+	now := time.Now()
+	before := &Todo{ID: 1, Title: "Buy milk"}
+	after := &Todo{ID: 1, Title: "Buy eggs"}
+
+	tests := []struct {
+		name         string
+		change       Change
+		wantType     FeedEventType
+		wantTitle    string
+		wantPrevious *Todo
+	}{
+		{name: "created", change: Change{ID: 1, After: after}, wantType: FeedTodoCreated, wantTitle: "Buy eggs"},
+		{name: "updated", change: Change{ID: 1, Before: before, After: after}, wantType: FeedTodoUpdated, wantTitle: "Buy eggs", wantPrevious: before},
+		{name: "deleted", change: Change{ID: 1, Before: before}, wantType: FeedTodoDeleted, wantTitle: "Buy milk", wantPrevious: before},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := FeedEventFromChange(tt.change, now)
+			if e.Type != tt.wantType || e.Todo.Title != tt.wantTitle || e.Previous != tt.wantPrevious || !e.At.Equal(now) {
+				t.Errorf("Unexpected event: %+v", e)
+			}
+		})
+	}
+}
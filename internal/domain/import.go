@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// ImportTodo は外部のデータ（iCalendar の VTODO など）から取り込む1件。Status が空なら初期ステータスのまま作る。
+// ExportedID は取り込み元がこのサーバーから書き出した Todo の ID。その Todo が残っていれば、重複しないよう取り込まない。
+type ImportTodo struct {
+	ExportedID  int
+	Title       string
+	Status      Status
+	CompletedAt *time.Time
+}
+
+type ImportResult struct {
+	Created []*Todo `json:"created"`
+	Skipped int     `json:"skipped"`
+}
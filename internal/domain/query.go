@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+)
+
+type SortOrder string
+
+const (
+	SortDefault   SortOrder = ""
+	SortPosition  SortOrder = "position"
+	SortCreatedAt SortOrder = "created_at"
+	SortUpdatedAt SortOrder = "updated_at"
+)
+
+var (
+	ErrInvalidSort   = errors.New("invalid sort order")
+	ErrInvalidFilter = errors.New("invalid filter")
+	ErrInvalidLimit  = errors.New("invalid limit")
+)
+
+// ListQuery は一覧の並び順と絞り込み条件。Filter は検索式（例: status:open "release notes"）。
+// Limit が 0 の場合は件数を制限しない。
+type ListQuery struct {
+	Sort   SortOrder `json:"sort,omitempty"`
+	Filter string    `json:"q,omitempty"`
+	Limit  int       `json:"limit,omitempty"`
+}
+
+// ValidateListQuery は並び順と件数を検証する。Filter の構文は filter パッケージで検証する。
+func ValidateListQuery(q ListQuery) error {
+	switch q.Sort {
+	case SortDefault, SortPosition, SortCreatedAt, SortUpdatedAt:
+	default:
+		return ErrInvalidSort
+	}
+	if q.Limit < 0 {
+		return ErrInvalidLimit
+	}
+	return nil
+}
+
+// SortTodos は todos を order の順に並べ替える。SortDefault は保存順のまま。
+func SortTodos(todos []*Todo, order SortOrder) error {
+	switch order {
+	case SortDefault:
+		return nil
+	case SortPosition:
+		sort.SliceStable(todos, func(i, j int) bool {
+			return lessPosition(todos[i], todos[j])
+		})
+	case SortCreatedAt:
+		sort.SliceStable(todos, func(i, j int) bool {
+			return todos[i].CreatedAt.Before(todos[j].CreatedAt)
+		})
+	case SortUpdatedAt:
+		sort.SliceStable(todos, func(i, j int) bool {
+			return todos[i].UpdatedAt.After(todos[j].UpdatedAt)
+		})
+	default:
+		return ErrInvalidSort
+	}
+	return nil
+}
+
+// 並び順を持たない（機能追加前に作られた）Todo は末尾に保存順で並べる。
+func lessPosition(a, b *Todo) bool {
+	if a.Position == "" || b.Position == "" {
+		return a.Position != "" && b.Position == ""
+	}
+	return a.Position < b.Position
+}
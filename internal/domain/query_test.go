@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSortTodos(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newTodoList := func() []*Todo {
+		return []*Todo{
+			{ID: 1, Position: "m", CreatedAt: base.Add(2 * time.Hour), UpdatedAt: base},
+			{ID: 2, Position: "", CreatedAt: base, UpdatedAt: base.Add(3 * time.Hour)},
+			{ID: 3, Position: "c", CreatedAt: base.Add(time.Hour), UpdatedAt: base.Add(time.Hour)},
+			{ID: 4, Position: "", CreatedAt: base.Add(3 * time.Hour), UpdatedAt: base.Add(2 * time.Hour)},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		order   SortOrder
+		wantIDs []int
+	}{
+		{name: "default keeps insertion order", order: SortDefault, wantIDs: []int{1, 2, 3, 4}},
+		{name: "position puts unranked last", order: SortPosition, wantIDs: []int{3, 1, 2, 4}},
+		{name: "created_at ascending", order: SortCreatedAt, wantIDs: []int{2, 3, 1, 4}},
+		{name: "updated_at descending", order: SortUpdatedAt, wantIDs: []int{2, 4, 3, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			todoList := newTodoList()
+
+			if err := SortTodos(todoList, tt.order); err != nil {
+				t.Fatalf("SortTodos() error = %v", err)
+			}
+			for i, id := range tt.wantIDs {
+				if todoList[i].ID != id {
+					t.Errorf("Expected ID %d at %d, got %d", id, i, todoList[i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestSortTodos_Invalid(t *testing.T) {
+	err := SortTodos([]*Todo{}, SortOrder("title"))
+
+	if !errors.Is(err, ErrInvalidSort) {
+		t.Errorf("Expected ErrInvalidSort, got %v", err)
+	}
+}
+
+func TestValidateListQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   ListQuery
+		wantErr error
+	}{
+		{name: "empty", query: ListQuery{}},
+		{name: "all fields", query: ListQuery{Sort: SortPosition, Filter: "status:open", Limit: 10}},
+		{name: "invalid sort", query: ListQuery{Sort: "title"}, wantErr: ErrInvalidSort},
+		{name: "negative limit", query: ListQuery{Limit: -1}, wantErr: ErrInvalidLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateListQuery(tt.query)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
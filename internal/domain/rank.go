@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// 並び順は base36 の小数部を表す文字列で管理し、辞書順がそのまま表示順になる。
+// 末尾に "0" を持たないキーだけを扱うことで、任意の2つのキーの間に必ず新しいキーを作れる。
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const MaxRankLength = 16
+
+var (
+	ErrInvalidRank = errors.New("invalid rank")
+	ErrInvalidMove = errors.New("invalid move")
+)
+
+// RankBetween は prev < key < next を満たすキーを返す。
+// prev が空なら先頭側、next が空なら末尾側に上限がないものとして扱う。
+func RankBetween(prev, next string) (string, error) {
+	if !isValidRank(prev) || !isValidRank(next) {
+		return "", ErrInvalidRank
+	}
+	if next != "" && prev >= next {
+		return "", ErrInvalidRank
+	}
+	if prev != "" && next == "" {
+		return rankAfter(prev), nil
+	}
+	return rankMidpoint(prev, next), nil
+}
+
+// rankAfter は末尾への追加用に、prev の "z" でない最初の桁を1つ進めたキーを返す。
+// 二分するとキーが数回の追加ごとに1文字伸びるが、こうすれば伸びるのは約35回に1文字で済む。
+func rankAfter(prev string) string {
+	for i := 0; i < len(prev); i++ {
+		if d := rankDigitValue(prev[i]); d < len(rankDigits)-1 {
+			return prev[:i] + string(rankDigits[d+1])
+		}
+	}
+	return prev + "1"
+}
+
+func rankMidpoint(prev, next string) string {
+	if next != "" {
+		n := 0
+		for n < len(next) && rankDigitAt(prev, n) == rankDigitValue(next[n]) {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(prev) {
+				rest = prev[n:]
+			}
+			return next[:n] + rankMidpoint(rest, next[n:])
+		}
+	}
+
+	digitPrev := rankDigitAt(prev, 0)
+	digitNext := len(rankDigits)
+	if next != "" {
+		digitNext = rankDigitValue(next[0])
+	}
+
+	if digitNext-digitPrev > 1 {
+		return string(rankDigits[(digitPrev+digitNext+1)/2])
+	}
+	if len(next) > 1 {
+		return next[:1]
+	}
+	rest := ""
+	if len(prev) > 1 {
+		rest = prev[1:]
+	}
+	return string(rankDigits[digitPrev]) + rankMidpoint(rest, "")
+}
+
+// RebalanceRanks は n 件分の等間隔で短いキーを昇順で返す。
+func RebalanceRanks(n int) []string {
+	width := 1
+	for capacity := len(rankDigits); capacity <= n; capacity *= len(rankDigits) {
+		width++
+	}
+	total := 1
+	for i := 0; i < width; i++ {
+		total *= len(rankDigits)
+	}
+	step := total / (n + 1)
+
+	rankList := make([]string, n)
+	for i := range rankList {
+		rankList[i] = formatRank((i+1)*step, width)
+	}
+	return rankList
+}
+
+func formatRank(value, width int) string {
+	buf := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		buf[i] = rankDigits[value%len(rankDigits)]
+		value /= len(rankDigits)
+	}
+	return strings.TrimRight(string(buf), "0")
+}
+
+func isValidRank(rank string) bool {
+	if strings.HasSuffix(rank, "0") {
+		return false
+	}
+	for i := 0; i < len(rank); i++ {
+		if rankDigitValue(rank[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func rankDigitAt(rank string, i int) int {
+	if i >= len(rank) {
+		return 0
+	}
+	return rankDigitValue(rank[i])
+}
+
+func rankDigitValue(c byte) int {
+	return strings.IndexByte(rankDigits, c)
+}
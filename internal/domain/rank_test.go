@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		next string
+	}{
+		{name: "empty list", prev: "", next: ""},
+		{name: "append", prev: "i", next: ""},
+		{name: "prepend", prev: "", next: "i"},
+		{name: "wide gap", prev: "a", next: "z"},
+		{name: "adjacent digits", prev: "a", next: "b"},
+		{name: "prefix of next", prev: "a", next: "a1"},
+		{name: "before smallest", prev: "", next: "01"},
+		{name: "after largest", prev: "z", next: ""},
+		{name: "long keys", prev: "abc1", next: "abc2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RankBetween(tt.prev, tt.next)
+
+			if err != nil {
+				t.Fatalf("RankBetween() error = %v", err)
+			}
+			if got <= tt.prev {
+				t.Errorf("Expected %q > %q", got, tt.prev)
+			}
+			if tt.next != "" && got >= tt.next {
+				t.Errorf("Expected %q < %q", got, tt.next)
+			}
+			if !isValidRank(got) {
+				t.Errorf("Expected valid rank, got %q", got)
+			}
+		})
+	}
+}
+
+func TestRankBetween_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		next string
+	}{
+		{name: "same key", prev: "i", next: "i"},
+		{name: "reversed", prev: "z", next: "a"},
+		{name: "trailing zero", prev: "a0", next: ""},
+		{name: "invalid character", prev: "", next: "A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RankBetween(tt.prev, tt.next)
+			if !errors.Is(err, ErrInvalidRank) {
+				t.Errorf("Expected ErrInvalidRank, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRankBetween_RepeatedInsertGrowsSlowly(t *testing.T) {
+	// Given: 同じ位置（先頭の直後）に繰り返し挿入する
+	// When:  RankBetween を100回呼び出す
+	// Then:  順序が保たれ、キーは MaxRankLength を超えうる長さまで伸びる
+	prev, next := "i", "j"
+	for i := 0; i < 100; i++ {
+		key, err := RankBetween(prev, next)
+		if err != nil {
+			t.Fatalf("RankBetween() error = %v at %d", err, i)
+		}
+		if key <= prev || key >= next {
+			t.Fatalf("Expected %q < %q < %q", prev, key, next)
+		}
+		next = key
+	}
+	if len(next) <= MaxRankLength {
+		t.Errorf("Expected key to exceed %d characters, got %q", MaxRankLength, next)
+	}
+}
+
+func TestRankBetween_RepeatedAppendGrowsSlowly(t *testing.T) {
+	// Given: 空のリストの末尾に繰り返し追加する
+	// When:  RankBetween(last, "") を1000回呼び出す
+	// Then:  順序が保たれ、キーはおよそ35回に1文字しか伸びない
+	last := ""
+	for i := 0; i < 1000; i++ {
+		key, err := RankBetween(last, "")
+		if err != nil {
+			t.Fatalf("RankBetween() error = %v at %d", err, i)
+		}
+		if key <= last || !isValidRank(key) {
+			t.Fatalf("Expected valid rank after %q, got %q", last, key)
+		}
+		last = key
+	}
+	if len(last) > 1000/35+2 {
+		t.Errorf("Expected key of at most %d characters, got %d (%q)", 1000/35+2, len(last), last)
+	}
+}
+
+func TestRebalanceRanks(t *testing.T) {
+	tests := []struct {
+		name string
+		n    int
+	}{
+		{name: "zero", n: 0},
+		{name: "one", n: 1},
+		{name: "fits one digit", n: 35},
+		{name: "needs two digits", n: 36},
+		{name: "many", n: 2000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rankList := RebalanceRanks(tt.n)
+
+			if len(rankList) != tt.n {
+				t.Fatalf("Expected %d ranks, got %d", tt.n, len(rankList))
+			}
+			if !sort.StringsAreSorted(rankList) {
+				t.Error("Expected ranks to be sorted")
+			}
+			for i, rank := range rankList {
+				if !isValidRank(rank) || rank == "" {
+					t.Errorf("Invalid rank %q at %d", rank, i)
+				}
+				if i > 0 && rank == rankList[i-1] {
+					t.Errorf("Duplicate rank %q at %d", rank, i)
+				}
+			}
+		})
+	}
+}
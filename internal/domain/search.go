@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrInvalidQuery = errors.New("invalid search query")
+
+type SearchHit struct {
+	Todo    *Todo   `json:"todo"`
+	Score   float64 `json:"score"`
+	Snippet string  `json:"snippet"`
+}
+
+type ISearcher interface {
+	Search(ctx context.Context, query string, limit int) ([]SearchHit, error)
+}
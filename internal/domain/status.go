@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusTodo       Status = "todo"
+	StatusInProgress Status = "in_progress"
+	StatusDone       Status = "done"
+	StatusCancelled  Status = "cancelled"
+)
+
+var (
+	ErrInvalidStatus     = errors.New("invalid status")
+	ErrInvalidTransition = errors.New("invalid status transition")
+)
+
+type StatusTransition struct {
+	From Status    `json:"from"`
+	To   Status    `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+// StateMachine は Todo の取りうるステータスと、許可された遷移を保持する。
+// terminal は作業を終えたとみなすステータスで、フィルタの status:closed などに使う。
+type StateMachine struct {
+	initial     Status
+	transitions map[Status][]Status
+	terminal    map[Status]bool
+}
+
+// NewStateMachine は terminal に初期ステータスや定義されていないステータスがあればエラーを返す。
+func NewStateMachine(initial Status, transitions map[Status][]Status, terminal []Status) (*StateMachine, error) {
+	if _, ok := transitions[initial]; !ok {
+		return nil, ErrInvalidStatus
+	}
+	for _, targetList := range transitions {
+		for _, to := range targetList {
+			if _, ok := transitions[to]; !ok {
+				return nil, ErrInvalidStatus
+			}
+		}
+	}
+	terminalSet := make(map[Status]bool, len(terminal))
+	for _, status := range terminal {
+		if _, ok := transitions[status]; !ok || status == initial {
+			return nil, ErrInvalidStatus
+		}
+		terminalSet[status] = true
+	}
+	return &StateMachine{initial: initial, transitions: transitions, terminal: terminalSet}, nil
+}
+
+// DefaultStateMachine は todo → in_progress → done を基本の流れとし、
+// 未完了のステータスからはいつでも cancelled に、終了したステータスからは todo に戻せる。
+func DefaultStateMachine() *StateMachine {
+	return &StateMachine{
+		initial: StatusTodo,
+		transitions: map[Status][]Status{
+			StatusTodo:       {StatusInProgress, StatusDone, StatusCancelled},
+			StatusInProgress: {StatusTodo, StatusDone, StatusCancelled},
+			StatusDone:       {StatusTodo},
+			StatusCancelled:  {StatusTodo},
+		},
+		terminal: map[Status]bool{StatusDone: true, StatusCancelled: true},
+	}
+}
+
+func (m *StateMachine) Initial() Status {
+	return m.initial
+}
+
+// Statuses は定義されたステータスを、初期ステータスを先頭に残りを名前順で返す。
+func (m *StateMachine) Statuses() []Status {
+	statuses := []Status{m.initial}
+	rest := make([]Status, 0, len(m.transitions)-1)
+	for status := range m.transitions {
+		if status != m.initial {
+			rest = append(rest, status)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool { return rest[i] < rest[j] })
+	return append(statuses, rest...)
+}
+
+// IsTerminal は status が作業を終えたステータスかを返す。
+func (m *StateMachine) IsTerminal(status Status) bool {
+	return m.terminal[status]
+}
+
+func (m *StateMachine) IsValid(status Status) bool {
+	_, ok := m.transitions[status]
+	return ok
+}
+
+func (m *StateMachine) CanTransition(from, to Status) bool {
+	for _, s := range m.transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *StateMachine) Transition(todo *Todo, to Status, at time.Time) error {
+	if !m.IsValid(to) {
+		return ErrInvalidStatus
+	}
+	if !m.CanTransition(todo.Status, to) {
+		return ErrInvalidTransition
+	}
+
+	todo.StatusHistory = append(todo.StatusHistory, StatusTransition{From: todo.Status, To: to, At: at})
+	todo.Status = to
+	if to == StatusDone {
+		todo.CompletedAt = &at
+	} else {
+		todo.CompletedAt = nil
+	}
+	return nil
+}
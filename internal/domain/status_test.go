@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStateMachine_Transition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    Status
+		to      Status
+		wantErr error
+	}{
+		{name: "todo to in_progress", from: StatusTodo, to: StatusInProgress},
+		{name: "todo to done", from: StatusTodo, to: StatusDone},
+		{name: "in_progress to done", from: StatusInProgress, to: StatusDone},
+		{name: "in_progress to cancelled", from: StatusInProgress, to: StatusCancelled},
+		{name: "done to todo", from: StatusDone, to: StatusTodo},
+		{name: "cancelled to todo", from: StatusCancelled, to: StatusTodo},
+		{name: "done to in_progress", from: StatusDone, to: StatusInProgress, wantErr: ErrInvalidTransition},
+		{name: "cancelled to done", from: StatusCancelled, to: StatusDone, wantErr: ErrInvalidTransition},
+		{name: "same status", from: StatusTodo, to: StatusTodo, wantErr: ErrInvalidTransition},
+		{name: "unknown status", from: StatusTodo, to: Status("archived"), wantErr: ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machine := DefaultStateMachine()
+			todo := &Todo{Status: tt.from}
+			at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			err := machine.Transition(todo, tt.to, at)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Transition() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if todo.Status != tt.from {
+					t.Errorf("Expected status to remain %q, got %q", tt.from, todo.Status)
+				}
+				if len(todo.StatusHistory) != 0 {
+					t.Errorf("Expected no history, got %d entries", len(todo.StatusHistory))
+				}
+				return
+			}
+			if todo.Status != tt.to {
+				t.Errorf("Expected status %q, got %q", tt.to, todo.Status)
+			}
+			if len(todo.StatusHistory) != 1 {
+				t.Fatalf("Expected 1 history entry, got %d", len(todo.StatusHistory))
+			}
+			if got := todo.StatusHistory[0]; got.From != tt.from || got.To != tt.to || !got.At.Equal(at) {
+				t.Errorf("Unexpected history entry: %+v", got)
+			}
+		})
+	}
+}
+
+func TestStateMachine_Transition_CompletedAt(t *testing.T) {
+	// Given: todo 状態の Todo
+	// When:  done に遷移させ、その後 todo に戻す
+	// Then:  done で CompletedAt が設定され、戻すとクリアされる
+	machine := DefaultStateMachine()
+	todo := &Todo{Status: StatusTodo}
+	doneAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := machine.Transition(todo, StatusDone, doneAt); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	if todo.CompletedAt == nil || !todo.CompletedAt.Equal(doneAt) {
+		t.Errorf("Expected CompletedAt %v, got %v", doneAt, todo.CompletedAt)
+	}
+	if !todo.IsCompleted() {
+		t.Error("Expected IsCompleted to be true")
+	}
+
+	if err := machine.Transition(todo, StatusTodo, doneAt.Add(time.Hour)); err != nil {
+		t.Fatalf("Transition failed: %v", err)
+	}
+	if todo.CompletedAt != nil {
+		t.Errorf("Expected CompletedAt to be cleared, got %v", todo.CompletedAt)
+	}
+	if len(todo.StatusHistory) != 2 {
+		t.Errorf("Expected 2 history entries, got %d", len(todo.StatusHistory))
+	}
+}
+
+func TestNewStateMachine(t *testing.T) {
+	// Given: review を含むカスタムワークフロー
+	// When:  NewStateMachine で構築する
+	// Then:  定義した遷移だけが許可される
+	machine, err := NewStateMachine(StatusTodo, map[Status][]Status{
+		StatusTodo:       {Status("review")},
+		Status("review"): {StatusDone, StatusTodo},
+		StatusDone:       {},
+	}, []Status{StatusDone})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if machine.Initial() != StatusTodo {
+		t.Errorf("Expected initial %q, got %q", StatusTodo, machine.Initial())
+	}
+	if !machine.CanTransition(StatusTodo, Status("review")) {
+		t.Error("Expected todo -> review to be allowed")
+	}
+	if machine.CanTransition(StatusTodo, StatusDone) {
+		t.Error("Expected todo -> done to be rejected")
+	}
+	if !machine.IsTerminal(StatusDone) || machine.IsTerminal(Status("review")) {
+		t.Error("Expected only done to be terminal")
+	}
+}
+
+func TestStateMachine_Statuses(t *testing.T) {
+	// Given: 既定のワークフロー
+	machine := DefaultStateMachine()
+
+	// When:  定義されたステータスを取得する
+	statuses := machine.Statuses()
+
+	// Then:  初期ステータスが先頭で、残りは名前順に並ぶ
+	want := []Status{StatusTodo, StatusCancelled, StatusDone, StatusInProgress}
+	if len(statuses) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, statuses)
+	}
+	for i := range want {
+		if statuses[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, statuses)
+			break
+		}
+	}
+}
+
+func TestNewStateMachine_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		initial     Status
+		transitions map[Status][]Status
+		terminal    []Status
+	}{
+		{
+			name:        "initial not defined",
+			initial:     StatusInProgress,
+			transitions: map[Status][]Status{StatusTodo: {}},
+		},
+		{
+			name:        "undefined target",
+			initial:     StatusTodo,
+			transitions: map[Status][]Status{StatusTodo: {StatusDone}},
+		},
+		{
+			name:        "undefined terminal",
+			initial:     StatusTodo,
+			transitions: map[Status][]Status{StatusTodo: {}},
+			terminal:    []Status{StatusDone},
+		},
+		{
+			name:        "initial is terminal",
+			initial:     StatusTodo,
+			transitions: map[Status][]Status{StatusTodo: {}},
+			terminal:    []Status{StatusTodo},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewStateMachine(tt.initial, tt.transitions, tt.terminal)
+			if !errors.Is(err, ErrInvalidStatus) {
+				t.Errorf("Expected ErrInvalidStatus, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// SyncVersion は Todo の最後の変更に振られた変更番号。一度も変更されていない Todo の変更番号は 0。
+type SyncVersion struct {
+	ID  int   `json:"id"`
+	Seq int64 `json:"seq"`
+}
+
+// SyncHead は変更番号の現在値。Epoch は変更番号を振り直したときに変わり、それより前のトークンを無効にする。
+type SyncHead struct {
+	Epoch string `json:"epoch"`
+	Seq   int64  `json:"seq"`
+}
+
+// SyncChange は前回の同期から変わった Todo の1件。ゴミ箱に移動した Todo と削除した Todo は Deleted だけを返す。
+type SyncChange struct {
+	Seq     int64 `json:"seq"`
+	ID      int   `json:"id"`
+	Deleted bool  `json:"deleted"`
+	Todo    *Todo `json:"todo,omitempty"`
+}
+
+// SyncPush はクライアントでの変更の1件。BaseSeq はクライアントが最後に受け取った変更番号で、
+// サーバー側でその後に変更されていれば適用せずに衝突として返す。ClientID は作成時にクライアントが付けた仮の ID。
+type SyncPush struct {
+	BulkOperation
+	ClientID string `json:"client_id,omitempty"`
+	BaseSeq  int64  `json:"base_seq"`
+}
+
+type SyncResultStatus string
+
+const (
+	SyncApplied  SyncResultStatus = "applied"
+	SyncConflict SyncResultStatus = "conflict"
+	SyncFailed   SyncResultStatus = "failed"
+)
+
+// SyncResult は SyncPush ごとの結果。衝突した場合の Todo と Seq はサーバー側の現在の状態で、削除されていれば Todo は nil。
+type SyncResult struct {
+	Index    int              `json:"index"`
+	ClientID string           `json:"client_id,omitempty"`
+	ID       int              `json:"id,omitempty"`
+	Status   SyncResultStatus `json:"status"`
+	Seq      int64            `json:"seq"`
+	Todo     *Todo            `json:"todo,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// SyncResponse の Token を次回の同期に渡すと、それ以降の変更だけを受け取れる。
+type SyncResponse struct {
+	Token   string       `json:"token"`
+	Changes []SyncChange `json:"changes"`
+	Results []SyncResult `json:"results,omitempty"`
+}
+
+var (
+	ErrInvalidSyncToken = errors.New("invalid sync token")
+	// ErrSyncTokenExpired は変更番号が振り直されたなどでトークンの位置から再開できないことを表す。トークンなしで同期し直す。
+	ErrSyncTokenExpired = errors.New("sync token expired")
+)
+
+// EncodeSyncToken は head をクライアントが中身を解釈しないトークンに変換する。
+func EncodeSyncToken(head SyncHead) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(head.Epoch + ":" + strconv.FormatInt(head.Seq, 10)))
+}
+
+// DecodeSyncToken は EncodeSyncToken の逆変換。空のトークンは初回の同期を表し、ゼロ値を返す。
+func DecodeSyncToken(token string) (SyncHead, error) {
+	if token == "" {
+		return SyncHead{}, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return SyncHead{}, ErrInvalidSyncToken
+	}
+	epoch, seqStr, ok := strings.Cut(string(data), ":")
+	if !ok || epoch == "" {
+		return SyncHead{}, ErrInvalidSyncToken
+	}
+	seq, err := strconv.ParseInt(seqStr, 10, 64)
+	if err != nil || seq < 0 {
+		return SyncHead{}, ErrInvalidSyncToken
+	}
+	return SyncHead{Epoch: epoch, Seq: seq}, nil
+}
+
+// IChangeIndex は Todo ごとの最後の変更番号を保持する。変更番号はリポジトリ全体で単調に増加する。
+type IChangeIndex interface {
+	Head(ctx context.Context) (SyncHead, error)
+	// Bump は ids の Todo に新しい変更番号を振る。
+	Bump(ctx context.Context, ids []int) error
+	Version(ctx context.Context, id int) (int64, error)
+	// Since は変更番号が seq より大きい Todo を変更番号の順に返す。
+	Since(ctx context.Context, seq int64) ([]SyncVersion, error)
+}
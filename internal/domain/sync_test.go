@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSyncToken_RoundTrip(t *testing.T) {
+	// Given: 変更番号の現在値
+	head := SyncHead{Epoch: "a1b2c3", Seq: 42}
+
+	// When:  トークンに変換して戻す
+	got, err := DecodeSyncToken(EncodeSyncToken(head))
+
+	// Then:  元の値に戻る
+	if err != nil || got != head {
+		t.Errorf("Expected %+v, got %+v, %v", head, got, err)
+	}
+}
+
+func TestDecodeSyncToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    SyncHead
+		wantErr error
+	}{
+		{name: "empty", token: "", want: SyncHead{}},
+		{name: "not base64", token: "!!", wantErr: ErrInvalidSyncToken},
+		{name: "no separator", token: "YWJj", wantErr: ErrInvalidSyncToken},
+		{name: "no epoch", token: "OjE", wantErr: ErrInvalidSyncToken},
+		{name: "negative seq", token: "YTotMQ", wantErr: ErrInvalidSyncToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeSyncToken(tt.token)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+	ErrUndoConflict  = errors.New("todo has changed since the operation")
+)
+
+// IUndoer はセッション（ctx の SessionFromContext）ごとに直前の操作を取り消す・やり直す。
+// 戻り値は取り消し・やり直しによって起きた変更。
+type IUndoer interface {
+	Undo(ctx context.Context) ([]Change, error)
+	Redo(ctx context.Context) ([]Change, error)
+}
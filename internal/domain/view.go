@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// View は名前を付けて保存した一覧の条件（スマートリスト）。
+// 組み込みビューは ID に英字のキーを、利用者が作ったビューは数字の ID を持つ。
+type View struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Query     ListQuery `json:"query"`
+	BuiltIn   bool      `json:"built_in"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+var (
+	ErrViewNotFound = errors.New("view not found")
+	ErrInvalidView  = errors.New("invalid view")
+	ErrViewReadOnly = errors.New("built-in views cannot be modified")
+)
+
+func ValidateView(v *View) error {
+	if v.Name == "" || len(v.Name) > 255 {
+		return ErrInvalidView
+	}
+	return ValidateListQuery(v.Query)
+}
+
+// BuiltInViews は常に利用できる組み込みビューを返す。
+// Todo には期限がないため、期限切れ（Overdue）のビューは用意していない。
+func BuiltInViews() []*View {
+	return []*View{
+		{
+			ID:      "today",
+			Name:    "Today",
+			Query:   ListQuery{Filter: "status:open updated:today", Sort: SortUpdatedAt},
+			BuiltIn: true,
+		},
+		{
+			ID:      "recently-completed",
+			Name:    "Recently completed",
+			Query:   ListQuery{Filter: "status:done completed>=-7d", Sort: SortUpdatedAt, Limit: 50},
+			BuiltIn: true,
+		},
+	}
+}
+
+type IViewRepository interface {
+	Create(ctx context.Context, view *View) error
+	List(ctx context.Context) ([]*View, error)
+	FindByID(ctx context.Context, id string) (*View, error)
+	Update(ctx context.Context, view *View) error
+	Delete(ctx context.Context, id string) error
+}
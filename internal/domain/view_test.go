@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateView(t *testing.T) {
+	tests := []struct {
+		name    string
+		view    *View
+		wantErr error
+	}{
+		{name: "valid", view: &View{Name: "Backend", Query: ListQuery{Filter: "backend", Limit: 10}}},
+		{name: "empty name", view: &View{Name: ""}, wantErr: ErrInvalidView},
+		{name: "name too long", view: &View{Name: strings.Repeat("a", 256)}, wantErr: ErrInvalidView},
+		{name: "invalid sort", view: &View{Name: "Backend", Query: ListQuery{Sort: "title"}}, wantErr: ErrInvalidSort},
+		{name: "negative limit", view: &View{Name: "Backend", Query: ListQuery{Limit: -1}}, wantErr: ErrInvalidLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateView(tt.view)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestBuiltInViews(t *testing.T) {
+	// Given: 組み込みビュー
+	// When:  BuiltInViews を呼び出す
+	// Then:  すべて検証を通り、BuiltIn が立っている
+	for _, view := range BuiltInViews() {
+		if err := ValidateView(view); err != nil {
+			t.Errorf("Expected built-in view %q to be valid, got %v", view.ID, err)
+		}
+		if !view.BuiltIn {
+			t.Errorf("Expected view %q to be built-in", view.ID)
+		}
+	}
+}
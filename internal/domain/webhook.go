@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Webhook は Todo の変更を通知する先。Events が空の場合はすべての種類の変更を通知する。
+// Secret は通知の署名に使い、作成時のレスポンス以外では返さない。
+type Webhook struct {
+	ID        string          `json:"id"`
+	URL       string          `json:"url"`
+	Events    []FeedEventType `json:"events"`
+	Secret    string          `json:"secret,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+
+	// MaxDeliveryAttempts 回送っても成功しなければ、その通知はあきらめる。
+	MaxDeliveryAttempts = 8
+)
+
+// WebhookDelivery は1件の通知とその送信状況。Payload は送信する本文で、通知を作った時点の内容を保持する。
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhook_id"`
+	Event          FeedEventType   `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// WebhookPayload は通知の本文。
+type WebhookPayload struct {
+	Type FeedEventType `json:"type"`
+	Todo *Todo         `json:"todo"`
+	At   time.Time     `json:"at"`
+}
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrInvalidWebhook   = errors.New("invalid webhook")
+	ErrDeliveryNotFound = errors.New("delivery not found")
+)
+
+func ValidateWebhook(w *Webhook) error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhook
+	}
+	for _, event := range w.Events {
+		switch event {
+		case FeedTodoCreated, FeedTodoUpdated, FeedTodoDeleted:
+		default:
+			return ErrInvalidWebhook
+		}
+	}
+	if w.Secret == "" {
+		return ErrInvalidWebhook
+	}
+	return nil
+}
+
+// Subscribes は w が event の種類の通知を受け取るかを返す。
+func (w *Webhook) Subscribes(event FeedEventType) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// SignWebhook は timestamp（Unix 秒）と本文を "." でつないだものの HMAC-SHA256 を "sha256=<hex>" の形で返す。
+// 受信側は同じ計算をして一致を確かめ、timestamp が古すぎるものを拒否することで再送攻撃を防げる。
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NextDeliveryAttempt は attempts 回目の送信に失敗した後、次に送る時刻を返す。
+// 間隔は 30 秒から倍々に延ばし、最大 1 時間とする。
+func NextDeliveryAttempt(now time.Time, attempts int) time.Time {
+	delay := 30 * time.Second
+	for i := 1; i < attempts && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return now.Add(delay)
+}
+
+type IWebhookRepository interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	List(ctx context.Context) ([]*Webhook, error)
+	FindByID(ctx context.Context, id string) (*Webhook, error)
+	Update(ctx context.Context, webhook *Webhook) error
+	Delete(ctx context.Context, id string) error
+}
+
+type IDeliveryRepository interface {
+	// Create は deliveries に ID を設定して保存する。
+	Create(ctx context.Context, deliveries []*WebhookDelivery) error
+	// ListDue は NextAttemptAt が now 以前の未送信の通知を古い順に返す。
+	ListDue(ctx context.Context, now time.Time) ([]*WebhookDelivery, error)
+	Update(ctx context.Context, delivery *WebhookDelivery) error
+	// ListByWebhook は webhookID の通知を新しい順に最大 limit 件返す。
+	ListByWebhook(ctx context.Context, webhookID string, limit int) ([]*WebhookDelivery, error)
+	// DeleteByWebhook は webhookID の通知をすべて削除する。
+	DeleteByWebhook(ctx context.Context, webhookID string) error
+}
+
+// IWebhookSender は通知を送信し、受信側の HTTP ステータスコードを返す。
+type IWebhookSender interface {
+	Send(ctx context.Context, webhook *Webhook, delivery *WebhookDelivery) (int, error)
+}
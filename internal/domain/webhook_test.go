@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateWebhook(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook Webhook
+		wantErr bool
+	}{
+		{name: "valid", webhook: Webhook{URL: "https://example.com/hook", Secret: "s"}},
+		{name: "events", webhook: Webhook{URL: "http://localhost:9000", Events: []FeedEventType{FeedTodoCreated, FeedTodoDeleted}, Secret: "s"}},
+		{name: "relative url", webhook: Webhook{URL: "/hook", Secret: "s"}, wantErr: true},
+		{name: "other scheme", webhook: Webhook{URL: "ftp://example.com", Secret: "s"}, wantErr: true},
+		{name: "unknown event", webhook: Webhook{URL: "https://example.com", Events: []FeedEventType{FeedReset}, Secret: "s"}, wantErr: true},
+		{name: "no secret", webhook: Webhook{URL: "https://example.com"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWebhook(&tt.webhook)
+			if tt.wantErr && !errors.Is(err, ErrInvalidWebhook) {
+				t.Errorf("Expected ErrInvalidWebhook, got %v", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhook_Subscribes(t *testing.T) {
+	all := &Webhook{}
+	created := &Webhook{Events: []FeedEventType{FeedTodoCreated}}
+
+	if !all.Subscribes(FeedTodoDeleted) {
+		t.Error("Expected webhook without events to subscribe to all events")
+	}
+	if !created.Subscribes(FeedTodoCreated) || created.Subscribes(FeedTodoUpdated) {
+		t.Error("Expected webhook to subscribe only to created")
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	// Given: 鍵・時刻・本文
+	body := []byte(`{"type":"created"}`)
+
+	// When:  署名する
+	got := SignWebhook("s3cret", 1700000000, body)
+
+	// Then:  "時刻.本文" の HMAC-SHA256 になる
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(`1700000000.{"type":"created"}`))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if got != want {
+		t.Errorf("Expected '%s', got '%s'", want, got)
+	}
+}
+
+func TestNextDeliveryAttempt(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: 20, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := NextDeliveryAttempt(now, tt.attempts).Sub(now); got != tt.want {
+			t.Errorf("attempts %d: expected %v, got %v", tt.attempts, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package feed
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+const (
+	DefaultReplaySize = 1000
+
+	// subscriberBuffer を超えて受信が遅れた購読者は切断する。再接続すれば Last-Event-ID から再開できる。
+	subscriberBuffer = 64
+)
+
+// Bus はプロセス内の変更通知を購読者に配る。直近 replaySize 件を保持し、途中からの再開に使う。
+type Bus struct {
+	replaySize int
+
+	mu          sync.Mutex
+	lastID      int64
+	replay      []domain.FeedEvent
+	subscribers map[chan domain.FeedEvent]struct{}
+}
+
+func NewBus(replaySize int) *Bus {
+	if replaySize <= 0 {
+		replaySize = DefaultReplaySize
+	}
+	return &Bus{
+		replaySize:  replaySize,
+		subscribers: make(map[chan domain.FeedEvent]struct{}),
+	}
+}
+
+// Publish は events に ID を付けて配る。
+func (b *Bus) Publish(events []domain.FeedEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, e := range events {
+		b.lastID++
+		e.ID = b.lastID
+		b.replay = append(b.replay, e)
+		if len(b.replay) > b.replaySize {
+			b.replay = b.replay[len(b.replay)-b.replaySize:]
+		}
+
+		for ch := range b.subscribers {
+			select {
+			case ch <- e:
+			default:
+				b.remove(ch)
+			}
+		}
+	}
+}
+
+func (b *Bus) Subscribe(ctx context.Context, lastEventID int64) <-chan domain.FeedEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var pending []domain.FeedEvent
+	if lastEventID > 0 && lastEventID < b.lastID {
+		if len(b.replay) == 0 || b.replay[0].ID > lastEventID+1 {
+			// 保持している範囲より古い位置からは再開できない
+			pending = []domain.FeedEvent{{ID: b.lastID, Type: domain.FeedReset, At: time.Now()}}
+		} else {
+			for _, e := range b.replay {
+				if e.ID > lastEventID {
+					pending = append(pending, e)
+				}
+			}
+		}
+	} else if lastEventID > b.lastID {
+		// 再起動などで ID が巻き戻っている
+		pending = []domain.FeedEvent{{ID: b.lastID, Type: domain.FeedReset, At: time.Now()}}
+	}
+
+	ch := make(chan domain.FeedEvent, len(pending)+subscriberBuffer)
+	for _, e := range pending {
+		ch <- e
+	}
+	b.subscribers[ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(ch)
+	}()
+	return ch
+}
+
+// remove は購読者を取り除いてチャネルを閉じる。すでに取り除かれていれば何もしない。
+func (b *Bus) remove(ch chan domain.FeedEvent) {
+	if _, ok := b.subscribers[ch]; !ok {
+		return
+	}
+	delete(b.subscribers, ch)
+	close(ch)
+}
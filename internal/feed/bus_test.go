@@ -0,0 +1,126 @@
+package feed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func publishTitles(bus *Bus, titles ...string) {
+	for _, title := range titles {
+		bus.Publish([]domain.FeedEvent{{Type: domain.FeedTodoCreated, Todo: &domain.Todo{Title: title}}})
+	}
+}
+
+// receive はチャネルにすでに届いている通知を受け取る。
+func receive(ch <-chan domain.FeedEvent) []domain.FeedEvent {
+	var events []domain.FeedEvent
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return events
+			}
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestBus_Publish(t *testing.T) {
+	// Given: 購読者が2人いる
+	bus := NewBus(0)
+	ctx := context.Background()
+	first := bus.Subscribe(ctx, 0)
+	second := bus.Subscribe(ctx, 0)
+
+	// When:  2件発行する
+	publishTitles(bus, "Buy milk", "Buy eggs")
+
+	// Then:  どちらの購読者にも増加する ID 付きで届く
+	for _, ch := range []<-chan domain.FeedEvent{first, second} {
+		events := receive(ch)
+		if len(events) != 2 || events[0].ID != 1 || events[1].ID != 2 || events[1].Todo.Title != "Buy eggs" {
+			t.Errorf("Unexpected events: %+v", events)
+		}
+	}
+}
+
+func TestBus_SubscribeResume(t *testing.T) {
+	// Given: 5件発行済みで、直近3件を保持しているバス
+	bus := NewBus(3)
+	publishTitles(bus, "1", "2", "3", "4", "5")
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		lastEventID int64
+		wantIDs     []int64
+		wantReset   bool
+	}{
+		{name: "new", lastEventID: 0, wantIDs: nil},
+		{name: "latest", lastEventID: 5, wantIDs: nil},
+		{name: "in buffer", lastEventID: 3, wantIDs: []int64{4, 5}},
+		{name: "oldest in buffer", lastEventID: 2, wantIDs: []int64{3, 4, 5}},
+		{name: "too old", lastEventID: 1, wantReset: true},
+		{name: "from future", lastEventID: 10, wantReset: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := receive(bus.Subscribe(ctx, tt.lastEventID))
+
+			if tt.wantReset {
+				if len(events) != 1 || events[0].Type != domain.FeedReset || events[0].ID != 5 {
+					t.Errorf("Expected reset event, got %+v", events)
+				}
+				return
+			}
+			if len(events) != len(tt.wantIDs) {
+				t.Fatalf("Expected %d events, got %+v", len(tt.wantIDs), events)
+			}
+			for i, e := range events {
+				if e.ID != tt.wantIDs[i] {
+					t.Errorf("Expected ID %d, got %d", tt.wantIDs[i], e.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestBus_SlowSubscriber(t *testing.T) {
+	// Given: 受信しない購読者
+	bus := NewBus(0)
+	ch := bus.Subscribe(context.Background(), 0)
+
+	// When:  バッファを超えて発行する
+	for i := 0; i <= subscriberBuffer; i++ {
+		publishTitles(bus, "todo")
+	}
+
+	// Then:  購読者は切断され、チャネルが閉じられる
+	events := receive(ch)
+	if len(events) != subscriberBuffer {
+		t.Errorf("Expected %d buffered events, got %d", subscriberBuffer, len(events))
+	}
+	if _, ok := <-ch; ok {
+		t.Error("Expected channel to be closed")
+	}
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	// Given: 購読中の ctx
+	bus := NewBus(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := bus.Subscribe(ctx, 0)
+
+	// When:  ctx をキャンセルする
+	cancel()
+
+	// Then:  チャネルが閉じられる
+	if _, ok := <-ch; ok {
+		t.Error("Expected channel to be closed")
+	}
+}
@@ -0,0 +1,66 @@
+package feed
+
+import (
+	"context"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// PublishingRepository は repo へのコミットされた変更を Bus に通知するデコレータ。
+type PublishingRepository struct {
+	domain.IRepository
+	bus *Bus
+}
+
+func NewPublishingRepository(repo domain.IRepository, bus *Bus) *PublishingRepository {
+	return &PublishingRepository{IRepository: repo, bus: bus}
+}
+
+func (r *PublishingRepository) Create(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Create(ctx, todo)
+	})
+}
+
+func (r *PublishingRepository) Insert(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Insert(ctx, todo)
+	})
+}
+
+func (r *PublishingRepository) Update(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Update(ctx, todo)
+	})
+}
+
+func (r *PublishingRepository) Delete(ctx context.Context, id int) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Delete(ctx, id)
+	})
+}
+
+// WithinTx はコミットに成功した後で、変更された Todo ごとに1件の通知を発行する。
+func (r *PublishingRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	var rec *domain.ChangeRecorder
+	err := r.IRepository.WithinTx(ctx, func(tx domain.IRepository) error {
+		rec = domain.NewChangeRecorder(tx)
+		return fn(rec)
+	})
+	if err != nil {
+		return err
+	}
+
+	changes := rec.Changes()
+	if len(changes) == 0 {
+		return nil
+	}
+	now := time.Now()
+	events := make([]domain.FeedEvent, len(changes))
+	for i, c := range changes {
+		events[i] = domain.FeedEventFromChange(c, now)
+	}
+	r.bus.Publish(events)
+	return nil
+}
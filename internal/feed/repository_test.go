@@ -0,0 +1,53 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+)
+
+func TestPublishingRepository(t *testing.T) {
+	// Given: 購読者がいるバスに通知するリポジトリ
+	bus := NewBus(0)
+	repo := NewPublishingRepository(storage.NewFileRepository(filepath.Join(t.TempDir(), "todos.json")), bus)
+	ctx := context.Background()
+	ch := bus.Subscribe(ctx, 0)
+
+	// When:  作成・更新・削除と、失敗するトランザクションを実行する
+	todo := &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}
+	if err := repo.Create(ctx, todo); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	todo.Title = "Buy oat milk"
+	if err := repo.Update(ctx, todo); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	_ = repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		if err := tx.Delete(ctx, todo.ID); err != nil {
+			return err
+		}
+		return errors.New("failed")
+	})
+	if err := repo.Delete(ctx, todo.ID); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+
+	// Then:  コミットされた変更だけが順に通知される
+	events := receive(ch)
+	wantTypes := []domain.FeedEventType{domain.FeedTodoCreated, domain.FeedTodoUpdated, domain.FeedTodoDeleted}
+	if len(events) != len(wantTypes) {
+		t.Fatalf("Expected %d events, got %+v", len(wantTypes), events)
+	}
+	for i, e := range events {
+		if e.Type != wantTypes[i] || e.Todo.ID != todo.ID {
+			t.Errorf("Unexpected event %d: %+v", i, e)
+		}
+	}
+	if events[1].Todo.Title != "Buy oat milk" || events[1].Previous.Title != "Buy milk" {
+		t.Errorf("Unexpected update event: %+v", events[1])
+	}
+}
@@ -0,0 +1,214 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+const dateLayout = "2006-01-02"
+
+// Expr は Todo が条件に一致するかを判定する。
+type Expr interface {
+	Match(todo *domain.Todo) bool
+}
+
+type andExpr []Expr
+
+func (e andExpr) Match(todo *domain.Todo) bool {
+	for _, expr := range e {
+		if !expr.Match(todo) {
+			return false
+		}
+	}
+	return true
+}
+
+type orExpr []Expr
+
+func (e orExpr) Match(todo *domain.Todo) bool {
+	for _, expr := range e {
+		if expr.Match(todo) {
+			return true
+		}
+	}
+	return false
+}
+
+type notExpr struct {
+	expr Expr
+}
+
+func (e notExpr) Match(todo *domain.Todo) bool {
+	return !e.expr.Match(todo)
+}
+
+type predicate func(todo *domain.Todo) bool
+
+func (f predicate) Match(todo *domain.Todo) bool {
+	return f(todo)
+}
+
+// statusGroup は status: に指定できる、複数のステータスをまとめた別名を machine のステータスに展開する。
+// open は終了していないステータス、closed は終了したステータス（domain.StateMachine.IsTerminal）にあたる。
+func statusGroup(name string, machine *domain.StateMachine) ([]domain.Status, bool) {
+	var closed bool
+	switch name {
+	case "open":
+	case "closed":
+		closed = true
+	default:
+		return nil, false
+	}
+
+	var statusList []domain.Status
+	for _, status := range machine.Statuses() {
+		if machine.IsTerminal(status) == closed {
+			statusList = append(statusList, status)
+		}
+	}
+	return statusList, true
+}
+
+func compileTerm(tok token, now time.Time, machine *domain.StateMachine) (Expr, error) {
+	if tok.op == "" {
+		text := strings.ToLower(tok.value)
+		return predicate(func(todo *domain.Todo) bool {
+			return strings.Contains(strings.ToLower(todo.Title), text)
+		}), nil
+	}
+	if tok.value == "" {
+		return nil, &SyntaxError{Column: tok.valueColumn, Msg: fmt.Sprintf("missing value for %q", tok.field)}
+	}
+
+	switch tok.field {
+	case "status":
+		return compileStatus(tok, machine)
+	case "title":
+		return compileTitle(tok)
+	case "id":
+		return compileID(tok)
+	case "created":
+		return compileDate(tok, now, func(todo *domain.Todo) *time.Time { return &todo.CreatedAt })
+	case "updated":
+		return compileDate(tok, now, func(todo *domain.Todo) *time.Time { return &todo.UpdatedAt })
+	case "completed":
+		return compileDate(tok, now, func(todo *domain.Todo) *time.Time { return todo.CompletedAt })
+	}
+	return nil, &SyntaxError{Column: tok.column, Msg: fmt.Sprintf("unknown field %q", tok.field)}
+}
+
+func compileStatus(tok token, machine *domain.StateMachine) (Expr, error) {
+	if tok.op != ":" && tok.op != "=" {
+		return nil, unsupportedOperator(tok)
+	}
+
+	statusList, ok := statusGroup(tok.value, machine)
+	if !ok {
+		status := domain.Status(tok.value)
+		if !machine.IsValid(status) {
+			return nil, &SyntaxError{Column: tok.valueColumn, Msg: fmt.Sprintf("unknown status %q", tok.value)}
+		}
+		statusList = []domain.Status{status}
+	}
+	return predicate(func(todo *domain.Todo) bool {
+		for _, status := range statusList {
+			if todo.Status == status {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// title: は部分一致、title= は完全一致。どちらも大文字小文字を区別しない。
+func compileTitle(tok token) (Expr, error) {
+	text := strings.ToLower(tok.value)
+	switch tok.op {
+	case ":":
+		return predicate(func(todo *domain.Todo) bool {
+			return strings.Contains(strings.ToLower(todo.Title), text)
+		}), nil
+	case "=":
+		return predicate(func(todo *domain.Todo) bool {
+			return strings.ToLower(todo.Title) == text
+		}), nil
+	}
+	return nil, unsupportedOperator(tok)
+}
+
+func compileID(tok token) (Expr, error) {
+	id, err := strconv.Atoi(tok.value)
+	if err != nil {
+		return nil, &SyntaxError{Column: tok.valueColumn, Msg: fmt.Sprintf("invalid id %q", tok.value)}
+	}
+
+	var match func(got int) bool
+	switch tok.op {
+	case ":", "=":
+		match = func(got int) bool { return got == id }
+	case "<":
+		match = func(got int) bool { return got < id }
+	case "<=":
+		match = func(got int) bool { return got <= id }
+	case ">":
+		match = func(got int) bool { return got > id }
+	case ">=":
+		match = func(got int) bool { return got >= id }
+	}
+	return predicate(func(todo *domain.Todo) bool { return match(todo.ID) }), nil
+}
+
+// compileDate は日付単位で比較する。created<2026-11-01 は 11/1 より前、created:2026-11-01 は 11/1 中を表す。
+// 日付はサーバーのローカルタイムで解釈する。
+func compileDate(tok token, now time.Time, field func(todo *domain.Todo) *time.Time) (Expr, error) {
+	start, err := parseDate(tok.value, now)
+	if err != nil {
+		return nil, &SyntaxError{Column: tok.valueColumn, Msg: fmt.Sprintf("invalid date %q, expected YYYY-MM-DD, today, yesterday or -Nd", tok.value)}
+	}
+	end := start.AddDate(0, 0, 1)
+
+	var match func(t time.Time) bool
+	switch tok.op {
+	case ":", "=":
+		match = func(t time.Time) bool { return !t.Before(start) && t.Before(end) }
+	case "<":
+		match = func(t time.Time) bool { return t.Before(start) }
+	case "<=":
+		match = func(t time.Time) bool { return t.Before(end) }
+	case ">":
+		match = func(t time.Time) bool { return !t.Before(end) }
+	case ">=":
+		match = func(t time.Time) bool { return !t.Before(start) }
+	}
+	return predicate(func(todo *domain.Todo) bool {
+		t := field(todo)
+		return t != nil && match(*t)
+	}), nil
+}
+
+// parseDate は日付をその日の 0 時として返す。
+func parseDate(value string, now time.Time) (time.Time, error) {
+	now = now.In(time.Local)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	switch {
+	case value == "today":
+		return today, nil
+	case value == "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case strings.HasPrefix(value, "-") && strings.HasSuffix(value, "d"):
+		days, err := strconv.ParseUint(value[1:len(value)-1], 10, 16)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return today.AddDate(0, 0, -int(days)), nil
+	}
+	return time.ParseInLocation(dateLayout, value, time.Local)
+}
+
+func unsupportedOperator(tok token) error {
+	return &SyntaxError{Column: tok.column + len([]rune(tok.field)), Msg: fmt.Sprintf("operator %q is not supported for %q", tok.op, tok.field)}
+}
@@ -0,0 +1,167 @@
+package filter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestExpr_Match(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 11, d, 12, 0, 0, 0, time.Local) }
+	completedAt := day(3)
+	todos := []*domain.Todo{
+		{ID: 1, Title: "Write release notes", Status: domain.StatusTodo, CreatedAt: day(1), UpdatedAt: day(1)},
+		{ID: 2, Title: "Release notes draft", Status: domain.StatusInProgress, CreatedAt: day(2), UpdatedAt: day(4)},
+		{ID: 3, Title: "Buy milk", Status: domain.StatusDone, CreatedAt: day(2), UpdatedAt: day(3), CompletedAt: &completedAt},
+		{ID: 4, Title: "牛乳を買う", Status: domain.StatusCancelled, CreatedAt: day(3), UpdatedAt: day(3)},
+	}
+
+	tests := []struct {
+		name    string
+		q       string
+		wantIDs []int
+	}{
+		{name: "word", q: "milk", wantIDs: []int{3}},
+		{name: "case insensitive phrase", q: `"RELEASE NOTES"`, wantIDs: []int{1, 2}},
+		{name: "japanese word", q: "牛乳", wantIDs: []int{4}},
+		{name: "status", q: "status:done", wantIDs: []int{3}},
+		{name: "status open", q: "status:open", wantIDs: []int{1, 2}},
+		{name: "status closed", q: "status:closed", wantIDs: []int{3, 4}},
+		{name: "implicit and", q: `status:open "release notes" -title:draft`, wantIDs: []int{1}},
+		{name: "or", q: "milk OR draft", wantIDs: []int{2, 3}},
+		{name: "parens", q: "(status:done OR status:cancelled) -milk", wantIDs: []int{4}},
+		{name: "negated group", q: "-(status:done OR status:cancelled)", wantIDs: []int{1, 2}},
+		{name: "title exact", q: `title="buy milk"`, wantIDs: []int{3}},
+		{name: "id range", q: "id>=2 id<4", wantIDs: []int{2, 3}},
+		{name: "created before", q: "created<2026-11-02", wantIDs: []int{1}},
+		{name: "created on", q: "created:2026-11-02", wantIDs: []int{2, 3}},
+		{name: "created on or before", q: "created<=2026-11-02", wantIDs: []int{1, 2, 3}},
+		{name: "updated after", q: "updated>2026-11-03", wantIDs: []int{2}},
+		{name: "completed", q: "completed>=2026-11-01", wantIDs: []int{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.q, domain.DefaultStateMachine())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			ids := []int{}
+			for _, todo := range todos {
+				if expr.Match(todo) {
+					ids = append(ids, todo.ID)
+				}
+			}
+
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("Expected %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
+
+func TestExpr_Match_RelativeDate(t *testing.T) {
+	now := time.Date(2026, 11, 10, 9, 0, 0, 0, time.Local)
+	todoAt := func(updatedAt time.Time) *domain.Todo {
+		return &domain.Todo{ID: 1, Title: "Buy milk", UpdatedAt: updatedAt}
+	}
+
+	tests := []struct {
+		name      string
+		q         string
+		updatedAt time.Time
+		want      bool
+	}{
+		{name: "today", q: "updated:today", updatedAt: now.Add(-8 * time.Hour), want: true},
+		{name: "not today", q: "updated:today", updatedAt: now.Add(-10 * time.Hour), want: false},
+		{name: "yesterday", q: "updated:yesterday", updatedAt: now.Add(-10 * time.Hour), want: true},
+		{name: "within 7 days", q: "updated>=-7d", updatedAt: time.Date(2026, 11, 3, 0, 0, 0, 0, time.Local), want: true},
+		{name: "older than 7 days", q: "updated>=-7d", updatedAt: time.Date(2026, 11, 2, 23, 59, 0, 0, time.Local), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseAt(tt.q, now, domain.DefaultStateMachine())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if got := expr.Match(todoAt(tt.updatedAt)); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParse_StatusUsesMachine(t *testing.T) {
+	// Given: todo・blocked・done だけを持つステートマシン
+	machine, err := domain.NewStateMachine(domain.StatusTodo, map[domain.Status][]domain.Status{
+		domain.StatusTodo: {"blocked", domain.StatusDone},
+		"blocked":         {domain.StatusTodo},
+		domain.StatusDone: {domain.StatusTodo},
+	}, []domain.Status{domain.StatusDone})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// When:  独自のステータスと、このマシンにないステータスで Parse する
+	expr, err := Parse("status:blocked", machine)
+	_, unknownErr := Parse("status:in_progress", machine)
+
+	// Then:  マシンにあるステータスだけを受け付ける
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !expr.Match(&domain.Todo{Status: "blocked"}) || expr.Match(&domain.Todo{Status: domain.StatusTodo}) {
+		t.Error("Expected status:blocked to match only blocked todos")
+	}
+	if !errors.Is(unknownErr, domain.ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter, got %v", unknownErr)
+	}
+}
+
+func TestParse_StatusGroupsUseMachine(t *testing.T) {
+	// Given: blocked で待つことができ、done だけが終了のステートマシン
+	machine, err := domain.NewStateMachine(domain.StatusTodo, map[domain.Status][]domain.Status{
+		domain.StatusTodo: {"blocked", domain.StatusDone},
+		"blocked":         {domain.StatusTodo},
+		domain.StatusDone: {domain.StatusTodo},
+	}, []domain.Status{domain.StatusDone})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// When:  status:open と status:closed で Parse する
+	open, err := Parse("status:open", machine)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	closed, err := Parse("status:closed", machine)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then:  終了していない独自のステータスは open に、終了したステータスだけが closed に含まれる
+	tests := []struct {
+		status     domain.Status
+		wantOpen   bool
+		wantClosed bool
+	}{
+		{status: domain.StatusTodo, wantOpen: true},
+		{status: "blocked", wantOpen: true},
+		{status: domain.StatusDone, wantClosed: true},
+	}
+	for _, tt := range tests {
+		todo := &domain.Todo{Status: tt.status}
+		if got := open.Match(todo); got != tt.wantOpen {
+			t.Errorf("Expected status:open to match %s = %v, got %v", tt.status, tt.wantOpen, got)
+		}
+		if got := closed.Match(todo); got != tt.wantClosed {
+			t.Errorf("Expected status:closed to match %s = %v, got %v", tt.status, tt.wantClosed, got)
+		}
+	}
+}
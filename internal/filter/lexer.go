@@ -0,0 +1,122 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenTerm
+	tokenNot
+	tokenOr
+	tokenLParen
+	tokenRParen
+)
+
+// token の column / valueColumn はエラー表示用の 1 始まりの文字位置。
+type token struct {
+	kind        tokenKind
+	column      int
+	field       string
+	op          string
+	value       string
+	valueColumn int
+}
+
+var operators = []string{"<=", ">=", ":", "=", "<", ">"}
+
+type lexer struct {
+	input  []rune
+	offset int
+}
+
+func (l *lexer) next() (token, error) {
+	for l.offset < len(l.input) && unicode.IsSpace(l.input[l.offset]) {
+		l.offset++
+	}
+	column := l.offset + 1
+	if l.offset >= len(l.input) {
+		return token{kind: tokenEOF, column: column}, nil
+	}
+
+	switch l.input[l.offset] {
+	case '(':
+		l.offset++
+		return token{kind: tokenLParen, column: column}, nil
+	case ')':
+		l.offset++
+		return token{kind: tokenRParen, column: column}, nil
+	case '-':
+		l.offset++
+		return token{kind: tokenNot, column: column}, nil
+	}
+	return l.term()
+}
+
+// term は空白か括弧までを1つの条件として読み取る。引用符の中の空白や括弧は値の一部として扱う。
+func (l *lexer) term() (token, error) {
+	tok := token{kind: tokenTerm, column: l.offset + 1}
+
+	var raw strings.Builder
+	quoted := false
+	for l.offset < len(l.input) {
+		r := l.input[l.offset]
+		if unicode.IsSpace(r) || r == '(' || r == ')' {
+			break
+		}
+		if r == '"' {
+			end := l.offset + 1
+			for end < len(l.input) && l.input[end] != '"' {
+				end++
+			}
+			if end >= len(l.input) {
+				return token{}, &SyntaxError{Column: l.offset + 1, Msg: "unterminated quoted string"}
+			}
+			raw.WriteString(string(l.input[l.offset+1 : end]))
+			l.offset = end + 1
+			quoted = true
+			continue
+		}
+
+		if !quoted && tok.op == "" && raw.Len() > 0 && isField(raw.String()) {
+			if op := l.operator(); op != "" {
+				tok.field = strings.ToLower(raw.String())
+				tok.op = op
+				l.offset += len(op)
+				tok.valueColumn = l.offset + 1
+				raw.Reset()
+				continue
+			}
+		}
+		raw.WriteRune(r)
+		l.offset++
+	}
+
+	tok.value = raw.String()
+	if tok.op == "" && !quoted && tok.value == "OR" {
+		return token{kind: tokenOr, column: tok.column}, nil
+	}
+	return tok, nil
+}
+
+func (l *lexer) operator() string {
+	rest := string(l.input[l.offset:min(l.offset+2, len(l.input))])
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+func isField(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package filter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func lexAll(t *testing.T, q string) []token {
+	t.Helper()
+	l := &lexer{input: []rune(q)}
+	var tokens []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if tok.kind == tokenEOF {
+			return tokens
+		}
+		tokens = append(tokens, tok)
+	}
+}
+
+func TestLexer(t *testing.T) {
+	tests := []struct {
+		name   string
+		q      string
+		tokens []token
+	}{
+		{name: "word", q: "milk", tokens: []token{{kind: tokenTerm, column: 1, value: "milk"}}},
+		{name: "field", q: "status:open", tokens: []token{{kind: tokenTerm, column: 1, field: "status", op: ":", value: "open", valueColumn: 8}}},
+		{name: "comparison", q: "created<=2026-11-01", tokens: []token{{kind: tokenTerm, column: 1, field: "created", op: "<=", value: "2026-11-01", valueColumn: 10}}},
+		{name: "quoted phrase", q: `"release notes"`, tokens: []token{{kind: tokenTerm, column: 1, value: "release notes"}}},
+		{name: "quoted field value", q: `title:"a (b)"`, tokens: []token{{kind: tokenTerm, column: 1, field: "title", op: ":", value: "a (b)", valueColumn: 7}}},
+		{name: "quoted colon is not a field", q: `"a:b"`, tokens: []token{{kind: tokenTerm, column: 1, value: "a:b"}}},
+		{name: "negation", q: "-milk", tokens: []token{{kind: tokenNot, column: 1}, {kind: tokenTerm, column: 2, value: "milk"}}},
+		{name: "or and parens", q: "(a OR b)", tokens: []token{
+			{kind: tokenLParen, column: 1},
+			{kind: tokenTerm, column: 2, value: "a"},
+			{kind: tokenOr, column: 4},
+			{kind: tokenTerm, column: 7, value: "b"},
+			{kind: tokenRParen, column: 8},
+		}},
+		{name: "quoted OR is a word", q: `"OR"`, tokens: []token{{kind: tokenTerm, column: 1, value: "OR"}}},
+		{name: "columns count characters", q: "牛乳　status:done", tokens: []token{
+			{kind: tokenTerm, column: 1, value: "牛乳"},
+			{kind: tokenTerm, column: 4, field: "status", op: ":", value: "done", valueColumn: 11},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := lexAll(t, tt.q)
+
+			if !reflect.DeepEqual(tokens, tt.tokens) {
+				t.Errorf("Expected %+v, got %+v", tt.tokens, tokens)
+			}
+		})
+	}
+}
+
+func TestLexer_UnterminatedQuote(t *testing.T) {
+	// Given: 閉じていない引用符を含む検索式
+	l := &lexer{input: []rune(`milk "release notes`)}
+
+	// When:  トークンを読み進める
+	l.next()
+	_, err := l.next()
+
+	// Then:  引用符の位置を示す SyntaxError が返る
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("Expected SyntaxError, got %v", err)
+	}
+	if syntaxErr.Column != 6 {
+		t.Errorf("Expected column 6, got %d", syntaxErr.Column)
+	}
+}
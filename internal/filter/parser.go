@@ -0,0 +1,171 @@
+// Package filter は Todo 一覧を絞り込むための検索式を解釈する。
+//
+//	status:open created<2026-11-01 "release notes" -title:draft
+//
+// 空白で区切った条件はすべて満たす必要があり（AND）、OR と括弧で選択肢を、先頭の - で否定を表す。
+// フィールドのない語や引用符で囲んだ語はタイトルの部分一致になる。
+// 使えるフィールドは status, title, id, created, updated, completed。
+// 日付は YYYY-MM-DD のほか today, yesterday, -7d（7日前）のように書ける。
+package filter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// SyntaxError は検索式の誤りと、その位置（1 始まりの文字位置）を表す。
+type SyntaxError struct {
+	Column int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at column %d: %s", e.Column, e.Msg)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return domain.ErrInvalidFilter
+}
+
+type parser struct {
+	lexer   *lexer
+	tok     token
+	now     time.Time
+	machine *domain.StateMachine
+}
+
+// Parse は検索式を解釈する。空の検索式はすべての Todo に一致する。
+// status: に指定できるステータスは machine で決まる。
+func Parse(q string, machine *domain.StateMachine) (Expr, error) {
+	return ParseAt(q, time.Now(), machine)
+}
+
+// ParseAt は today や -7d などの相対的な日付を now を基準に解釈する。
+func ParseAt(q string, now time.Time, machine *domain.StateMachine) (Expr, error) {
+	p := &parser{lexer: &lexer{input: []rune(q)}, now: now, machine: machine}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenEOF {
+		return andExpr{}, nil
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.unexpected()
+	}
+	return expr, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// parseOr: and ( OR and )*
+func (p *parser) parseOr() (Expr, error) {
+	expr, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	exprList := orExpr{expr}
+	for p.tok.kind == tokenOr {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprList = append(exprList, expr)
+	}
+	if len(exprList) == 1 {
+		return exprList[0], nil
+	}
+	return exprList, nil
+}
+
+// parseAnd: unary unary*
+func (p *parser) parseAnd() (Expr, error) {
+	var exprList andExpr
+	for p.tok.kind == tokenTerm || p.tok.kind == tokenNot || p.tok.kind == tokenLParen {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		exprList = append(exprList, expr)
+	}
+
+	switch len(exprList) {
+	case 0:
+		return nil, p.unexpected()
+	case 1:
+		return exprList[0], nil
+	}
+	return exprList, nil
+}
+
+// parseUnary: - unary | ( or ) | term
+func (p *parser) parseUnary() (Expr, error) {
+	switch p.tok.kind {
+	case tokenNot:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokenTerm && p.tok.kind != tokenNot && p.tok.kind != tokenLParen {
+			return nil, p.unexpected()
+		}
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr}, nil
+	case tokenLParen:
+		column := p.tok.column
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokenRParen {
+			return nil, &SyntaxError{Column: column, Msg: `unclosed "("`}
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+
+	expr, err := compileTerm(p.tok, p.now, p.machine)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func (p *parser) unexpected() error {
+	switch p.tok.kind {
+	case tokenEOF:
+		return &SyntaxError{Column: p.tok.column, Msg: "unexpected end of query"}
+	case tokenOr:
+		return &SyntaxError{Column: p.tok.column, Msg: `unexpected "OR"`}
+	case tokenRParen:
+		return &SyntaxError{Column: p.tok.column, Msg: `unexpected ")"`}
+	}
+	return &SyntaxError{Column: p.tok.column, Msg: "unexpected token"}
+}
@@ -0,0 +1,74 @@
+package filter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestParse_SyntaxError(t *testing.T) {
+	tests := []struct {
+		name   string
+		q      string
+		column int
+		msg    string
+	}{
+		{name: "unknown field", q: "status:open tag:backend", column: 13, msg: `unknown field "tag"`},
+		{name: "missing value", q: "status:", column: 8, msg: `missing value for "status"`},
+		{name: "unknown status", q: "status:doing", column: 8, msg: `unknown status "doing"`},
+		{name: "invalid date", q: "created<2026-13-01", column: 9, msg: `invalid date "2026-13-01", expected YYYY-MM-DD, today, yesterday or -Nd`},
+		{name: "invalid relative date", q: "updated>=--3d", column: 10, msg: `invalid date "--3d", expected YYYY-MM-DD, today, yesterday or -Nd`},
+		{name: "invalid id", q: "id>abc", column: 4, msg: `invalid id "abc"`},
+		{name: "unsupported operator", q: "status<open", column: 7, msg: `operator "<" is not supported for "status"`},
+		{name: "unterminated quote", q: `"release notes`, column: 1, msg: "unterminated quoted string"},
+		{name: "unclosed paren", q: "(a OR b", column: 1, msg: `unclosed "("`},
+		{name: "unexpected paren", q: "a)", column: 2, msg: `unexpected ")"`},
+		{name: "leading OR", q: "OR a", column: 1, msg: `unexpected "OR"`},
+		{name: "trailing OR", q: "a OR", column: 5, msg: "unexpected end of query"},
+		{name: "dangling negation", q: "a -", column: 4, msg: "unexpected end of query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.q, domain.DefaultStateMachine())
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Expected SyntaxError, got %v", err)
+			}
+			if syntaxErr.Column != tt.column || syntaxErr.Msg != tt.msg {
+				t.Errorf("Expected %q at column %d, got %q at column %d", tt.msg, tt.column, syntaxErr.Msg, syntaxErr.Column)
+			}
+			if !errors.Is(err, domain.ErrInvalidFilter) {
+				t.Errorf("Expected error to wrap ErrInvalidFilter, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSyntaxError_Error(t *testing.T) {
+	// Given: 位置とメッセージを持つ SyntaxError
+	err := &SyntaxError{Column: 13, Msg: `unknown field "tag"`}
+
+	// When:  Error を呼び出す
+	// Then:  位置を含むメッセージが返る
+	want := `syntax error at column 13: unknown field "tag"`
+	if err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	// Given: 空白だけの検索式
+	// When:  Parse する
+	// Then:  すべての Todo に一致する
+	expr, err := Parse("   ", domain.DefaultStateMachine())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !expr.Match(&domain.Todo{ID: 1, Title: "Buy milk"}) {
+		t.Error("Expected empty filter to match")
+	}
+}
@@ -0,0 +1,102 @@
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateTimeFormat = "20060102T150405Z"
+	dateFormat     = "20060102"
+
+	// maxLineOctets を超える行は折り返す（RFC 5545 3.1）。
+	maxLineOctets = 75
+)
+
+// Encode は cal を iCalendar の形式で w に書く。stamp は各 VTODO の DTSTAMP。
+func Encode(w io.Writer, cal Calendar, stamp time.Time) error {
+	e := &encoder{w: bufio.NewWriter(w)}
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", cal.ProdID)
+	e.line("CALSCALE", "GREGORIAN")
+	if cal.Name != "" {
+		e.line("X-WR-CALNAME", escapeText(cal.Name))
+	}
+	for _, todo := range cal.Todos {
+		e.todo(todo, stamp)
+	}
+	e.line("END", "VCALENDAR")
+	return e.w.Flush()
+}
+
+type encoder struct {
+	w *bufio.Writer
+}
+
+func (e *encoder) todo(todo VTodo, stamp time.Time) {
+	e.line("BEGIN", "VTODO")
+	e.line("UID", escapeText(todo.UID))
+	e.line("DTSTAMP", formatDateTime(stamp))
+	e.line("SUMMARY", escapeText(todo.Summary))
+	if todo.Description != "" {
+		e.line("DESCRIPTION", escapeText(todo.Description))
+	}
+	if todo.Status != "" {
+		e.line("STATUS", todo.Status)
+	}
+	if !todo.Created.IsZero() {
+		e.line("CREATED", formatDateTime(todo.Created))
+	}
+	if !todo.LastModified.IsZero() {
+		e.line("LAST-MODIFIED", formatDateTime(todo.LastModified))
+	}
+	if todo.Completed != nil {
+		e.line("COMPLETED", formatDateTime(*todo.Completed))
+	}
+	if todo.Due != nil {
+		e.line("DUE", formatDateTime(*todo.Due))
+	}
+	if todo.Priority > 0 {
+		e.line("PRIORITY", strconv.Itoa(todo.Priority))
+	}
+	if todo.RRule != "" {
+		e.line("RRULE", todo.RRule)
+	}
+	e.line("END", "VTODO")
+}
+
+// line は "name:value" を CRLF で終わる行として書き、75 オクテットを超える部分は空白で始まる行に折り返す。
+// UTF-8 の文字の途中では折り返さない。
+func (e *encoder) line(name, value string) {
+	s := name + ":" + value
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		e.w.WriteString(s[:cut])
+		e.w.WriteString("\r\n ")
+		s = s[cut:]
+		// 続きの行は先頭の空白も 75 オクテットに含める
+		limit = maxLineOctets - 1
+	}
+	e.w.WriteString(s)
+	e.w.WriteString("\r\n")
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeFormat)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escapeText は TEXT 型の値の \ ; , と改行をエスケープする。
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
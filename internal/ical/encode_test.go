@@ -0,0 +1,81 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	// Given: 完了した VTODO と、期限・優先度・繰り返しを持つ VTODO
+	at := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	cal := Calendar{
+		ProdID: "-//test//EN",
+		Name:   "Work, home",
+		Todos: []VTodo{
+			{UID: "1@test", Summary: "Buy milk; eggs", Status: StatusCompleted, Created: at, LastModified: at, Completed: &at},
+			{UID: "2@test", Summary: "Weekly review", Due: &at, Priority: 1, RRule: "FREQ=WEEKLY"},
+		},
+	}
+	var buf bytes.Buffer
+
+	// When:  書き出す
+	if err := Encode(&buf, cal, at); err != nil {
+		t.Fatal(err)
+	}
+
+	// Then:  CRLF の行で、TEXT はエスケープされ、指定した項目だけが出る
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//test//EN",
+		"CALSCALE:GREGORIAN",
+		`X-WR-CALNAME:Work\, home`,
+		"BEGIN:VTODO",
+		"UID:1@test",
+		"DTSTAMP:20261018T093000Z",
+		`SUMMARY:Buy milk\; eggs`,
+		"STATUS:COMPLETED",
+		"CREATED:20261018T093000Z",
+		"LAST-MODIFIED:20261018T093000Z",
+		"COMPLETED:20261018T093000Z",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:2@test",
+		"DTSTAMP:20261018T093000Z",
+		"SUMMARY:Weekly review",
+		"DUE:20261018T093000Z",
+		"PRIORITY:1",
+		"RRULE:FREQ=WEEKLY",
+		"END:VTODO",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if buf.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestEncode_FoldsLongLines(t *testing.T) {
+	// Given: 75 オクテットを超える日本語のタイトル
+	summary := strings.Repeat("買い物リスト", 10)
+	var buf bytes.Buffer
+
+	// When:  書き出す
+	Encode(&buf, Calendar{Todos: []VTodo{{UID: "1", Summary: summary}}}, time.Now())
+
+	// Then:  どの行も 75 オクテット以下で、読み込めば元に戻る
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("Expected at most %d octets, got %d: %q", maxLineOctets, len(line), line)
+		}
+	}
+	todos, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(todos) != 1 || todos[0].Summary != summary {
+		t.Errorf("Expected %q, got %+v", summary, todos)
+	}
+}
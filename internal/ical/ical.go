@@ -0,0 +1,39 @@
+// Package ical は iCalendar（RFC 5545）の VTODO を書き出し、読み込む。
+package ical
+
+import (
+	"errors"
+	"time"
+)
+
+// VTODO の STATUS。
+const (
+	StatusNeedsAction = "NEEDS-ACTION"
+	StatusInProcess   = "IN-PROCESS"
+	StatusCompleted   = "COMPLETED"
+	StatusCancelled   = "CANCELLED"
+)
+
+var ErrInvalidCalendar = errors.New("invalid iCalendar data")
+
+// VTodo は VTODO コンポーネント。ゼロ値・nil の項目は書き出さない。
+// Priority は 1（最高）〜 9（最低）で、0 は未指定。RRule は RRULE の値をそのまま持つ。
+type VTodo struct {
+	UID          string
+	Summary      string
+	Description  string
+	Status       string
+	Created      time.Time
+	LastModified time.Time
+	Completed    *time.Time
+	Due          *time.Time
+	Priority     int
+	RRule        string
+}
+
+// Calendar は VCALENDAR。Name はカレンダーアプリで表示する名前（X-WR-CALNAME）。
+type Calendar struct {
+	ProdID string
+	Name   string
+	Todos  []VTodo
+}
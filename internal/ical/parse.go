@@ -0,0 +1,222 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parse は iCalendar のデータから VTODO をすべて読み込む。VEVENT などほかのコンポーネントと、
+// VTODO の中の VALARM は読み飛ばす。形式が正しくなければ ErrInvalidCalendar を包んだエラーを返す。
+func Parse(r io.Reader) ([]VTodo, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var todos []VTodo
+	var stack []string
+	var current *VTodo
+	for _, l := range lines {
+		name, params, value, ok := splitContentLine(l.text)
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: missing ':'", ErrInvalidCalendar, l.number)
+		}
+
+		switch name {
+		case "BEGIN":
+			component := strings.ToUpper(value)
+			if len(stack) == 0 && component != "VCALENDAR" {
+				return nil, fmt.Errorf("%w: line %d: expected BEGIN:VCALENDAR", ErrInvalidCalendar, l.number)
+			}
+			stack = append(stack, component)
+			if component == "VTODO" && len(stack) == 2 {
+				current = &VTodo{}
+			}
+			continue
+		case "END":
+			component := strings.ToUpper(value)
+			if len(stack) == 0 || stack[len(stack)-1] != component {
+				return nil, fmt.Errorf("%w: line %d: unexpected END:%s", ErrInvalidCalendar, l.number, value)
+			}
+			stack = stack[:len(stack)-1]
+			if component == "VTODO" && current != nil && len(stack) == 1 {
+				todos = append(todos, *current)
+				current = nil
+			}
+			continue
+		}
+
+		// VTODO の直下のプロパティだけを読む
+		if current == nil || len(stack) != 2 {
+			continue
+		}
+		if err := current.set(name, params, value); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %s: %v", ErrInvalidCalendar, l.number, name, err)
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("%w: missing END:%s", ErrInvalidCalendar, stack[len(stack)-1])
+	}
+	if lines == nil {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidCalendar)
+	}
+	return todos, nil
+}
+
+func (t *VTodo) set(name string, params map[string]string, value string) error {
+	var err error
+	switch name {
+	case "UID":
+		t.UID = unescapeText(value)
+	case "SUMMARY":
+		t.Summary = unescapeText(value)
+	case "DESCRIPTION":
+		t.Description = unescapeText(value)
+	case "STATUS":
+		t.Status = strings.ToUpper(value)
+	case "CREATED":
+		t.Created, err = parseDateTime(value, params)
+	case "LAST-MODIFIED":
+		t.LastModified, err = parseDateTime(value, params)
+	case "COMPLETED":
+		t.Completed, err = parseOptionalDateTime(value, params)
+	case "DUE":
+		t.Due, err = parseOptionalDateTime(value, params)
+	case "PRIORITY":
+		t.Priority, err = strconv.Atoi(value)
+		if err == nil && (t.Priority < 0 || t.Priority > 9) {
+			err = fmt.Errorf("out of range 0-9")
+		}
+	case "RRULE":
+		t.RRule = value
+	}
+	return err
+}
+
+type contentLine struct {
+	number int
+	text   string
+}
+
+// unfold は行を読み込み、空白で始まる行を前の行に連結する（RFC 5545 3.1）。空行は無視する。
+// 読み込みのエラーは ErrInvalidCalendar と合わせて包む。
+func unfold(r io.Reader) ([]contentLine, error) {
+	var lines []contentLine
+	br := bufio.NewReader(r)
+	for number := 1; ; number++ {
+		text, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCalendar, err)
+		}
+		text = strings.TrimRight(text, "\r\n")
+		switch {
+		case text == "":
+		case (text[0] == ' ' || text[0] == '\t') && len(lines) > 0:
+			lines[len(lines)-1].text += text[1:]
+		default:
+			lines = append(lines, contentLine{number: number, text: text})
+		}
+		if err == io.EOF {
+			return lines, nil
+		}
+	}
+}
+
+// splitContentLine は "NAME;PARAM=VALUE:value" を分ける。引用符の中の ; と : は区切りとみなさない。
+// 名前とパラメータ名は大文字にする。
+func splitContentLine(line string) (string, map[string]string, string, bool) {
+	inQuote := false
+	colon := -1
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			inQuote = !inQuote
+		case ':':
+			if !inQuote {
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	head, value := line[:colon], line[colon+1:]
+	parts := splitUnquoted(head, ';')
+	params := map[string]string{}
+	for _, p := range parts[1:] {
+		key, val, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, value, true
+}
+
+func splitUnquoted(s string, sep byte) []string {
+	var parts []string
+	inQuote := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			inQuote = !inQuote
+		case sep:
+			if !inQuote {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseDateTime は UTC（末尾が Z）、TZID 付きまたは浮動の日時、日付（VALUE=DATE）を読む。
+// 浮動の日時と日付は、TZID がなければ UTC とみなす。
+func parseDateTime(value string, params map[string]string) (time.Time, error) {
+	loc := time.UTC
+	if tzid := params["TZID"]; tzid != "" && !strings.HasSuffix(value, "Z") {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	for _, layout := range []string{dateTimeFormat, "20060102T150405", dateFormat} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date-time %q", value)
+}
+
+func parseOptionalDateTime(value string, params map[string]string) (*time.Time, error) {
+	t, err := parseDateTime(value, params)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// unescapeText は escapeText の逆。
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,93 @@
+package ical
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	// Given: 他のアプリが書き出したカレンダー（LF の改行、折り返し、VEVENT、VALARM を含む）
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//other//EN",
+		"BEGIN:VEVENT",
+		"UID:event",
+		"SUMMARY:Meeting",
+		"END:VEVENT",
+		"BEGIN:VTODO",
+		"UID:abc",
+		`SUMMARY:Write the quarterly report\, part `,
+		" one",
+		"STATUS:in-process",
+		"DUE;TZID=Asia/Tokyo:20261020T180000",
+		"PRIORITY:5",
+		"RRULE:FREQ=MONTHLY;BYMONTHDAY=1",
+		"BEGIN:VALARM",
+		"SUMMARY:Alarm",
+		"END:VALARM",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"SUMMARY;LANGUAGE=en:Call \"Bob\"",
+		"STATUS:COMPLETED",
+		"COMPLETED:20261018T093000Z",
+		"DUE;VALUE=DATE:20261019",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\n")
+
+	// When:  読み込む
+	todos, err := Parse(strings.NewReader(data))
+
+	// Then:  VTODO だけが、VALARM の項目に上書きされずに読み込まれる
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(todos) != 2 {
+		t.Fatalf("Expected 2 todos, got %d", len(todos))
+	}
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	first := todos[0]
+	if first.UID != "abc" || first.Summary != "Write the quarterly report, part one" || first.Status != StatusInProcess {
+		t.Errorf("Unexpected todo: %+v", first)
+	}
+	if first.Due == nil || !first.Due.Equal(time.Date(2026, 10, 20, 18, 0, 0, 0, tokyo)) || first.Priority != 5 || first.RRule != "FREQ=MONTHLY;BYMONTHDAY=1" {
+		t.Errorf("Unexpected due, priority or rule: %v %d %s", first.Due, first.Priority, first.RRule)
+	}
+	second := todos[1]
+	if second.Summary != `Call "Bob"` || second.Completed == nil || !second.Completed.Equal(time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected todo: %+v", second)
+	}
+	if second.Due == nil || !second.Due.Equal(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected date-only due, got %v", second.Due)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "empty", data: ""},
+		{name: "not a calendar", data: "BEGIN:VTODO\nEND:VTODO\n"},
+		{name: "missing end", data: "BEGIN:VCALENDAR\nBEGIN:VTODO\nSUMMARY:x\n"},
+		{name: "mismatched end", data: "BEGIN:VCALENDAR\nBEGIN:VTODO\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{name: "no colon", data: "BEGIN:VCALENDAR\nBEGIN:VTODO\nSUMMARY\nEND:VTODO\nEND:VCALENDAR\n"},
+		{name: "bad date", data: "BEGIN:VCALENDAR\nBEGIN:VTODO\nDUE:tomorrow\nEND:VTODO\nEND:VCALENDAR\n"},
+		{name: "bad priority", data: "BEGIN:VCALENDAR\nBEGIN:VTODO\nPRIORITY:10\nEND:VTODO\nEND:VCALENDAR\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When:  不正なデータを読み込む
+			_, err := Parse(strings.NewReader(tt.data))
+
+			// Then:  ErrInvalidCalendar になる
+			if !errors.Is(err, ErrInvalidCalendar) {
+				t.Errorf("Expected ErrInvalidCalendar, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ArchiveTodoUsecase interface {
+	Execute(ctx context.Context, id int) (*domain.Todo, error)
+}
+
+type ListArchiveTodoUsecase interface {
+	Execute(ctx context.Context, query string) ([]*domain.Todo, error)
+}
+
+type UnarchiveTodoUsecase interface {
+	Execute(ctx context.Context, id int) (*domain.Todo, error)
+}
+
+type ArchiveHandler struct {
+	archiveUsecase     ArchiveTodoUsecase
+	listArchiveUsecase ListArchiveTodoUsecase
+	unarchiveUsecase   UnarchiveTodoUsecase
+}
+
+func NewArchiveHandler(archive ArchiveTodoUsecase, listArchive ListArchiveTodoUsecase, unarchive UnarchiveTodoUsecase) *ArchiveHandler {
+	return &ArchiveHandler{
+		archiveUsecase:     archive,
+		listArchiveUsecase: listArchive,
+		unarchiveUsecase:   unarchive,
+	}
+}
+
+func (h *ArchiveHandler) ArchiveTodo(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	var id int
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	todo, err := h.archiveUsecase.Execute(r.Context(), id)
+	if err != nil {
+		if err.Error() == "todo not found" {
+			w.WriteHeader(http.StatusNotFound)
+		} else if errors.Is(err, domain.ErrNotArchivable) || errors.Is(err, domain.ErrTodoConflict) {
+			w.WriteHeader(http.StatusConflict)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(todo)
+}
+
+func (h *ArchiveHandler) ListArchive(w http.ResponseWriter, r *http.Request) {
+	todos, err := h.listArchiveUsecase.Execute(r.Context(), r.URL.Query().Get("q"))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(todos)
+}
+
+func (h *ArchiveHandler) UnarchiveTodo(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	var id int
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	todo, err := h.unarchiveUsecase.Execute(r.Context(), id)
+	if err != nil {
+		if err.Error() == "todo not found" {
+			w.WriteHeader(http.StatusNotFound)
+		} else if errors.Is(err, domain.ErrTodoConflict) {
+			w.WriteHeader(http.StatusConflict)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(todo)
+}
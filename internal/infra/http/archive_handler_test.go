@@ -0,0 +1,131 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockArchiveTodoUsecase struct {
+	err error
+}
+
+func (m *mockArchiveTodoUsecase) Execute(ctx context.Context, id int) (*domain.Todo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Todo{ID: id, Title: "Buy milk", Status: domain.StatusDone}, nil
+}
+
+type mockListArchiveTodoUsecase struct {
+	err   error
+	query string
+}
+
+func (m *mockListArchiveTodoUsecase) Execute(ctx context.Context, query string) ([]*domain.Todo, error) {
+	m.query = query
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusDone}}, nil
+}
+
+func TestArchiveTodoHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		err      error
+		wantCode int
+	}{
+		{name: "archived", id: "1", wantCode: http.StatusOK},
+		{name: "invalid id", id: "abc", wantCode: http.StatusBadRequest},
+		{name: "not found", id: "1", err: domain.ErrTodoNotFound, wantCode: http.StatusNotFound},
+		{name: "not finished", id: "1", err: domain.ErrNotArchivable, wantCode: http.StatusConflict},
+		{name: "id conflict", id: "1", err: domain.ErrTodoConflict, wantCode: http.StatusConflict},
+		{name: "internal error", id: "1", err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewArchiveHandler(&mockArchiveTodoUsecase{err: tt.err}, nil, nil)
+
+			req, _ := http.NewRequest("POST", "/todo/"+tt.id+"/archive", nil)
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.ArchiveTodo(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestListArchiveHandler(t *testing.T) {
+	// Given: q パラメータ付きのリクエスト
+	// When:  ListArchive を呼び出す
+	// Then:  200 OK が返り、q が usecase に渡される
+	mockList := &mockListArchiveTodoUsecase{}
+	handler := NewArchiveHandler(nil, mockList, nil)
+
+	req, _ := http.NewRequest("GET", "/archive?q=milk", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListArchive(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if mockList.query != "milk" {
+		t.Errorf("Expected query 'milk', got '%s'", mockList.query)
+	}
+}
+
+func TestListArchiveHandler_UsecaseError(t *testing.T) {
+	handler := NewArchiveHandler(nil, &mockListArchiveTodoUsecase{err: fmt.Errorf("repository error")}, nil)
+
+	req, _ := http.NewRequest("GET", "/archive", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListArchive(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", w.Code)
+	}
+}
+
+func TestUnarchiveTodoHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		err      error
+		wantCode int
+	}{
+		{name: "unarchived", id: "1", wantCode: http.StatusOK},
+		{name: "invalid id", id: "abc", wantCode: http.StatusBadRequest},
+		{name: "not archived", id: "1", err: domain.ErrTodoNotFound, wantCode: http.StatusNotFound},
+		{name: "id conflict", id: "1", err: domain.ErrTodoConflict, wantCode: http.StatusConflict},
+		{name: "internal error", id: "1", err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewArchiveHandler(nil, nil, &mockArchiveTodoUsecase{err: tt.err})
+
+			req, _ := http.NewRequest("POST", "/archive/"+tt.id+"/unarchive", nil)
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.UnarchiveTodo(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
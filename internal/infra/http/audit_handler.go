@@ -0,0 +1,66 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ListAuditUsecase interface {
+	Execute(ctx context.Context, query domain.AuditQuery) ([]*domain.AuditEntry, error)
+}
+
+type AuditHandler struct {
+	listAuditUsecase ListAuditUsecase
+}
+
+func NewAuditHandler(listAudit ListAuditUsecase) *AuditHandler {
+	return &AuditHandler{listAuditUsecase: listAudit}
+}
+
+// ListAudit は監査ログを新しい順に返す。since / until は RFC 3339 形式の時刻。
+func (h *AuditHandler) ListAudit(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := domain.AuditQuery{
+		Actor:      params.Get("actor"),
+		RequestID:  params.Get("request_id"),
+		Resource:   params.Get("resource"),
+		ResourceID: params.Get("resource_id"),
+		Action:     params.Get("action"),
+	}
+	for name, dst := range map[string]**time.Time{"since": &query.Since, "until": &query.Until} {
+		if s := params.Get(name); s != "" {
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			*dst = &t
+		}
+	}
+	if limitStr := params.Get("limit"); limitStr != "" {
+		if _, err := fmt.Sscanf(limitStr, "%d", &query.Limit); err != nil || query.Limit < 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	entries, err := h.listAuditUsecase.Execute(r.Context(), query)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidAuditQuery) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(entries)
+}
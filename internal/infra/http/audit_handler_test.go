@@ -0,0 +1,90 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockListAuditUsecase struct {
+	query domain.AuditQuery
+	err   error
+}
+
+func (m *mockListAuditUsecase) Execute(ctx context.Context, query domain.AuditQuery) ([]*domain.AuditEntry, error) {
+	m.query = query
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*domain.AuditEntry{
+		{Seq: 2, Actor: "alice", Resource: domain.AuditResourceTodo, ResourceID: "1", Action: "title_changed",
+			Diff: []domain.FieldChange{{Field: "title", From: json.RawMessage(`"Buy milk"`), To: json.RawMessage(`"Buy eggs"`)}}},
+	}, nil
+}
+
+func TestListAuditHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		err      error
+		wantCode int
+	}{
+		{name: "all", query: "", wantCode: http.StatusOK},
+		{name: "filtered", query: "?actor=alice&resource=todo&resource_id=1&since=2026-01-01T00:00:00Z&limit=10", wantCode: http.StatusOK},
+		{name: "invalid since", query: "?since=yesterday", wantCode: http.StatusBadRequest},
+		{name: "invalid limit", query: "?limit=0", wantCode: http.StatusBadRequest},
+		{name: "invalid query", query: "", err: domain.ErrInvalidAuditQuery, wantCode: http.StatusBadRequest},
+		{name: "internal error", query: "", err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAuditHandler(&mockListAuditUsecase{err: tt.err})
+
+			req, _ := http.NewRequest("GET", "/audit"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListAudit(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestListAuditHandler_Query(t *testing.T) {
+	// Given: 条件を指定したリクエスト
+	usecase := &mockListAuditUsecase{}
+	handler := NewAuditHandler(usecase)
+
+	req, _ := http.NewRequest("GET", "/audit?actor=alice&request_id=req-1&action=title_changed&since=2026-01-01T00:00:00Z&until=2026-02-01T00:00:00Z&limit=5", nil)
+	w := httptest.NewRecorder()
+
+	// When:  ListAudit を呼び出す
+	handler.ListAudit(w, req)
+
+	// Then:  条件が usecase に渡され、差分を含むエントリが返る
+	q := usecase.query
+	if q.Actor != "alice" || q.RequestID != "req-1" || q.Action != "title_changed" || q.Limit != 5 {
+		t.Errorf("Unexpected query: %+v", q)
+	}
+	if q.Since == nil || !q.Since.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) || q.Until == nil {
+		t.Errorf("Unexpected period: %v - %v", q.Since, q.Until)
+	}
+
+	var entries []map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&entries); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	diff, _ := entries[0]["diff"].([]any)
+	if len(entries) != 1 || len(diff) != 1 || diff[0].(map[string]any)["to"] != "Buy eggs" {
+		t.Errorf("Unexpected entries: %v", entries)
+	}
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type BulkTodoUsecase interface {
+	Execute(ctx context.Context, mode domain.BulkMode, operations []domain.BulkOperation) ([]domain.BulkResult, error)
+}
+
+type BulkHandler struct {
+	bulkUsecase BulkTodoUsecase
+}
+
+func NewBulkHandler(bulk BulkTodoUsecase) *BulkHandler {
+	return &BulkHandler{bulkUsecase: bulk}
+}
+
+type BulkTodoRequest struct {
+	Mode       domain.BulkMode        `json:"mode"`
+	Operations []domain.BulkOperation `json:"operations"`
+}
+
+type BulkTodoResponse struct {
+	Committed bool                `json:"committed"`
+	Results   []domain.BulkResult `json:"results"`
+}
+
+func (h *BulkHandler) BulkTodo(w http.ResponseWriter, r *http.Request) {
+	var req BulkTodoRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = domain.BulkAtomic
+	}
+
+	resultList, err := h.bulkUsecase.Execute(r.Context(), req.Mode, req.Operations)
+	if err != nil && !errors.Is(err, domain.ErrBulkRolledBack) {
+		if errors.Is(err, domain.ErrInvalidBulkMode) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusBadRequest
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(BulkTodoResponse{Committed: err == nil, Results: resultList})
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockBulkTodoUsecase struct {
+	err        error
+	resultList []domain.BulkResult
+	mode       domain.BulkMode
+	operations []domain.BulkOperation
+}
+
+func (m *mockBulkTodoUsecase) Execute(ctx context.Context, mode domain.BulkMode, operations []domain.BulkOperation) ([]domain.BulkResult, error) {
+	m.mode = mode
+	m.operations = operations
+	return m.resultList, m.err
+}
+
+func TestBulkTodoHandler(t *testing.T) {
+	// Given: mode を省略した2件の操作
+	// When:  BulkTodo を呼び出す
+	// Then:  atomic として usecase に渡され、200 OK と committed=true が返る
+	mockBulk := &mockBulkTodoUsecase{
+		resultList: []domain.BulkResult{
+			{Index: 0, Op: domain.BulkCreate, Status: domain.BulkOK},
+			{Index: 1, Op: domain.BulkDelete, Status: domain.BulkOK},
+		},
+	}
+	handler := NewBulkHandler(mockBulk)
+
+	body := strings.NewReader(`{"operations": [{"op": "create", "title": "Buy milk"}, {"op": "delete", "id": 2}]}`)
+	req, _ := http.NewRequest("POST", "/todo/bulk", body)
+	w := httptest.NewRecorder()
+
+	handler.BulkTodo(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if mockBulk.mode != domain.BulkAtomic {
+		t.Errorf("Expected atomic mode, got %q", mockBulk.mode)
+	}
+	if len(mockBulk.operations) != 2 || mockBulk.operations[1].ID != 2 {
+		t.Errorf("Unexpected operations: %+v", mockBulk.operations)
+	}
+	var got BulkTodoResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if !got.Committed || len(got.Results) != 2 {
+		t.Errorf("Unexpected response: %+v", got)
+	}
+}
+
+func TestBulkTodoHandler_RolledBack(t *testing.T) {
+	// Given: usecase が ErrBulkRolledBack と結果を返すモック
+	// When:  BulkTodo を呼び出す
+	// Then:  400 Bad Request と committed=false・結果一覧が返る
+	mockBulk := &mockBulkTodoUsecase{
+		err:        domain.ErrBulkRolledBack,
+		resultList: []domain.BulkResult{{Index: 0, Op: domain.BulkComplete, Status: domain.BulkFailed, Error: "todo not found"}},
+	}
+	handler := NewBulkHandler(mockBulk)
+
+	req, _ := http.NewRequest("POST", "/todo/bulk", strings.NewReader(`{"mode": "atomic", "operations": [{"op": "complete", "id": 9}]}`))
+	w := httptest.NewRecorder()
+
+	handler.BulkTodo(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", w.Code)
+	}
+	var got BulkTodoResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if got.Committed || len(got.Results) != 1 || got.Results[0].Error != "todo not found" {
+		t.Errorf("Unexpected response: %+v", got)
+	}
+}
+
+func TestBulkTodoHandler_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{name: "invalid json", body: `not json`, wantCode: http.StatusBadRequest},
+		{name: "invalid mode", body: `{"mode": "partial"}`, err: domain.ErrInvalidBulkMode, wantCode: http.StatusBadRequest},
+		{name: "storage error", body: `{"operations": []}`, err: fmt.Errorf("storage failure"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewBulkHandler(&mockBulkTodoUsecase{err: tt.err})
+
+			req, _ := http.NewRequest("POST", "/todo/bulk", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.BulkTodo(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package http
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/ical"
+)
+
+const (
+	calendarContentType = "text/calendar"
+	calendarProdID      = "-//go-todo//todo feed//EN"
+
+	// exportedUIDSuffix は書き出した VTODO の UID（"todo-<ID>@go-todo"）の後ろの部分。
+	exportedUIDSuffix = "@go-todo"
+)
+
+type ImportTodoUsecase interface {
+	Execute(ctx context.Context, items []domain.ImportTodo) (*domain.ImportResult, error)
+}
+
+// CalendarHandler は Todo を iCalendar の VTODO としてカレンダーアプリに配り、VTODO を取り込む。
+// 利用者ごとの区別はないため、フィードは feedToken を知っていれば誰でも読める。feedToken が空ならフィードは無効。
+// Todo には期限・優先度・繰り返しがないため、フィードに DUE・PRIORITY・RRULE は出さず、取り込みでも捨てる。
+type CalendarHandler struct {
+	listUsecase     ListTodoUsecase
+	listViewUsecase ListViewTodoUsecase
+	importUsecase   ImportTodoUsecase
+	feedToken       string
+}
+
+func NewCalendarHandler(list ListTodoUsecase, listView ListViewTodoUsecase, imp ImportTodoUsecase, feedToken string) *CalendarHandler {
+	return &CalendarHandler{listUsecase: list, listViewUsecase: listView, importUsecase: imp, feedToken: feedToken}
+}
+
+// ExportFeed は Todo を .ics で返す。view を指定するとそのビューの Todo だけを返す。
+// カレンダーアプリはヘッダーを付けられないことが多いため、token はクエリパラメータでも受け付ける。
+func (h *CalendarHandler) ExportFeed(w http.ResponseWriter, r *http.Request) {
+	if h.feedToken == "" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="todo feed"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	name := "Todos"
+	var todos []*domain.Todo
+	var err error
+	if viewID := r.URL.Query().Get("view"); viewID != "" {
+		name += ": " + viewID
+		todos, err = h.listViewUsecase.Execute(r.Context(), viewID)
+	} else {
+		todos, err = h.listUsecase.Execute(r.Context(), domain.ListQuery{})
+	}
+	if err != nil {
+		writeViewError(w, err)
+		return
+	}
+
+	cal := ical.Calendar{ProdID: calendarProdID, Name: name, Todos: make([]ical.VTodo, len(todos))}
+	for i, todo := range todos {
+		cal.Todos[i] = newVTodo(todo)
+	}
+	w.Header().Set("Content-Type", calendarContentType+"; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-cache")
+	ical.Encode(w, cal, time.Now())
+}
+
+func (h *CalendarHandler) authorized(r *http.Request) bool {
+	token := r.URL.Query().Get("token")
+	if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		token = bearer
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.feedToken)) == 1
+}
+
+// ImportTodos は本文の iCalendar から VTODO を取り込み、作成した Todo を返す。
+// このサーバーが書き出した VTODO は、元の Todo が残っていれば取り込まない。
+func (h *CalendarHandler) ImportTodos(w http.ResponseWriter, r *http.Request) {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" && mediaType(contentType) != calendarContentType {
+		writeError(w, http.StatusUnsupportedMediaType, ErrorResponse{Error: "Content-Type must be " + calendarContentType})
+		return
+	}
+
+	vtodos, err := ical.Parse(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, ErrorResponse{Error: fmt.Sprintf("request body must not exceed %d bytes", maxRequestBody)})
+			return
+		}
+		writeError(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if len(vtodos) == 0 {
+		writeError(w, http.StatusBadRequest, ErrorResponse{Error: "calendar contains no VTODO"})
+		return
+	}
+
+	items, fieldErrors := newImportTodos(vtodos)
+	if len(fieldErrors) > 0 {
+		writeError(w, http.StatusBadRequest, ErrorResponse{Error: "invalid VTODO", Fields: fieldErrors})
+		return
+	}
+
+	result, err := h.importUsecase.Execute(r.Context(), items)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStatus) || errors.Is(err, domain.ErrInvalidTransition) {
+			writeError(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+var (
+	statusToICal = map[domain.Status]string{
+		domain.StatusTodo:       ical.StatusNeedsAction,
+		domain.StatusInProgress: ical.StatusInProcess,
+		domain.StatusDone:       ical.StatusCompleted,
+		domain.StatusCancelled:  ical.StatusCancelled,
+	}
+	statusFromICal = map[string]domain.Status{
+		ical.StatusNeedsAction: domain.StatusTodo,
+		ical.StatusInProcess:   domain.StatusInProgress,
+		ical.StatusCompleted:   domain.StatusDone,
+		ical.StatusCancelled:   domain.StatusCancelled,
+	}
+)
+
+func newVTodo(todo *domain.Todo) ical.VTodo {
+	return ical.VTodo{
+		UID:          "todo-" + strconv.Itoa(todo.ID) + exportedUIDSuffix,
+		Summary:      todo.Title,
+		Status:       statusToICal[todo.Status],
+		Created:      todo.CreatedAt,
+		LastModified: todo.UpdatedAt,
+		Completed:    todo.CompletedAt,
+	}
+}
+
+// newImportTodos は VTODO を取り込む Todo に変換する。誤りは "vtodo.<番号>.<プロパティ>" のフィールドで返す。
+// STATUS がなくても COMPLETED があれば完了とみなす。
+func newImportTodos(vtodos []ical.VTodo) ([]domain.ImportTodo, []FieldError) {
+	var items []domain.ImportTodo
+	var fieldErrors []FieldError
+	for i, vtodo := range vtodos {
+		field := "vtodo." + strconv.Itoa(i) + "."
+		item := domain.ImportTodo{Title: vtodo.Summary, CompletedAt: vtodo.Completed}
+		fieldErrors = append(fieldErrors, requireTitle(field+"summary", vtodo.Summary)...)
+
+		switch {
+		case vtodo.Status != "":
+			status, ok := statusFromICal[vtodo.Status]
+			if !ok {
+				fieldErrors = append(fieldErrors, FieldError{Field: field + "status", Message: "must be NEEDS-ACTION, IN-PROCESS, COMPLETED or CANCELLED"})
+			}
+			item.Status = status
+		case vtodo.Completed != nil:
+			item.Status = domain.StatusDone
+		}
+
+		if id, ok := strings.CutSuffix(vtodo.UID, exportedUIDSuffix); ok {
+			if id, ok := strings.CutPrefix(id, "todo-"); ok {
+				item.ExportedID, _ = strconv.Atoi(id)
+			}
+		}
+		items = append(items, item)
+	}
+	return items, fieldErrors
+}
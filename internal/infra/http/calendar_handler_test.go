@@ -0,0 +1,184 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockImportTodoUsecase struct {
+	err   error
+	items []domain.ImportTodo
+}
+
+func (m *mockImportTodoUsecase) Execute(ctx context.Context, items []domain.ImportTodo) (*domain.ImportResult, error) {
+	m.items = items
+	if m.err != nil {
+		return nil, m.err
+	}
+	result := &domain.ImportResult{Created: []*domain.Todo{}}
+	for i, item := range items {
+		result.Created = append(result.Created, &domain.Todo{ID: 10 + i, Title: item.Title, Status: item.Status})
+	}
+	return result, nil
+}
+
+func TestCalendarHandler_Feed(t *testing.T) {
+	at := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	list := &mockListTodoUsecase{todos: []*domain.Todo{
+		{ID: 1, Title: "Buy milk, eggs", Status: domain.StatusDone, CompletedAt: &at, CreatedAt: at, UpdatedAt: at},
+		{ID: 2, Title: "Write report", Status: domain.StatusInProgress, CreatedAt: at, UpdatedAt: at},
+	}}
+
+	tests := []struct {
+		name     string
+		token    string
+		target   string
+		bearer   string
+		status   int
+		contains []string
+	}{
+		{
+			name: "query token", token: "secret", target: "/calendar/todos.ics?token=secret", status: http.StatusOK,
+			contains: []string{
+				"BEGIN:VCALENDAR\r\n", "X-WR-CALNAME:Todos\r\n",
+				"UID:todo-1@go-todo\r\n", `SUMMARY:Buy milk\, eggs`, "STATUS:COMPLETED\r\n", "COMPLETED:20261018T093000Z\r\n",
+				"UID:todo-2@go-todo\r\n", "STATUS:IN-PROCESS\r\n",
+			},
+		},
+		{name: "bearer token", token: "secret", target: "/calendar/todos.ics", bearer: "secret", status: http.StatusOK, contains: []string{"BEGIN:VTODO"}},
+		{name: "view", token: "secret", target: "/calendar/todos.ics?token=secret&view=today", status: http.StatusOK, contains: []string{"X-WR-CALNAME:Todos: today\r\n", "SUMMARY:Buy milk\r\n"}},
+		{name: "wrong token", token: "secret", target: "/calendar/todos.ics?token=guess", status: http.StatusUnauthorized},
+		{name: "disabled", token: "", target: "/calendar/todos.ics?token=", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: フィードのトークンを設定した CalendarHandler
+			handler := NewCalendarHandler(list, &mockListViewTodoUsecase{}, nil, tt.token)
+			req := httptest.NewRequest("GET", tt.target, nil)
+			if tt.bearer != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.bearer)
+			}
+			w := httptest.NewRecorder()
+
+			// When:  フィードを取得する
+			handler.ExportFeed(w, req)
+
+			// Then:  トークンが正しければ VTODO の一覧を返す
+			if w.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, w.Code)
+			}
+			if tt.status == http.StatusOK && w.Header().Get("Content-Type") != "text/calendar; charset=utf-8" {
+				t.Errorf("Unexpected Content-Type: %s", w.Header().Get("Content-Type"))
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(w.Body.String(), s) {
+					t.Errorf("Expected %q in:\n%s", s, w.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestCalendarHandler_Import(t *testing.T) {
+	// Given: このサーバーが書き出した VTODO と他のアプリの VTODO を含むカレンダー
+	body := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VTODO",
+		"UID:todo-7@go-todo",
+		"SUMMARY:Buy milk",
+		"STATUS:NEEDS-ACTION",
+		"END:VTODO",
+		"BEGIN:VTODO",
+		"UID:abc@example.com",
+		"SUMMARY:Call Bob",
+		"COMPLETED:20261018T093000Z",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+	mock := &mockImportTodoUsecase{}
+	handler := NewCalendarHandler(nil, nil, mock, "")
+	req := httptest.NewRequest("POST", "/calendar/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	w := httptest.NewRecorder()
+
+	// When:  取り込む
+	handler.ImportTodos(w, req)
+
+	// Then:  VTODO が取り込む Todo に変換され、作成した Todo を返す
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d %s", w.Code, w.Body.String())
+	}
+	if len(mock.items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(mock.items))
+	}
+	first, second := mock.items[0], mock.items[1]
+	if first.ExportedID != 7 || first.Title != "Buy milk" || first.Status != domain.StatusTodo {
+		t.Errorf("Unexpected first item: %+v", first)
+	}
+	if second.ExportedID != 0 || second.Status != domain.StatusDone || second.CompletedAt == nil {
+		t.Errorf("Unexpected second item: %+v", second)
+	}
+	var result domain.ImportResult
+	json.NewDecoder(w.Body).Decode(&result)
+	if len(result.Created) != 2 {
+		t.Errorf("Expected 2 created todos, got %+v", result)
+	}
+}
+
+func TestCalendarHandler_Import_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		fields      []FieldError
+	}{
+		{name: "json", contentType: "application/json", body: `{}`, status: http.StatusUnsupportedMediaType},
+		{name: "not a calendar", body: "hello", status: http.StatusBadRequest},
+		{name: "no vtodo", body: "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", status: http.StatusBadRequest},
+		{
+			name:   "invalid fields",
+			body:   "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nSTATUS:WAITING\r\nEND:VTODO\r\nEND:VCALENDAR\r\n",
+			status: http.StatusBadRequest,
+			fields: []FieldError{{Field: "vtodo.0.summary", Message: "is required"}, {Field: "vtodo.0.status", Message: "must be NEEDS-ACTION, IN-PROCESS, COMPLETED or CANCELLED"}},
+		},
+		{name: "too large", body: strings.Repeat("X", maxRequestBody+1), status: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: 取り込めない本文
+			mock := &mockImportTodoUsecase{}
+			handler := NewCalendarHandler(nil, nil, mock, "")
+			req := httptest.NewRequest("POST", "/calendar/import", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			// When:  取り込む
+			handler.ImportTodos(w, req)
+
+			// Then:  エラーを返し、usecase は呼ばれない
+			if w.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d %s", tt.status, w.Code, w.Body.String())
+			}
+			if mock.items != nil {
+				t.Errorf("Expected usecase not to be called")
+			}
+			var resp ErrorResponse
+			json.NewDecoder(w.Body).Decode(&resp)
+			if tt.fields != nil && (len(resp.Fields) != len(tt.fields) || resp.Fields[0] != tt.fields[0] || resp.Fields[1] != tt.fields[1]) {
+				t.Errorf("Expected fields %+v, got %+v", tt.fields, resp.Fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Deprecate は旧 API のルートに、廃止予定であることを示すヘッダーを付ける。
+//   - Deprecation: 廃止予定になった日時（RFC 9745 の @<Unix 時刻>）
+//   - Sunset: 提供を終了する日時（RFC 8594）
+//   - Link: 移行先の URL（rel="successor-version"）。successor の {name} はリクエストのパスの値に置き換える
+func Deprecate(next http.HandlerFunc, deprecatedAt, sunset time.Time, successor string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "@"+strconv.FormatInt(deprecatedAt.Unix(), 10))
+		w.Header().Set("Sunset", sunset.UTC().Format(http.TimeFormat))
+		w.Header().Set("Link", "<"+expandPath(successor, r)+`>; rel="successor-version"`)
+		next(w, r)
+	}
+}
+
+// expandPath は path の {name} を r.PathValue(name) に置き換える。
+func expandPath(path string, r *http.Request) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, "{"); ok && strings.HasSuffix(name, "}") {
+			segments[i] = r.PathValue(strings.TrimSuffix(name, "}"))
+		}
+	}
+	return strings.Join(segments, "/")
+}
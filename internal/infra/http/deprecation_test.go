@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeprecate(t *testing.T) {
+	// Given: 旧ルートを Deprecate で包んだハンドラー
+	deprecatedAt := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 18, 0, 0, 0, 0, time.UTC)
+	called := false
+	handler := Deprecate(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}, deprecatedAt, sunset, "/v1/todos/{id}")
+
+	req := httptest.NewRequest("GET", "/todo/42", nil)
+	req.SetPathValue("id", "42")
+	w := httptest.NewRecorder()
+
+	// When:  リクエストする
+	handler(w, req)
+
+	// Then:  元のハンドラーが呼ばれ、廃止予定のヘッダーが付く
+	if !called {
+		t.Fatal("Expected next handler to be called")
+	}
+	headers := map[string]string{
+		"Deprecation": "@1792281600",
+		"Sunset":      "Sun, 18 Apr 2027 00:00:00 GMT",
+		"Link":        `</v1/todos/42>; rel="successor-version"`,
+	}
+	for name, want := range headers {
+		if got := w.Header().Get(name); got != want {
+			t.Errorf("Expected %s %q, got %q", name, want, got)
+		}
+	}
+}
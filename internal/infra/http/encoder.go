@@ -0,0 +1,105 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// todoEncoder は TodoHandler のレスポンスの形。API のバージョンごとに用意し、
+// 一方の形を変えても他方のバージョンのクライアントに影響しないようにする。
+type todoEncoder interface {
+	writeTodo(w http.ResponseWriter, todo *domain.Todo)
+	writeCreated(w http.ResponseWriter, todo *domain.Todo)
+	writeTodos(w http.ResponseWriter, todos []*domain.Todo)
+	writeDeleted(w http.ResponseWriter, id int)
+}
+
+// legacyEncoder はバージョンのない /todo の形。domain.Todo をそのまま返し、削除はメッセージを返す。
+type legacyEncoder struct{}
+
+func (legacyEncoder) writeTodo(w http.ResponseWriter, todo *domain.Todo) {
+	writeJSON(w, http.StatusOK, todo)
+}
+
+func (legacyEncoder) writeCreated(w http.ResponseWriter, todo *domain.Todo) {
+	writeJSON(w, http.StatusCreated, todo)
+}
+
+func (legacyEncoder) writeTodos(w http.ResponseWriter, todos []*domain.Todo) {
+	writeJSON(w, http.StatusOK, todos)
+}
+
+func (legacyEncoder) writeDeleted(w http.ResponseWriter, id int) {
+	writeJSON(w, http.StatusOK, map[string]string{"message": "todo deleted successfully"})
+}
+
+// TodoV1 は /v1 の Todo。domain.Todo の JSON の形に依存しないよう、出力するフィールドをここで決める。
+type TodoV1 struct {
+	ID            int                       `json:"id"`
+	Title         string                    `json:"title"`
+	Position      string                    `json:"position,omitempty"`
+	Status        domain.Status             `json:"status"`
+	Completed     bool                      `json:"completed"`
+	StatusHistory []domain.StatusTransition `json:"status_history"`
+	CompletedAt   *time.Time                `json:"completed_at,omitempty"`
+	CreatedAt     time.Time                 `json:"created_at"`
+	UpdatedAt     time.Time                 `json:"updated_at"`
+}
+
+// TodoListV1 は /v1 の一覧。配列をそのまま返さず、将来ページングなどの項目を追加できるようにする。
+type TodoListV1 struct {
+	Items []TodoV1 `json:"items"`
+	Count int      `json:"count"`
+}
+
+func newTodoV1(todo *domain.Todo) TodoV1 {
+	history := todo.StatusHistory
+	if history == nil {
+		history = []domain.StatusTransition{}
+	}
+	return TodoV1{
+		ID:            todo.ID,
+		Title:         todo.Title,
+		Position:      todo.Position,
+		Status:        todo.Status,
+		Completed:     todo.IsCompleted(),
+		StatusHistory: history,
+		CompletedAt:   todo.CompletedAt,
+		CreatedAt:     todo.CreatedAt,
+		UpdatedAt:     todo.UpdatedAt,
+	}
+}
+
+// v1Encoder は /v1/todos の形。作成したら Location ヘッダーで URL を返し、削除は本文のない 204 を返す。
+type v1Encoder struct{}
+
+func (v1Encoder) writeTodo(w http.ResponseWriter, todo *domain.Todo) {
+	writeJSON(w, http.StatusOK, newTodoV1(todo))
+}
+
+func (v1Encoder) writeCreated(w http.ResponseWriter, todo *domain.Todo) {
+	w.Header().Set("Location", "/v1/todos/"+strconv.Itoa(todo.ID))
+	writeJSON(w, http.StatusCreated, newTodoV1(todo))
+}
+
+func (v1Encoder) writeTodos(w http.ResponseWriter, todos []*domain.Todo) {
+	list := TodoListV1{Items: make([]TodoV1, len(todos)), Count: len(todos)}
+	for i, todo := range todos {
+		list.Items[i] = newTodoV1(todo)
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (v1Encoder) writeDeleted(w http.ResponseWriter, id int) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
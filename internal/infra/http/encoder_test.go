@@ -0,0 +1,81 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestTodoHandlerV1_CreateTodo(t *testing.T) {
+	// Given: /v1 の TodoHandler
+	handler := NewTodoHandler(&mockCreateTodoUsecase{}, nil, nil, nil, nil).V1()
+	req := httptest.NewRequest("POST", "/v1/todos", strings.NewReader(`{"title": "Buy milk"}`))
+	w := httptest.NewRecorder()
+
+	// When:  作成する
+	handler.CreateTodo(w, req)
+
+	// Then:  201 と作成した Todo の URL を返す
+	if w.Code != http.StatusCreated || w.Header().Get("Location") != "/v1/todos/1" {
+		t.Fatalf("Expected 201 with Location, got %d %q", w.Code, w.Header().Get("Location"))
+	}
+	var todo map[string]any
+	json.NewDecoder(w.Body).Decode(&todo)
+	if todo["title"] != "Buy milk" || todo["completed"] != false {
+		t.Errorf("Unexpected todo: %v", todo)
+	}
+	if _, ok := todo["deleted_at"]; ok {
+		t.Errorf("Expected no deleted_at in v1")
+	}
+}
+
+func TestTodoHandlerV1_ListTodo(t *testing.T) {
+	// Given: 2件の Todo を返す usecase
+	mockUsecase := &mockListTodoUsecase{
+		todos: []*domain.Todo{
+			{ID: 1, Title: "Buy milk"},
+			{ID: 2, Title: "Go to gym"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		handler *TodoHandler
+		want    string
+	}{
+		{"legacy", NewTodoHandler(nil, mockUsecase, nil, nil, nil), "["},
+		{"v1", NewTodoHandler(nil, mockUsecase, nil, nil, nil).V1(), `{"items":[`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When:  一覧を取得する
+			w := httptest.NewRecorder()
+			tt.handler.ListTodo(w, httptest.NewRequest("GET", "/v1/todos", nil))
+
+			// Then:  バージョンごとの形で返す
+			if w.Code != http.StatusOK || !strings.HasPrefix(w.Body.String(), tt.want) {
+				t.Errorf("Expected body starting with %s, got %d %s", tt.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTodoHandlerV1_DeleteTodo(t *testing.T) {
+	// Given: /v1 の TodoHandler
+	handler := NewTodoHandler(nil, nil, nil, nil, &mockDeleteTodoUsecase{}).V1()
+	req := httptest.NewRequest("DELETE", "/v1/todos/1", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+
+	// When:  削除する
+	handler.DeleteTodo(w, req)
+
+	// Then:  本文なしの 204 を返す
+	if w.Code != http.StatusNoContent || w.Body.Len() != 0 {
+		t.Errorf("Expected 204 without body, got %d %q", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+const (
+	LastEventIDHeader = "Last-Event-ID"
+	DefaultHeartbeat  = 15 * time.Second
+)
+
+type SubscribeTodoEventsUsecase interface {
+	Execute(ctx context.Context, lastEventID int64, query domain.FeedQuery) (<-chan domain.FeedEvent, error)
+}
+
+type EventsHandler struct {
+	subscribeUsecase SubscribeTodoEventsUsecase
+	heartbeat        time.Duration
+}
+
+func NewEventsHandler(subscribe SubscribeTodoEventsUsecase) *EventsHandler {
+	return &EventsHandler{subscribeUsecase: subscribe, heartbeat: DefaultHeartbeat}
+}
+
+// StreamEvents は Todo の変更を Server-Sent Events で流す。
+// q（検索式）と view（保存したビューの ID）で絞り込め、Last-Event-ID ヘッダーまたは last_event_id で途中から再開できる。
+// 接続を保つため、変更がない間も heartbeat ごとにコメント行を送る。
+func (h *EventsHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	lastEventIDStr := r.Header.Get(LastEventIDHeader)
+	if lastEventIDStr == "" {
+		lastEventIDStr = r.URL.Query().Get("last_event_id")
+	}
+	var lastEventID int64
+	if lastEventIDStr != "" {
+		id, err := strconv.ParseInt(lastEventIDStr, 10, 64)
+		if err != nil || id < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		lastEventID = id
+	}
+
+	query := domain.FeedQuery{
+		Filter: r.URL.Query().Get("q"),
+		ViewID: r.URL.Query().Get("view"),
+	}
+	events, err := h.subscribeUsecase.Execute(r.Context(), lastEventID, query)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidFilter) {
+			writeFilterError(w, err)
+		} else if errors.Is(err, domain.ErrViewNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockSubscribeTodoEventsUsecase struct {
+	events      []domain.FeedEvent
+	keepOpen    bool
+	lastEventID int64
+	query       domain.FeedQuery
+	err         error
+}
+
+func (m *mockSubscribeTodoEventsUsecase) Execute(ctx context.Context, lastEventID int64, query domain.FeedQuery) (<-chan domain.FeedEvent, error) {
+	m.lastEventID = lastEventID
+	m.query = query
+	if m.err != nil {
+		return nil, m.err
+	}
+	ch := make(chan domain.FeedEvent, len(m.events))
+	for _, e := range m.events {
+		ch <- e
+	}
+	if !m.keepOpen {
+		close(ch)
+	}
+	return ch, nil
+}
+
+func TestStreamEventsHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		lastEventID string
+		err         error
+		wantCode    int
+	}{
+		{name: "stream", url: "/events", wantCode: http.StatusOK},
+		{name: "resume", url: "/events", lastEventID: "3", wantCode: http.StatusOK},
+		{name: "invalid last event id", url: "/events", lastEventID: "abc", wantCode: http.StatusBadRequest},
+		{name: "invalid filter", url: "/events?q=status:unknown", err: fmt.Errorf("%w: unknown status", domain.ErrInvalidFilter), wantCode: http.StatusBadRequest},
+		{name: "unknown view", url: "/events?view=99", err: domain.ErrViewNotFound, wantCode: http.StatusNotFound},
+		{name: "internal error", url: "/events", err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewEventsHandler(&mockSubscribeTodoEventsUsecase{err: tt.err})
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set(LastEventIDHeader, tt.lastEventID)
+			}
+			w := httptest.NewRecorder()
+
+			handler.StreamEvents(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestStreamEventsHandler_Stream(t *testing.T) {
+	// Given: 2件の変更を流す usecase
+	usecase := &mockSubscribeTodoEventsUsecase{events: []domain.FeedEvent{
+		{ID: 4, Type: domain.FeedTodoCreated, Todo: &domain.Todo{ID: 1, Title: "Buy milk"}},
+		{ID: 5, Type: domain.FeedTodoDeleted, Todo: &domain.Todo{ID: 1, Title: "Buy milk"}},
+	}}
+	handler := NewEventsHandler(usecase)
+
+	req, _ := http.NewRequest("GET", "/events?q=status:open&view=today", nil)
+	req.Header.Set(LastEventIDHeader, "3")
+	w := httptest.NewRecorder()
+
+	// When:  StreamEvents を呼び出す
+	handler.StreamEvents(w, req)
+
+	// Then:  条件と再開位置が usecase に渡され、SSE 形式で ID と種類付きの変更が流れる
+	if usecase.lastEventID != 3 || usecase.query.Filter != "status:open" || usecase.query.ViewID != "today" {
+		t.Errorf("Unexpected subscription: %d, %+v", usecase.lastEventID, usecase.query)
+	}
+	if got := w.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Expected text/event-stream, got '%s'", got)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "id: 4\nevent: created\ndata: {") || !strings.Contains(body, "id: 5\nevent: deleted\n") {
+		t.Errorf("Unexpected body: %s", body)
+	}
+}
+
+func TestStreamEventsHandler_Heartbeat(t *testing.T) {
+	// Given: 変更のないまま開いている購読
+	handler := NewEventsHandler(&mockSubscribeTodoEventsUsecase{keepOpen: true})
+	handler.heartbeat = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", "/events", nil)
+	w := httptest.NewRecorder()
+
+	// When:  接続が切れるまで StreamEvents を実行する
+	handler.StreamEvents(w, req)
+
+	// Then:  ハートビートのコメント行が送られている
+	if !strings.Contains(w.Body.String(), ": heartbeat\n\n") {
+		t.Errorf("Expected heartbeat, got %q", w.Body.String())
+	}
+}
@@ -0,0 +1,274 @@
+package http
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// TodoFormat は Todo を JSON 以外の形で書き出す。JSON は API のバージョンごとの todoEncoder が書く。
+type TodoFormat interface {
+	// ContentType は Content-Type ヘッダーの値。Accept との照合には ; より前の部分を使う。
+	ContentType() string
+	WriteTodo(w io.Writer, todo *domain.Todo) error
+	WriteTodos(w io.Writer, todos []*domain.Todo) error
+}
+
+// FormatRegistry は format クエリパラメータの名前と TodoFormat の対応。登録順に Accept の */* 以外の範囲と照合する。
+// json は常に使え、Accept がない・*/* のときも JSON を返す。
+type FormatRegistry struct {
+	names   []string
+	formats map[string]TodoFormat
+}
+
+func NewFormatRegistry() *FormatRegistry {
+	return &FormatRegistry{formats: map[string]TodoFormat{}}
+}
+
+// DefaultFormats は csv・markdown・text を登録したレジストリを返す。
+func DefaultFormats() *FormatRegistry {
+	formats := NewFormatRegistry()
+	formats.Register("csv", csvFormat{})
+	formats.Register("markdown", markdownFormat{})
+	formats.Register("text", textFormat{})
+	return formats
+}
+
+// Register は name で format を登録する。同じ名前なら置き換える。
+func (f *FormatRegistry) Register(name string, format TodoFormat) {
+	if _, ok := f.formats[name]; !ok {
+		f.names = append(f.names, name)
+	}
+	f.formats[name] = format
+}
+
+// ContentTypes は登録した形式のメディアタイプを登録順に返す。JSON は含まない。
+func (f *FormatRegistry) ContentTypes() []string {
+	var types []string
+	for _, name := range f.names {
+		types = append(types, mediaType(f.formats[name].ContentType()))
+	}
+	return types
+}
+
+const jsonMediaType = "application/json"
+
+// negotiate は format クエリパラメータ、なければ Accept ヘッダーから形式を選ぶ。
+// JSON なら nil と true を、どれも受け付けられなければ false を返す。
+func (f *FormatRegistry) negotiate(r *http.Request) (TodoFormat, bool) {
+	if name := r.URL.Query().Get("format"); name != "" {
+		if name == "json" {
+			return nil, true
+		}
+		format, ok := f.formats[name]
+		return format, ok
+	}
+
+	accept := r.Header.Get("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return nil, true
+	}
+	for _, rng := range parseAccept(accept) {
+		if matchMediaType(rng, jsonMediaType) {
+			return nil, true
+		}
+		for _, name := range f.names {
+			if format := f.formats[name]; matchMediaType(rng, mediaType(format.ContentType())) {
+				return format, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// parseAccept は Accept ヘッダーのメディア範囲を q の大きい順に返す。q=0 の範囲は除く。
+func parseAccept(accept string) []string {
+	type mediaRange struct {
+		name string
+		q    float64
+	}
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		name, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(s, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			ranges = append(ranges, mediaRange{name: name, q: q})
+		}
+	}
+	slices.SortStableFunc(ranges, func(a, b mediaRange) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+
+	names := make([]string, len(ranges))
+	for i, rng := range ranges {
+		names[i] = rng.name
+	}
+	return names
+}
+
+// matchMediaType は Accept のメディア範囲（text/* などを含む）が typ に一致するかを返す。
+func matchMediaType(rng, typ string) bool {
+	if rng == "*/*" || rng == typ {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(rng, "/*")
+	return ok && strings.HasPrefix(typ, prefix+"/")
+}
+
+func mediaType(contentType string) string {
+	typ, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(typ))
+}
+
+// writeNotAcceptable は 406 と、使えるメディアタイプを返す。
+func writeNotAcceptable(w http.ResponseWriter, formats *FormatRegistry) {
+	writeError(w, http.StatusNotAcceptable, ErrorResponse{
+		Error: "not acceptable; supported types are " + strings.Join(append([]string{jsonMediaType}, formats.ContentTypes()...), ", "),
+	})
+}
+
+// csvFormat は表計算ソフトに貼り付けるための CSV。1行目は見出し。
+type csvFormat struct{}
+
+func (csvFormat) ContentType() string { return "text/csv; charset=utf-8" }
+
+func (csvFormat) WriteTodo(w io.Writer, todo *domain.Todo) error {
+	return csvFormat{}.WriteTodos(w, []*domain.Todo{todo})
+}
+
+func (csvFormat) WriteTodos(w io.Writer, todos []*domain.Todo) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"id", "title", "status", "completed", "position", "completed_at", "created_at", "updated_at"})
+	for _, todo := range todos {
+		cw.Write([]string{
+			strconv.Itoa(todo.ID),
+			csvText(todo.Title),
+			string(todo.Status),
+			strconv.FormatBool(todo.IsCompleted()),
+			todo.Position,
+			formatTime(todo.CompletedAt),
+			formatTime(&todo.CreatedAt),
+			formatTime(&todo.UpdatedAt),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvText は表計算ソフトが数式として実行しないよう、= + - @ タブ CR で始まる値の先頭に ' を付ける。
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// markdownFormat はドキュメントに貼り付けるための Markdown のチェックリスト。
+type markdownFormat struct{}
+
+func (markdownFormat) ContentType() string { return "text/markdown; charset=utf-8" }
+
+func (markdownFormat) WriteTodo(w io.Writer, todo *domain.Todo) error {
+	return markdownFormat{}.WriteTodos(w, []*domain.Todo{todo})
+}
+
+func (markdownFormat) WriteTodos(w io.Writer, todos []*domain.Todo) error {
+	bw := bufio.NewWriter(w)
+	for _, todo := range todos {
+		check := " "
+		if todo.IsCompleted() {
+			check = "x"
+		}
+		title := markdownEscaper.Replace(oneLine(todo.Title))
+		if todo.Status == domain.StatusCancelled {
+			title = "~~" + title + "~~"
+		}
+		fmt.Fprintf(bw, "- [%s] %s\n", check, title)
+	}
+	return bw.Flush()
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "#", `\#`, "~", `\~`, "|", `\|`,
+)
+
+// textFormat は YAML に近いプレーンテキスト。一覧は "- " で始まる項目を並べる。
+type textFormat struct{}
+
+func (textFormat) ContentType() string { return "text/plain; charset=utf-8" }
+
+func (textFormat) WriteTodo(w io.Writer, todo *domain.Todo) error {
+	bw := bufio.NewWriter(w)
+	writeTextFields(bw, todo, "", "")
+	return bw.Flush()
+}
+
+func (textFormat) WriteTodos(w io.Writer, todos []*domain.Todo) error {
+	bw := bufio.NewWriter(w)
+	if len(todos) == 0 {
+		bw.WriteString("[]\n")
+	}
+	for _, todo := range todos {
+		writeTextFields(bw, todo, "- ", "  ")
+	}
+	return bw.Flush()
+}
+
+// writeTextFields は1件の Todo を "key: value" の行で書く。最初の行の前に first を、以降の行の前に indent を付ける。
+func writeTextFields(w io.Writer, todo *domain.Todo, first, indent string) {
+	fields := [][2]string{
+		{"id", strconv.Itoa(todo.ID)},
+		{"title", strconv.Quote(todo.Title)},
+		{"status", string(todo.Status)},
+		{"completed", strconv.FormatBool(todo.IsCompleted())},
+	}
+	if todo.Position != "" {
+		fields = append(fields, [2]string{"position", strconv.Quote(todo.Position)})
+	}
+	if todo.CompletedAt != nil {
+		fields = append(fields, [2]string{"completed_at", formatTime(todo.CompletedAt)})
+	}
+	fields = append(fields, [2]string{"created_at", formatTime(&todo.CreatedAt)}, [2]string{"updated_at", formatTime(&todo.UpdatedAt)})
+
+	for i, field := range fields {
+		prefix := indent
+		if i == 0 {
+			prefix = first
+		}
+		fmt.Fprintf(w, "%s%s: %s\n", prefix, field[0], field[1])
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,214 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestFormatRegistry_Negotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		accept string
+		want   string
+		ok     bool
+	}{
+		{name: "no accept", target: "/todo/list", want: "json", ok: true},
+		{name: "browser", target: "/todo/list", accept: "text/html,application/xhtml+xml,*/*;q=0.8", want: "json", ok: true},
+		{name: "csv", target: "/todo/list", accept: "text/csv", want: "text/csv", ok: true},
+		{name: "markdown with params", target: "/todo/list", accept: "text/markdown; charset=utf-8", want: "text/markdown", ok: true},
+		{name: "q order", target: "/todo/list", accept: "application/json;q=0.5, text/plain", want: "text/plain", ok: true},
+		{name: "wildcard subtype", target: "/todo/list", accept: "text/*", want: "text/csv", ok: true},
+		{name: "q zero", target: "/todo/list", accept: "text/csv;q=0, application/json", want: "json", ok: true},
+		{name: "format overrides accept", target: "/todo/list?format=markdown", accept: "application/json", want: "text/markdown", ok: true},
+		{name: "format json", target: "/todo/list?format=json", accept: "text/csv", want: "json", ok: true},
+		{name: "unsupported accept", target: "/todo/list", accept: "application/xml", ok: false},
+		{name: "unsupported format", target: "/todo/list?format=xml", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: 既定のレジストリとリクエスト
+			req := httptest.NewRequest("GET", tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			// When:  形式を選ぶ
+			format, ok := DefaultFormats().negotiate(req)
+
+			// Then:  期待した形式になる
+			if ok != tt.ok {
+				t.Fatalf("Expected ok %v, got %v", tt.ok, ok)
+			}
+			if !ok {
+				return
+			}
+			got := "json"
+			if format != nil {
+				got = mediaType(format.ContentType())
+			}
+			if got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func formatTestTodos() []*domain.Todo {
+	at := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	return []*domain.Todo{
+		{ID: 1, Title: "Buy milk, eggs", Status: domain.StatusDone, CompletedAt: &at, CreatedAt: at, UpdatedAt: at},
+		{ID: 2, Title: "Fix *bold* bug", Status: domain.StatusTodo, CreatedAt: at, UpdatedAt: at},
+		{ID: 3, Title: "Old idea", Status: domain.StatusCancelled, CreatedAt: at, UpdatedAt: at},
+	}
+}
+
+func TestFormats_WriteTodos(t *testing.T) {
+	tests := []struct {
+		name   string
+		format TodoFormat
+		want   string
+	}{
+		{
+			name:   "csv",
+			format: csvFormat{},
+			want: "id,title,status,completed,position,completed_at,created_at,updated_at\n" +
+				"1,\"Buy milk, eggs\",done,true,,2026-10-18T09:00:00Z,2026-10-18T09:00:00Z,2026-10-18T09:00:00Z\n" +
+				"2,Fix *bold* bug,todo,false,,,2026-10-18T09:00:00Z,2026-10-18T09:00:00Z\n" +
+				"3,Old idea,cancelled,false,,,2026-10-18T09:00:00Z,2026-10-18T09:00:00Z\n",
+		},
+		{
+			name:   "markdown",
+			format: markdownFormat{},
+			want:   "- [x] Buy milk, eggs\n- [ ] Fix \\*bold\\* bug\n- [ ] ~~Old idea~~\n",
+		},
+		{
+			name:   "text",
+			format: textFormat{},
+			want: "- id: 1\n  title: \"Buy milk, eggs\"\n  status: done\n  completed: true\n  completed_at: 2026-10-18T09:00:00Z\n" +
+				"  created_at: 2026-10-18T09:00:00Z\n  updated_at: 2026-10-18T09:00:00Z\n" +
+				"- id: 2\n  title: \"Fix *bold* bug\"\n  status: todo\n  completed: false\n" +
+				"  created_at: 2026-10-18T09:00:00Z\n  updated_at: 2026-10-18T09:00:00Z\n" +
+				"- id: 3\n  title: \"Old idea\"\n  status: cancelled\n  completed: false\n" +
+				"  created_at: 2026-10-18T09:00:00Z\n  updated_at: 2026-10-18T09:00:00Z\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: 完了・未完了・中止の Todo
+			var buf bytes.Buffer
+
+			// When:  一覧を書き出す
+			if err := tt.format.WriteTodos(&buf, formatTestTodos()); err != nil {
+				t.Fatal(err)
+			}
+
+			// Then:  形式ごとの本文になる
+			if buf.String() != tt.want {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestCSVFormat_EscapesFormulas(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "=HYPERLINK(\"http://example.com\")", want: "\"'=HYPERLINK(\"\"http://example.com\"\")\""},
+		{title: "+1", want: "'+1"},
+		{title: "-1", want: "'-1"},
+		{title: "@SUM(A1)", want: "'@SUM(A1)"},
+		{title: "Buy milk = 2", want: "Buy milk = 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			// Given: 数式として解釈されうるタイトルの Todo
+			todo := &domain.Todo{ID: 1, Title: tt.title, Status: domain.StatusTodo}
+			var buf bytes.Buffer
+
+			// When:  CSV で書き出す
+			if err := (csvFormat{}).WriteTodo(&buf, todo); err != nil {
+				t.Fatal(err)
+			}
+
+			// Then:  先頭が記号ならセルの先頭に ' が付く
+			lines := strings.Split(buf.String(), "\n")
+			if cell := strings.TrimPrefix(lines[1], "1,"); !strings.HasPrefix(cell, tt.want+",") {
+				t.Errorf("Expected title cell %s, got %s", tt.want, lines[1])
+			}
+		})
+	}
+}
+
+type upperFormat struct{}
+
+func (upperFormat) ContentType() string { return "text/x-upper" }
+
+func (upperFormat) WriteTodo(w io.Writer, todo *domain.Todo) error {
+	_, err := io.WriteString(w, strings.ToUpper(todo.Title))
+	return err
+}
+
+func (upperFormat) WriteTodos(w io.Writer, todos []*domain.Todo) error {
+	return nil
+}
+
+func TestTodoHandler_Negotiation(t *testing.T) {
+	// Given: 独自の形式を登録した TodoHandler
+	find := &mockFindByIDTodoUsecase{todo: formatTestTodos()[0]}
+	handler := NewTodoHandler(nil, &mockListTodoUsecase{todos: formatTestTodos()}, find, nil, nil)
+	handler.Formats().Register("upper", upperFormat{})
+
+	tests := []struct {
+		name        string
+		serve       http.HandlerFunc
+		target      string
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{name: "list as csv", serve: handler.ListTodo, target: "/todo/list", accept: "text/csv", status: http.StatusOK, contentType: "text/csv; charset=utf-8", body: "id,title,"},
+		{name: "v1 list as json", serve: handler.V1().ListTodo, target: "/v1/todos", accept: "application/json", status: http.StatusOK, contentType: "application/json", body: `{"items":`},
+		{name: "get as markdown", serve: handler.FindByIDTodo, target: "/todo/1?format=markdown", status: http.StatusOK, contentType: "text/markdown; charset=utf-8", body: "- [x] Buy milk, eggs\n"},
+		{name: "registered format", serve: handler.V1().FindByIDTodo, target: "/v1/todos/1", accept: "text/x-upper", status: http.StatusOK, contentType: "text/x-upper", body: "BUY MILK, EGGS"},
+		{name: "not acceptable", serve: handler.ListTodo, target: "/todo/list", accept: "application/xml", status: http.StatusNotAcceptable, contentType: "application/json", body: `{"error":"not acceptable; supported types are application/json, text/csv, text/markdown, text/plain, text/x-upper"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.target, nil)
+			req.SetPathValue("id", "1")
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+
+			// When:  Accept または format を指定して取得する
+			tt.serve(w, req)
+
+			// Then:  選んだ形式で返し、Accept によって変わることを示す
+			if w.Code != tt.status || w.Header().Get("Content-Type") != tt.contentType {
+				t.Fatalf("Expected %d %s, got %d %s", tt.status, tt.contentType, w.Code, w.Header().Get("Content-Type"))
+			}
+			if !strings.HasPrefix(w.Body.String(), tt.body) {
+				t.Errorf("Expected body starting with %q, got %q", tt.body, w.Body.String())
+			}
+			if w.Header().Get("Vary") != "Accept" {
+				t.Errorf("Expected Vary: Accept, got %q", w.Header().Get("Vary"))
+			}
+		})
+	}
+}
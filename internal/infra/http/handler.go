@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -14,7 +15,7 @@ type CreateTodoUsecase interface {
 }
 
 type ListTodoUsecase interface {
-	Execute(ctx context.Context) ([]*domain.Todo, error)
+	Execute(ctx context.Context, query domain.ListQuery) ([]*domain.Todo, error)
 }
 
 type FindByIDTodoUsecase interface {
@@ -29,12 +30,17 @@ type DeleteTodoUsecase interface {
 	Execute(ctx context.Context, id int) error
 }
 
+// TodoHandler は Todo の作成・一覧・取得・更新・削除を扱う。
+// NewTodoHandler はバージョンのない /todo の形で、V1 は /v1/todos の形でレスポンスを返す。
+// 一覧と取得は Accept ヘッダーか format クエリパラメータに応じて、formats に登録した形式でも返す。
 type TodoHandler struct {
 	createUsecase   CreateTodoUsecase
 	listUsecase     ListTodoUsecase
 	findByIDUsecase FindByIDTodoUsecase
 	updateUsecase   UpdateTodoUsecase
 	deleteUsecase   DeleteTodoUsecase
+	encoder         todoEncoder
+	formats         *FormatRegistry
 }
 
 func NewTodoHandler(create CreateTodoUsecase, list ListTodoUsecase, findByID FindByIDTodoUsecase, update UpdateTodoUsecase, del DeleteTodoUsecase) *TodoHandler {
@@ -44,22 +50,44 @@ func NewTodoHandler(create CreateTodoUsecase, list ListTodoUsecase, findByID Fin
 		findByIDUsecase: findByID,
 		updateUsecase:   update,
 		deleteUsecase:   del,
+		encoder:         legacyEncoder{},
+		formats:         DefaultFormats(),
 	}
 }
 
+// V1 は同じ usecase を使い、/v1/todos の形でレスポンスを返す TodoHandler を返す。
+func (h *TodoHandler) V1() *TodoHandler {
+	v1 := *h
+	v1.encoder = v1Encoder{}
+	return &v1
+}
+
+// Formats は一覧と取得で使う形式のレジストリを返す。V1 で作った TodoHandler とは同じレジストリを共有する。
+func (h *TodoHandler) Formats() *FormatRegistry {
+	return h.formats
+}
+
+// negotiate はレスポンスの形式を選ぶ。受け付けられる形式がなければ 406 を返して false を返す。
+func (h *TodoHandler) negotiate(w http.ResponseWriter, r *http.Request) (TodoFormat, bool) {
+	w.Header().Add("Vary", "Accept")
+	format, ok := h.formats.negotiate(r)
+	if !ok {
+		writeNotAcceptable(w, h.formats)
+	}
+	return format, ok
+}
+
 type CreateTodoRequest struct {
 	Title string `json:"title"`
 }
 
+func (req CreateTodoRequest) Validate() []FieldError {
+	return requireTitle("title", req.Title)
+}
+
 func (h *TodoHandler) CreateTodo(w http.ResponseWriter, r *http.Request) {
 	var req CreateTodoRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	if req.Title == "" {
-		w.WriteHeader(http.StatusBadRequest)
+	if !decodeRequest(w, r, &req) {
 		return
 	}
 
@@ -69,24 +97,52 @@ func (h *TodoHandler) CreateTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(todo)
+	h.encoder.writeCreated(w, todo)
 }
 
 func (h *TodoHandler) ListTodo(w http.ResponseWriter, r *http.Request) {
-	todos, err := h.listUsecase.Execute(r.Context())
+	format, ok := h.negotiate(w, r)
+	if !ok {
+		return
+	}
+
+	query := domain.ListQuery{
+		Sort:   domain.SortOrder(r.URL.Query().Get("sort")),
+		Filter: r.URL.Query().Get("q"),
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if _, err := fmt.Sscanf(limitStr, "%d", &query.Limit); err != nil || query.Limit < 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	todos, err := h.listUsecase.Execute(r.Context(), query)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		if errors.Is(err, domain.ErrInvalidFilter) {
+			writeFilterError(w, err)
+		} else if errors.Is(err, domain.ErrInvalidSort) || errors.Is(err, domain.ErrInvalidLimit) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(todos)
+	if format == nil {
+		h.encoder.writeTodos(w, todos)
+		return
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	format.WriteTodos(w, todos)
 }
 
 func (h *TodoHandler) FindByIDTodo(w http.ResponseWriter, r *http.Request) {
+	format, ok := h.negotiate(w, r)
+	if !ok {
+		return
+	}
+
 	idStr := r.PathValue("id")
 	var id int
 	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
@@ -109,9 +165,12 @@ func (h *TodoHandler) FindByIDTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(todo)
+	if format == nil {
+		h.encoder.writeTodo(w, todo)
+		return
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	format.WriteTodo(w, todo)
 }
 
 type UpdateTodoRequest struct {
@@ -119,6 +178,10 @@ type UpdateTodoRequest struct {
 	Completed bool   `json:"completed"`
 }
 
+func (req UpdateTodoRequest) Validate() []FieldError {
+	return requireTitle("title", req.Title)
+}
+
 func (h *TodoHandler) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	var id int
@@ -128,8 +191,7 @@ func (h *TodoHandler) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req UpdateTodoRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+	if !decodeRequest(w, r, &req) {
 		return
 	}
 
@@ -139,15 +201,15 @@ func (h *TodoHandler) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusNotFound)
 		} else if err.Error() == "title cannot be empty" || err.Error() == "title too long" {
 			w.WriteHeader(http.StatusBadRequest)
+		} else if errors.Is(err, domain.ErrInvalidTransition) {
+			w.WriteHeader(http.StatusConflict)
 		} else {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(todo)
+	h.encoder.writeTodo(w, todo)
 }
 
 func (h *TodoHandler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
@@ -167,7 +229,12 @@ func (h *TodoHandler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.encoder.writeDeleted(w, id)
+}
+
+// writeFilterError は検索式の誤りを位置を含めて返し、利用者が修正できるようにする。
+func writeFilterError(w http.ResponseWriter, err error) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"message": "todo deleted successfully"})
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 }
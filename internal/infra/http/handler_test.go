@@ -2,9 +2,11 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -23,7 +25,7 @@ func (m *mockCreateTodoUsecase) Execute(ctx context.Context, title string) (*dom
 	return &domain.Todo{
 		ID:        1,
 		Title:     title,
-		Completed: false,
+		Status:    domain.StatusTodo,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}, nil
@@ -68,9 +70,11 @@ func TestCreateTodoHandler_EmptyTitle(t *testing.T) {
 type mockListTodoUsecase struct {
 	err   error
 	todos []*domain.Todo
+	query domain.ListQuery
 }
 
-func (m *mockListTodoUsecase) Execute(ctx context.Context) ([]*domain.Todo, error) {
+func (m *mockListTodoUsecase) Execute(ctx context.Context, query domain.ListQuery) ([]*domain.Todo, error) {
+	m.query = query
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -152,15 +156,22 @@ func (m *mockUpdateTodoUsecase) Execute(ctx context.Context, id int, title strin
 	}
 	if m.todo != nil {
 		m.todo.Title = title
-		m.todo.Completed = completed
+		m.todo.Status = domain.StatusTodo
+		if completed {
+			m.todo.Status = domain.StatusDone
+		}
 		return m.todo, nil
 	}
-	return &domain.Todo{
+	todo := &domain.Todo{
 		ID:        id,
 		Title:     title,
-		Completed: completed,
+		Status:    domain.StatusTodo,
 		UpdatedAt: time.Now(),
-	}, nil
+	}
+	if completed {
+		todo.Status = domain.StatusDone
+	}
+	return todo, nil
 }
 
 func TestUpdateTodoHandler(t *testing.T) {
@@ -467,3 +478,136 @@ func TestDeleteTodoHandler_UsecaseError(t *testing.T) {
 		t.Errorf("Expected status 500, got %d", w.Code)
 	}
 }
+
+func TestUpdateTodoHandler_InvalidTransition(t *testing.T) {
+	// Given: usecase が ErrInvalidTransition を返すモック
+	// When:  UpdateTodo を呼び出す
+	// Then:  409 Conflict が返る
+	mockUpdate := &mockUpdateTodoUsecase{err: domain.ErrInvalidTransition}
+	handler := NewTodoHandler(nil, nil, nil, mockUpdate, nil)
+
+	body := strings.NewReader(`{"title": "test", "completed": true}`)
+	req, _ := http.NewRequest("PUT", "/todo/1", body)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+
+	handler.UpdateTodo(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", w.Code)
+	}
+}
+
+func TestListTodoHandler_Sort(t *testing.T) {
+	// Given: sort=position 付きのリクエスト
+	// When:  ListTodo を呼び出す
+	// Then:  200 OK が返り、並び順が usecase に渡される
+	mockList := &mockListTodoUsecase{todos: []*domain.Todo{}}
+	handler := NewTodoHandler(nil, mockList, nil, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/todo/list?sort=position", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListTodo(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if mockList.query.Sort != domain.SortPosition {
+		t.Errorf("Expected sort 'position', got '%s'", mockList.query.Sort)
+	}
+}
+
+func TestListTodoHandler_InvalidSort(t *testing.T) {
+	// Given: usecase が ErrInvalidSort を返すモック
+	// When:  ListTodo を呼び出す
+	// Then:  400 Bad Request が返る
+	mockList := &mockListTodoUsecase{err: domain.ErrInvalidSort}
+	handler := NewTodoHandler(nil, mockList, nil, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/todo/list?sort=unknown", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListTodo(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestListTodoHandler_Filter(t *testing.T) {
+	// Given: q パラメータ付きのリクエスト
+	// When:  ListTodo を呼び出す
+	// Then:  200 OK が返り、検索式が usecase に渡される
+	mockList := &mockListTodoUsecase{todos: []*domain.Todo{}}
+	handler := NewTodoHandler(nil, mockList, nil, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/todo/list?q="+url.QueryEscape(`status:open "release notes"`), nil)
+	w := httptest.NewRecorder()
+
+	handler.ListTodo(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if mockList.query.Filter != `status:open "release notes"` {
+		t.Errorf("Expected filter to be passed, got '%s'", mockList.query.Filter)
+	}
+}
+
+func TestListTodoHandler_InvalidFilter(t *testing.T) {
+	// Given: usecase が検索式の誤りを返すモック
+	// When:  ListTodo を呼び出す
+	// Then:  400 Bad Request とエラー内容が返る
+	filterErr := fmt.Errorf("syntax error at column 1: unknown field \"tag\": %w", domain.ErrInvalidFilter)
+	mockList := &mockListTodoUsecase{err: filterErr}
+	handler := NewTodoHandler(nil, mockList, nil, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/todo/list?q=tag:backend", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListTodo(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+	var body map[string]string
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body["error"] != filterErr.Error() {
+		t.Errorf("Expected error %q, got %q", filterErr.Error(), body["error"])
+	}
+}
+
+func TestListTodoHandler_Limit(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     string
+		wantCode  int
+		wantLimit int
+	}{
+		{name: "valid", limit: "10", wantCode: http.StatusOK, wantLimit: 10},
+		{name: "not a number", limit: "abc", wantCode: http.StatusBadRequest},
+		{name: "zero", limit: "0", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockList := &mockListTodoUsecase{todos: []*domain.Todo{}}
+			handler := NewTodoHandler(nil, mockList, nil, nil, nil)
+
+			req, _ := http.NewRequest("GET", "/todo/list?limit="+tt.limit, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListTodo(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if mockList.query.Limit != tt.wantLimit {
+				t.Errorf("Expected limit %d, got %d", tt.wantLimit, mockList.query.Limit)
+			}
+		})
+	}
+}
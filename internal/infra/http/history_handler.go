@@ -0,0 +1,45 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type TodoHistoryUsecase interface {
+	Execute(ctx context.Context, id int) ([]*domain.Event, error)
+}
+
+type HistoryHandler struct {
+	historyUsecase TodoHistoryUsecase
+}
+
+func NewHistoryHandler(history TodoHistoryUsecase) *HistoryHandler {
+	return &HistoryHandler{historyUsecase: history}
+}
+
+func (h *HistoryHandler) TodoHistory(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	var id int
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	events, err := h.historyUsecase.Execute(r.Context(), id)
+	if err != nil {
+		if err.Error() == "todo not found" {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(events)
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockTodoHistoryUsecase struct {
+	err error
+}
+
+func (m *mockTodoHistoryUsecase) Execute(ctx context.Context, id int) ([]*domain.Event, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []*domain.Event{
+		{Seq: 1, Type: domain.EventTodoCreated, TodoID: id, At: at, Actor: "alice", Todo: &domain.Todo{ID: id, Title: "Buy milk"}},
+		{Seq: 2, Type: domain.EventTitleChanged, TodoID: id, At: at, Actor: "bob", Title: "Buy oat milk"},
+	}, nil
+}
+
+func TestTodoHistoryHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		err      error
+		wantCode int
+	}{
+		{name: "found", id: "1", wantCode: http.StatusOK},
+		{name: "invalid id", id: "abc", wantCode: http.StatusBadRequest},
+		{name: "not found", id: "1", err: domain.ErrTodoNotFound, wantCode: http.StatusNotFound},
+		{name: "internal error", id: "1", err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHistoryHandler(&mockTodoHistoryUsecase{err: tt.err})
+
+			req, _ := http.NewRequest("GET", "/todo/"+tt.id+"/history", nil)
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.TodoHistory(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestTodoHistoryHandler_Response(t *testing.T) {
+	// Given: 2件のイベントを返す usecase
+	handler := NewHistoryHandler(&mockTodoHistoryUsecase{})
+
+	req, _ := http.NewRequest("GET", "/todo/1/history", nil)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+
+	// When:  TodoHistory を呼び出す
+	handler.TodoHistory(w, req)
+
+	// Then:  種類・時刻・利用者を含むイベントが返る
+	var events []map[string]any
+	if err := json.NewDecoder(w.Body).Decode(&events); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[1]["type"] != "title_changed" || events[1]["actor"] != "bob" || events[1]["at"] != "2026-01-01T00:00:00Z" {
+		t.Errorf("Unexpected event: %v", events[1])
+	}
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+const (
+	ActorHeader     = "X-Actor"
+	DefaultActor    = "anonymous"
+	SessionHeader   = "X-Session-ID"
+	RequestIDHeader = "X-Request-ID"
+)
+
+// ActorMiddleware は X-Actor ヘッダーの値を操作した利用者として context に設定する。
+// 認証は行っていないため、値は申告されたものをそのまま使う。
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Header.Get(ActorHeader)
+		if actor == "" {
+			actor = DefaultActor
+		}
+		next.ServeHTTP(w, r.WithContext(domain.WithActor(r.Context(), actor)))
+	})
+}
+
+// SessionMiddleware は X-Session-ID ヘッダーの値をセッションとして context に設定する。
+// ヘッダーがない場合は利用者ごとに1つのセッションとみなすため、ActorMiddleware の内側で使う。
+func SessionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session := r.Header.Get(SessionHeader)
+		if session == "" {
+			session = "actor:" + domain.ActorFromContext(r.Context())
+		}
+		next.ServeHTTP(w, r.WithContext(domain.WithSession(r.Context(), session)))
+	})
+}
+
+// RequestIDMiddleware は X-Request-ID ヘッダーの値、なければ新たに生成した ID をリクエスト ID として context に設定し、
+// レスポンスの X-Request-ID ヘッダーでも返す。
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			b := make([]byte, 8)
+			rand.Read(b)
+			requestID = hex.EncodeToString(b)
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(domain.WithRequestID(r.Context(), requestID)))
+	})
+}
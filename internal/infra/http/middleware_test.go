@@ -0,0 +1,106 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestActorMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		wantActor string
+	}{
+		{name: "header", header: "alice", wantActor: "alice"},
+		{name: "no header", header: "", wantActor: DefaultActor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actor string
+			handler := ActorMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor = domain.ActorFromContext(r.Context())
+			}))
+
+			req, _ := http.NewRequest("GET", "/todo/list", nil)
+			if tt.header != "" {
+				req.Header.Set(ActorHeader, tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if actor != tt.wantActor {
+				t.Errorf("Expected actor '%s', got '%s'", tt.wantActor, actor)
+			}
+		})
+	}
+}
+
+func TestSessionMiddleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		wantSession string
+	}{
+		{name: "header", header: "tab-1", wantSession: "tab-1"},
+		{name: "no header", header: "", wantSession: "actor:alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var session string
+			handler := ActorMiddleware(SessionMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				session = domain.SessionFromContext(r.Context())
+			})))
+
+			req, _ := http.NewRequest("POST", "/undo", nil)
+			req.Header.Set(ActorHeader, "alice")
+			if tt.header != "" {
+				req.Header.Set(SessionHeader, tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if session != tt.wantSession {
+				t.Errorf("Expected session '%s', got '%s'", tt.wantSession, session)
+			}
+		})
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{name: "header", header: "req-1"},
+		{name: "no header", header: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requestID string
+			handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requestID = domain.RequestIDFromContext(r.Context())
+			}))
+
+			req, _ := http.NewRequest("POST", "/todo", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if tt.header != "" && requestID != tt.header {
+				t.Errorf("Expected request ID '%s', got '%s'", tt.header, requestID)
+			}
+			if requestID == "" {
+				t.Error("Expected request ID to be set")
+			}
+			if got := w.Header().Get(RequestIDHeader); got != requestID {
+				t.Errorf("Expected response header '%s', got '%s'", requestID, got)
+			}
+		})
+	}
+}
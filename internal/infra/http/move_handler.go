@@ -0,0 +1,58 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type MoveTodoUsecase interface {
+	Execute(ctx context.Context, id int, afterID int, beforeID int) (*domain.Todo, error)
+}
+
+type MoveHandler struct {
+	moveUsecase MoveTodoUsecase
+}
+
+func NewMoveHandler(move MoveTodoUsecase) *MoveHandler {
+	return &MoveHandler{moveUsecase: move}
+}
+
+type MoveTodoRequest struct {
+	AfterID  int `json:"after_id"`
+	BeforeID int `json:"before_id"`
+}
+
+func (h *MoveHandler) MoveTodo(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	var id int
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var req MoveTodoRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	todo, err := h.moveUsecase.Execute(r.Context(), id, req.AfterID, req.BeforeID)
+	if err != nil {
+		if err.Error() == "todo not found" {
+			w.WriteHeader(http.StatusNotFound)
+		} else if errors.Is(err, domain.ErrInvalidMove) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(todo)
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockMoveTodoUsecase struct {
+	err      error
+	afterID  int
+	beforeID int
+}
+
+func (m *mockMoveTodoUsecase) Execute(ctx context.Context, id int, afterID int, beforeID int) (*domain.Todo, error) {
+	m.afterID = afterID
+	m.beforeID = beforeID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Todo{ID: id, Title: "Buy milk", Status: domain.StatusTodo, Position: "i"}, nil
+}
+
+func TestMoveTodoHandler(t *testing.T) {
+	// Given: after_id と before_id を指定したリクエスト
+	// When:  MoveTodo を呼び出す
+	// Then:  200 OK が返り、隣接 ID が usecase に渡される
+	mockMove := &mockMoveTodoUsecase{}
+	handler := NewMoveHandler(mockMove)
+
+	req, _ := http.NewRequest("POST", "/todo/3/move", strings.NewReader(`{"after_id": 1, "before_id": 2}`))
+	req.SetPathValue("id", "3")
+	w := httptest.NewRecorder()
+
+	handler.MoveTodo(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if mockMove.afterID != 1 || mockMove.beforeID != 2 {
+		t.Errorf("Expected after=1 before=2, got after=%d before=%d", mockMove.afterID, mockMove.beforeID)
+	}
+}
+
+func TestMoveTodoHandler_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{name: "invalid id", id: "abc", body: `{"after_id": 1}`, wantCode: http.StatusBadRequest},
+		{name: "invalid json", id: "3", body: `not json`, wantCode: http.StatusBadRequest},
+		{name: "invalid move", id: "3", body: `{}`, err: domain.ErrInvalidMove, wantCode: http.StatusBadRequest},
+		{name: "not found", id: "3", body: `{"after_id": 99}`, err: domain.ErrTodoNotFound, wantCode: http.StatusNotFound},
+		{name: "internal error", id: "3", body: `{"after_id": 1}`, err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewMoveHandler(&mockMoveTodoUsecase{err: tt.err})
+
+			req, _ := http.NewRequest("POST", "/todo/"+tt.id+"/move", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.MoveTodo(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
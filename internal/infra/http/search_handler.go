@@ -0,0 +1,47 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type SearchTodoUsecase interface {
+	Execute(ctx context.Context, query string, limit int) ([]domain.SearchHit, error)
+}
+
+type SearchHandler struct {
+	searchUsecase SearchTodoUsecase
+}
+
+func NewSearchHandler(search SearchTodoUsecase) *SearchHandler {
+	return &SearchHandler{searchUsecase: search}
+}
+
+func (h *SearchHandler) SearchTodo(w http.ResponseWriter, r *http.Request) {
+	var limit int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if _, err := fmt.Sscanf(limitStr, "%d", &limit); err != nil || limit < 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	hitList, err := h.searchUsecase.Execute(r.Context(), r.URL.Query().Get("q"), limit)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidQuery) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(hitList)
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockSearchTodoUsecase struct {
+	err   error
+	query string
+	limit int
+}
+
+func (m *mockSearchTodoUsecase) Execute(ctx context.Context, query string, limit int) ([]domain.SearchHit, error) {
+	m.query = query
+	m.limit = limit
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []domain.SearchHit{{Todo: &domain.Todo{ID: 1, Title: "Buy milk"}, Score: 1.5, Snippet: "Buy <mark>milk</mark>"}}, nil
+}
+
+func TestSearchTodoHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		err       error
+		wantCode  int
+		wantLimit int
+	}{
+		{name: "found", url: "/todo/search?q=milk", wantCode: http.StatusOK},
+		{name: "with limit", url: "/todo/search?q=milk&limit=5", wantCode: http.StatusOK, wantLimit: 5},
+		{name: "invalid limit", url: "/todo/search?q=milk&limit=abc", wantCode: http.StatusBadRequest},
+		{name: "zero limit", url: "/todo/search?q=milk&limit=0", wantCode: http.StatusBadRequest},
+		{name: "invalid query", url: "/todo/search?q=%22milk", err: domain.ErrInvalidQuery, wantCode: http.StatusBadRequest},
+		{name: "internal error", url: "/todo/search?q=milk", err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSearch := &mockSearchTodoUsecase{err: tt.err}
+			handler := NewSearchHandler(mockSearch)
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			w := httptest.NewRecorder()
+
+			handler.SearchTodo(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if tt.wantCode == http.StatusOK && mockSearch.limit != tt.wantLimit {
+				t.Errorf("Expected limit %d, got %d", tt.wantLimit, mockSearch.limit)
+			}
+		})
+	}
+}
+
+func TestSearchTodoHandler_Response(t *testing.T) {
+	// Given: 検索結果を返す usecase
+	handler := NewSearchHandler(&mockSearchTodoUsecase{})
+
+	req, _ := http.NewRequest("GET", "/todo/search?q=milk", nil)
+	w := httptest.NewRecorder()
+
+	// When:  SearchTodo を呼び出す
+	handler.SearchTodo(w, req)
+
+	// Then:  todo, score, snippet を含む JSON が返る
+	var hitList []domain.SearchHit
+	if err := json.NewDecoder(w.Body).Decode(&hitList); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(hitList) != 1 || hitList[0].Todo.ID != 1 || hitList[0].Snippet != "Buy <mark>milk</mark>" {
+		t.Errorf("Expected 1 highlighted hit, got %+v", hitList)
+	}
+}
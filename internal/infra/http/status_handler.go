@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ChangeStatusTodoUsecase interface {
+	Execute(ctx context.Context, id int, status domain.Status) (*domain.Todo, error)
+}
+
+type StatusHandler struct {
+	changeStatusUsecase ChangeStatusTodoUsecase
+}
+
+func NewStatusHandler(changeStatus ChangeStatusTodoUsecase) *StatusHandler {
+	return &StatusHandler{changeStatusUsecase: changeStatus}
+}
+
+type ChangeStatusRequest struct {
+	Status domain.Status `json:"status"`
+}
+
+// Validate は指定の有無だけを検証する。取りうる値はステートマシンが決める。
+func (req ChangeStatusRequest) Validate() []FieldError {
+	if req.Status == "" {
+		return []FieldError{{Field: "status", Message: "is required"}}
+	}
+	return nil
+}
+
+func (h *StatusHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	var id int
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var req ChangeStatusRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	todo, err := h.changeStatusUsecase.Execute(r.Context(), id, req.Status)
+	if err != nil {
+		if err.Error() == "todo not found" {
+			w.WriteHeader(http.StatusNotFound)
+		} else if errors.Is(err, domain.ErrInvalidStatus) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else if errors.Is(err, domain.ErrInvalidTransition) {
+			w.WriteHeader(http.StatusConflict)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(todo)
+}
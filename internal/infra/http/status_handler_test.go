@@ -0,0 +1,82 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockChangeStatusTodoUsecase struct {
+	err error
+}
+
+func (m *mockChangeStatusTodoUsecase) Execute(ctx context.Context, id int, status domain.Status) (*domain.Todo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Todo{ID: id, Title: "Buy milk", Status: status}, nil
+}
+
+func TestChangeStatusHandler(t *testing.T) {
+	// Given: 正常に遷移する usecase
+	// When:  status=done で ChangeStatus を呼び出す
+	// Then:  200 OK と status / completed を含む JSON が返る
+	handler := NewStatusHandler(&mockChangeStatusTodoUsecase{})
+
+	body := strings.NewReader(`{"status": "done"}`)
+	req, _ := http.NewRequest("POST", "/todo/1/status", body)
+	req.SetPathValue("id", "1")
+	w := httptest.NewRecorder()
+
+	handler.ChangeStatus(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if got["status"] != "done" || got["completed"] != true {
+		t.Errorf("Unexpected response: %v", got)
+	}
+}
+
+func TestChangeStatusHandler_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{name: "invalid id", id: "abc", body: `{"status": "done"}`, wantCode: http.StatusBadRequest},
+		{name: "invalid json", id: "1", body: `not json`, wantCode: http.StatusBadRequest},
+		{name: "invalid status", id: "1", body: `{"status": "unknown"}`, err: domain.ErrInvalidStatus, wantCode: http.StatusBadRequest},
+		{name: "not found", id: "1", body: `{"status": "done"}`, err: fmt.Errorf("todo not found"), wantCode: http.StatusNotFound},
+		{name: "invalid transition", id: "1", body: `{"status": "done"}`, err: domain.ErrInvalidTransition, wantCode: http.StatusConflict},
+		{name: "internal error", id: "1", body: `{"status": "done"}`, err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewStatusHandler(&mockChangeStatusTodoUsecase{err: tt.err})
+
+			req, _ := http.NewRequest("POST", "/todo/"+tt.id+"/status", strings.NewReader(tt.body))
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.ChangeStatus(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
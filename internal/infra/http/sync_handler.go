@@ -0,0 +1,62 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type SyncTodoUsecase interface {
+	Execute(ctx context.Context, token string, pushes []domain.SyncPush) (*domain.SyncResponse, error)
+}
+
+type SyncHandler struct {
+	syncUsecase SyncTodoUsecase
+}
+
+func NewSyncHandler(sync SyncTodoUsecase) *SyncHandler {
+	return &SyncHandler{syncUsecase: sync}
+}
+
+// SyncRequest の Changes はクライアントでの変更で、サーバーでの変更を受け取る前に適用する。
+type SyncRequest struct {
+	Token   string            `json:"token"`
+	Changes []domain.SyncPush `json:"changes"`
+}
+
+// PullChanges は token の後の変更だけを返す。
+func (h *SyncHandler) PullChanges(w http.ResponseWriter, r *http.Request) {
+	h.sync(w, r, r.URL.Query().Get("token"), nil)
+}
+
+// Sync はクライアントでの変更を適用してから、token の後の変更を返す。
+func (h *SyncHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	var req SyncRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+	h.sync(w, r, req.Token, req.Changes)
+}
+
+// sync は token の位置から再開できない場合に 410 Gone を返す。クライアントはトークンなしで同期し直す。
+func (h *SyncHandler) sync(w http.ResponseWriter, r *http.Request, token string, pushes []domain.SyncPush) {
+	response, err := h.syncUsecase.Execute(r.Context(), token, pushes)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidSyncToken):
+			w.WriteHeader(http.StatusBadRequest)
+		case errors.Is(err, domain.ErrSyncTokenExpired):
+			w.WriteHeader(http.StatusGone)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
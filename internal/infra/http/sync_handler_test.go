@@ -0,0 +1,99 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockSyncTodoUsecase struct {
+	err    error
+	token  string
+	pushes []domain.SyncPush
+}
+
+func (m *mockSyncTodoUsecase) Execute(ctx context.Context, token string, pushes []domain.SyncPush) (*domain.SyncResponse, error) {
+	m.token = token
+	m.pushes = pushes
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.SyncResponse{Token: "next", Changes: []domain.SyncChange{}}, nil
+}
+
+func TestPullChangesHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "ok", wantCode: http.StatusOK},
+		{name: "invalid token", err: domain.ErrInvalidSyncToken, wantCode: http.StatusBadRequest},
+		{name: "expired token", err: domain.ErrSyncTokenExpired, wantCode: http.StatusGone},
+		{name: "internal error", err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSync := &mockSyncTodoUsecase{err: tt.err}
+			handler := NewSyncHandler(mockSync)
+
+			req, _ := http.NewRequest("GET", "/sync?token=abc", nil)
+			w := httptest.NewRecorder()
+
+			handler.PullChanges(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if mockSync.token != "abc" || mockSync.pushes != nil {
+				t.Errorf("Unexpected arguments: %q, %+v", mockSync.token, mockSync.pushes)
+			}
+		})
+	}
+}
+
+func TestSyncHandler(t *testing.T) {
+	// Given: トークンと2件の変更を含むリクエスト
+	// When:  Sync を呼び出す
+	// Then:  変更がそのまま usecase に渡され、200 OK が返る
+	mockSync := &mockSyncTodoUsecase{}
+	handler := NewSyncHandler(mockSync)
+
+	body := `{"token":"abc","changes":[{"op":"create","title":"Buy milk","client_id":"tmp-1"},{"op":"update","id":2,"title":"Read paper","base_seq":7}]}`
+	req, _ := http.NewRequest("POST", "/sync", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.Sync(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if mockSync.token != "abc" || len(mockSync.pushes) != 2 {
+		t.Fatalf("Unexpected arguments: %q, %+v", mockSync.token, mockSync.pushes)
+	}
+	if p := mockSync.pushes[0]; p.Op != domain.BulkCreate || p.ClientID != "tmp-1" {
+		t.Errorf("Unexpected create: %+v", p)
+	}
+	if p := mockSync.pushes[1]; p.ID != 2 || p.Title != "Read paper" || p.BaseSeq != 7 {
+		t.Errorf("Unexpected update: %+v", p)
+	}
+}
+
+func TestSyncHandler_InvalidJSON(t *testing.T) {
+	handler := NewSyncHandler(&mockSyncTodoUsecase{})
+
+	req, _ := http.NewRequest("POST", "/sync", strings.NewReader(`{`))
+	w := httptest.NewRecorder()
+
+	handler.Sync(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ListTrashTodoUsecase interface {
+	Execute(ctx context.Context) ([]*domain.Todo, error)
+}
+
+type RestoreTodoUsecase interface {
+	Execute(ctx context.Context, id int) (*domain.Todo, error)
+}
+
+type PurgeTodoUsecase interface {
+	Execute(ctx context.Context, id int) error
+}
+
+type TrashHandler struct {
+	listTrashUsecase ListTrashTodoUsecase
+	restoreUsecase   RestoreTodoUsecase
+	purgeUsecase     PurgeTodoUsecase
+}
+
+func NewTrashHandler(listTrash ListTrashTodoUsecase, restore RestoreTodoUsecase, purge PurgeTodoUsecase) *TrashHandler {
+	return &TrashHandler{
+		listTrashUsecase: listTrash,
+		restoreUsecase:   restore,
+		purgeUsecase:     purge,
+	}
+}
+
+func (h *TrashHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	todos, err := h.listTrashUsecase.Execute(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(todos)
+}
+
+func (h *TrashHandler) RestoreTodo(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	var id int
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	todo, err := h.restoreUsecase.Execute(r.Context(), id)
+	if err != nil {
+		if err.Error() == "todo not found" {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(todo)
+}
+
+func (h *TrashHandler) PurgeTodo(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	var id int
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := h.purgeUsecase.Execute(r.Context(), id); err != nil {
+		if err.Error() == "todo not found" {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "todo purged successfully"})
+}
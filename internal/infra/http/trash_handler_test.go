@@ -0,0 +1,144 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockListTrashTodoUsecase struct {
+	err   error
+	todos []*domain.Todo
+}
+
+func (m *mockListTrashTodoUsecase) Execute(ctx context.Context) ([]*domain.Todo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.todos, nil
+}
+
+type mockRestoreTodoUsecase struct {
+	err error
+}
+
+func (m *mockRestoreTodoUsecase) Execute(ctx context.Context, id int) (*domain.Todo, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Todo{ID: id, Title: "Buy milk", Status: domain.StatusTodo}, nil
+}
+
+type mockPurgeTodoUsecase struct {
+	err error
+}
+
+func (m *mockPurgeTodoUsecase) Execute(ctx context.Context, id int) error {
+	return m.err
+}
+
+func TestListTrashHandler(t *testing.T) {
+	// Given: ゴミ箱に1件ある usecase
+	// When:  ListTrash を呼び出す
+	// Then:  200 OK と deleted_at 付きの一覧が返る
+	deletedAt := time.Now()
+	mockList := &mockListTrashTodoUsecase{
+		todos: []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, DeletedAt: &deletedAt}},
+	}
+	handler := NewTrashHandler(mockList, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/trash", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListTrash(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	var got []map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if len(got) != 1 || got[0]["deleted_at"] == nil {
+		t.Errorf("Unexpected response: %v", got)
+	}
+}
+
+func TestListTrashHandler_UsecaseError(t *testing.T) {
+	handler := NewTrashHandler(&mockListTrashTodoUsecase{err: fmt.Errorf("repository error")}, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/trash", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListTrash(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", w.Code)
+	}
+}
+
+func TestRestoreTodoHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		err      error
+		wantCode int
+	}{
+		{name: "restored", id: "1", wantCode: http.StatusOK},
+		{name: "invalid id", id: "abc", wantCode: http.StatusBadRequest},
+		{name: "not in trash", id: "1", err: domain.ErrTodoNotFound, wantCode: http.StatusNotFound},
+		{name: "internal error", id: "1", err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewTrashHandler(nil, &mockRestoreTodoUsecase{err: tt.err}, nil)
+
+			req, _ := http.NewRequest("POST", "/todo/"+tt.id+"/restore", nil)
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.RestoreTodo(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestPurgeTodoHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		err      error
+		wantCode int
+	}{
+		{name: "purged", id: "1", wantCode: http.StatusOK},
+		{name: "invalid id", id: "abc", wantCode: http.StatusBadRequest},
+		{name: "not in trash", id: "1", err: domain.ErrTodoNotFound, wantCode: http.StatusNotFound},
+		{name: "internal error", id: "1", err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewTrashHandler(nil, nil, &mockPurgeTodoUsecase{err: tt.err})
+
+			req, _ := http.NewRequest("DELETE", "/trash/"+tt.id, nil)
+			req.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			handler.PurgeTodo(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type UndoTodoUsecase interface {
+	Execute(ctx context.Context) ([]domain.Change, error)
+}
+
+type RedoTodoUsecase interface {
+	Execute(ctx context.Context) ([]domain.Change, error)
+}
+
+type UndoHandler struct {
+	undoUsecase UndoTodoUsecase
+	redoUsecase RedoTodoUsecase
+}
+
+func NewUndoHandler(undo UndoTodoUsecase, redo RedoTodoUsecase) *UndoHandler {
+	return &UndoHandler{undoUsecase: undo, redoUsecase: redo}
+}
+
+func (h *UndoHandler) Undo(w http.ResponseWriter, r *http.Request) {
+	changes, err := h.undoUsecase.Execute(r.Context())
+	writeUndoResult(w, changes, err)
+}
+
+func (h *UndoHandler) Redo(w http.ResponseWriter, r *http.Request) {
+	changes, err := h.redoUsecase.Execute(r.Context())
+	writeUndoResult(w, changes, err)
+}
+
+func writeUndoResult(w http.ResponseWriter, changes []domain.Change, err error) {
+	if err != nil {
+		if errors.Is(err, domain.ErrNothingToUndo) || errors.Is(err, domain.ErrNothingToRedo) {
+			w.WriteHeader(http.StatusNotFound)
+		} else if errors.Is(err, domain.ErrUndoConflict) {
+			w.WriteHeader(http.StatusConflict)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(changes)
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockUndoTodoUsecase struct {
+	err error
+}
+
+func (m *mockUndoTodoUsecase) Execute(ctx context.Context) ([]domain.Change, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []domain.Change{{ID: 1, Before: &domain.Todo{ID: 1, Title: "Buy eggs"}, After: &domain.Todo{ID: 1, Title: "Buy milk"}}}, nil
+}
+
+func TestUndoHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		redo     bool
+		err      error
+		wantCode int
+	}{
+		{name: "undo", wantCode: http.StatusOK},
+		{name: "nothing to undo", err: domain.ErrNothingToUndo, wantCode: http.StatusNotFound},
+		{name: "undo conflict", err: domain.ErrUndoConflict, wantCode: http.StatusConflict},
+		{name: "undo internal error", err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+		{name: "redo", redo: true, wantCode: http.StatusOK},
+		{name: "nothing to redo", redo: true, err: domain.ErrNothingToRedo, wantCode: http.StatusNotFound},
+		{name: "redo conflict", redo: true, err: domain.ErrUndoConflict, wantCode: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := &mockUndoTodoUsecase{err: tt.err}
+			handler := NewUndoHandler(usecase, usecase)
+
+			w := httptest.NewRecorder()
+			if tt.redo {
+				req, _ := http.NewRequest("POST", "/redo", nil)
+				handler.Redo(w, req)
+			} else {
+				req, _ := http.NewRequest("POST", "/undo", nil)
+				handler.Undo(w, req)
+			}
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestUndoHandler_Response(t *testing.T) {
+	// Given: 1件の変更を取り消す usecase
+	usecase := &mockUndoTodoUsecase{}
+	handler := NewUndoHandler(usecase, usecase)
+
+	req, _ := http.NewRequest("POST", "/undo", nil)
+	w := httptest.NewRecorder()
+
+	// When:  Undo を呼び出す
+	handler.Undo(w, req)
+
+	// Then:  変更前後の状態が返る
+	var changes []domain.Change
+	if err := json.NewDecoder(w.Body).Decode(&changes); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Before.Title != "Buy eggs" || changes[0].After.Title != "Buy milk" {
+		t.Errorf("Unexpected changes: %+v", changes)
+	}
+}
@@ -0,0 +1,213 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// maxRequestBody はリクエストの本文の上限。
+const maxRequestBody = 1 << 20
+
+// FieldError は本文のフィールドごとの誤り。Field はトップレベルのフィールド名で、入れ子の場合は "operations.0.id" のように続ける。
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse は検証に失敗したときの本文。Fields には見つかった誤りをすべて含める。
+type ErrorResponse struct {
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// validator はリクエストの型が値の条件を検証する。型や未知のフィールドは decodeRequest が検証する。
+type validator interface {
+	Validate() []FieldError
+}
+
+// decodeRequest は本文を JSON として dst に読み込み、誤りがあれば 400 または 415 を返して false を返す。
+// 本文は dst の型（OpenAPI の文書と同じ型）に従って検証する。
+//   - Content-Type は application/json。省略した場合は JSON とみなす
+//   - 本文はオブジェクト1つで、その後にデータがないこと
+//   - 未知のフィールドや型の誤りは最初の1つで止めず、フィールドごとにまとめて返す
+//   - dst が validator なら、その誤りも合わせて返す
+func decodeRequest(w http.ResponseWriter, r *http.Request, dst any) bool {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || mediaType != "application/json" {
+			writeError(w, http.StatusUnsupportedMediaType, ErrorResponse{Error: "Content-Type must be application/json"})
+			return false
+		}
+	}
+
+	fields, err := readObject(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, ErrorResponse{Error: fmt.Sprintf("request body must not exceed %d bytes", maxRequestBody)})
+			return false
+		}
+		writeError(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return false
+	}
+
+	fieldErrors := decodeFields(fields, dst)
+	if v, ok := dst.(validator); ok {
+		// 型の誤りで読み込めなかったフィールドは値の条件を検証しない
+		failed := map[string]bool{}
+		for _, fe := range fieldErrors {
+			failed[strings.SplitN(fe.Field, ".", 2)[0]] = true
+		}
+		for _, fe := range v.Validate() {
+			if !failed[strings.SplitN(fe.Field, ".", 2)[0]] {
+				fieldErrors = append(fieldErrors, fe)
+			}
+		}
+	}
+	if len(fieldErrors) > 0 {
+		writeError(w, http.StatusBadRequest, ErrorResponse{Error: "invalid request body", Fields: fieldErrors})
+		return false
+	}
+	return true
+}
+
+// readObject は本文の JSON オブジェクトをフィールドごとに分けて返す。
+func readObject(body io.Reader) (map[string]json.RawMessage, error) {
+	dec := json.NewDecoder(body)
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			return nil, err
+		case errors.Is(err, io.EOF):
+			return nil, errors.New("request body is required")
+		default:
+			return nil, fmt.Errorf("request body is not valid JSON: %v", err)
+		}
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, err
+		}
+		return nil, errors.New("request body must contain a single JSON object")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		return nil, errors.New("request body must be a JSON object")
+	}
+	return fields, nil
+}
+
+// decodeFields はフィールドを1つずつ dst の対応するフィールドに読み込み、誤りをすべて返す。
+// 入れ子のオブジェクトも未知のフィールドを許さない。
+func decodeFields(fields map[string]json.RawMessage, dst any) []FieldError {
+	targets := map[string]reflect.Value{}
+	collectFields(reflect.ValueOf(dst).Elem(), targets)
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fieldErrors []FieldError
+	for _, name := range names {
+		target, ok := targets[name]
+		if !ok {
+			fieldErrors = append(fieldErrors, FieldError{Field: name, Message: "unknown field"})
+			continue
+		}
+		dec := json.NewDecoder(bytes.NewReader(fields[name]))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(target.Addr().Interface()); err != nil {
+			fieldErrors = append(fieldErrors, fieldError(name, err))
+		}
+	}
+	return fieldErrors
+}
+
+// collectFields は encoding/json と同じ名前で構造体のフィールドを集める。名前のない埋め込みの構造体は展開する。
+func collectFields(v reflect.Value, targets map[string]reflect.Value) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			collectFields(v.Field(i), targets)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		targets[name] = v.Field(i)
+	}
+}
+
+// fieldError は encoding/json のエラーを利用者に見せるメッセージにする。
+func fieldError(name string, err error) FieldError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := name
+		if typeErr.Field != "" {
+			field += "." + typeErr.Field
+		}
+		return FieldError{Field: field, Message: "must be " + jsonTypeName(typeErr.Type)}
+	}
+	if unknown, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return FieldError{Field: name + "." + strings.Trim(unknown, `"`), Message: "unknown field"}
+	}
+	return FieldError{Field: name, Message: err.Error()}
+}
+
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// requireTitle は Todo のタイトルやビューの名前を domain.ValidateTodo / ValidateView と同じ条件で検証する。
+func requireTitle(field, title string) []FieldError {
+	switch {
+	case title == "":
+		return []FieldError{{Field: field, Message: "is required"}}
+	case len(title) > 255:
+		return []FieldError{{Field: field, Message: "must be at most 255 bytes"}}
+	}
+	return nil
+}
+
+func writeError(w http.ResponseWriter, status int, body ErrorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
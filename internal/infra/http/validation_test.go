@@ -0,0 +1,184 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeRequest_CreateTodo(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantError   string
+		wantFields  []FieldError
+	}{
+		{
+			name:        "valid",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"title": "Buy milk"}`,
+			wantStatus:  http.StatusCreated,
+		},
+		{
+			name:       "content type omitted",
+			body:       `{"title": "Buy milk"}`,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:        "form content type",
+			contentType: "application/x-www-form-urlencoded",
+			body:        `title=Buy+milk`,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantError:   "Content-Type must be application/json",
+		},
+		{
+			name:       "empty body",
+			wantStatus: http.StatusBadRequest,
+			wantError:  "request body is required",
+		},
+		{
+			name:       "malformed JSON",
+			body:       `{"title": `,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "request body is not valid JSON: unexpected EOF",
+		},
+		{
+			name:       "trailing data",
+			body:       `{"title": "Buy milk"} {"title": "Read book"}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "request body must contain a single JSON object",
+		},
+		{
+			name:       "not an object",
+			body:       `["Buy milk"]`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "request body must be a JSON object",
+		},
+		{
+			name:       "all field errors at once",
+			body:       `{"titel": "Buy milk", "due_date": "2026-01-01", "title": 1}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid request body",
+			wantFields: []FieldError{
+				{Field: "due_date", Message: "unknown field"},
+				{Field: "titel", Message: "unknown field"},
+				{Field: "title", Message: "must be a string"},
+			},
+		},
+		{
+			name:       "missing title",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid request body",
+			wantFields: []FieldError{{Field: "title", Message: "is required"}},
+		},
+		{
+			name:       "title too long",
+			body:       `{"title": "` + strings.Repeat("a", 256) + `"}`,
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid request body",
+			wantFields: []FieldError{{Field: "title", Message: "must be at most 255 bytes"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: 作成する TodoHandler
+			handler := NewTodoHandler(&mockCreateTodoUsecase{}, nil, nil, nil, nil)
+			req := httptest.NewRequest("POST", "/todo", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			// When:  CreateTodo を呼び出す
+			handler.CreateTodo(w, req)
+
+			// Then:  誤りがあれば、すべてのフィールドの誤りを1つのレスポンスで返す
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantError == "" {
+				return
+			}
+			var resp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Error != tt.wantError || !reflect.DeepEqual(resp.Fields, tt.wantFields) {
+				t.Errorf("Expected %q %v, got %q %v", tt.wantError, tt.wantFields, resp.Error, resp.Fields)
+			}
+		})
+	}
+}
+
+func TestDecodeRequest_Nested(t *testing.T) {
+	// Given: 入れ子のオブジェクトに未知のフィールドと型の誤りがある本文
+	body := `{"mode": "atomic", "operations": [{"op": "create", "title": "a", "color": "red"}]}`
+	req := httptest.NewRequest("POST", "/todo/bulk", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	var dst BulkTodoRequest
+
+	// When:  本文を読み込む
+	ok := decodeRequest(w, req, &dst)
+
+	// Then:  入れ子のフィールドの位置を示して拒否する
+	if ok || w.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", w.Code)
+	}
+	var resp ErrorResponse
+	json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Fields) != 1 || resp.Fields[0].Field != "operations.color" {
+		t.Errorf("Unexpected fields: %v", resp.Fields)
+	}
+
+	// Given: 入れ子のフィールドの型が違う本文
+	req = httptest.NewRequest("POST", "/todo/bulk", strings.NewReader(`{"operations": [{"op": "update", "id": "1"}]}`))
+	w = httptest.NewRecorder()
+
+	// When:  本文を読み込む
+	decodeRequest(w, req, &BulkTodoRequest{})
+
+	// Then:  型の誤りもフィールドの位置を示す
+	resp = ErrorResponse{}
+	json.NewDecoder(w.Body).Decode(&resp)
+	if len(resp.Fields) != 1 || resp.Fields[0] != (FieldError{Field: "operations.0.id", Message: "must be an integer"}) {
+		t.Errorf("Unexpected fields: %v", resp.Fields)
+	}
+}
+
+func TestDecodeRequest_TooLarge(t *testing.T) {
+	body := `{"title": "` + strings.Repeat("a", maxRequestBody) + `"}`
+	req := httptest.NewRequest("POST", "/todo", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	ok := decodeRequest(w, req, &CreateTodoRequest{})
+
+	if ok || w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, got %d", w.Code)
+	}
+}
+
+func TestDecodeRequest_Embedded(t *testing.T) {
+	// Given: 埋め込みの構造体（SyncPush は BulkOperation を埋め込む）のフィールド
+	body := `{"token": "", "changes": [{"op": "create", "title": "a", "client_id": "c1", "base_seq": 0}]}`
+	req := httptest.NewRequest("POST", "/sync", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	var dst SyncRequest
+
+	// When:  本文を読み込む
+	ok := decodeRequest(w, req, &dst)
+
+	// Then:  埋め込みのフィールドも既知のフィールドとして読み込む
+	if !ok {
+		t.Fatalf("Expected valid request, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(dst.Changes) != 1 || dst.Changes[0].Title != "a" || dst.Changes[0].ClientID != "c1" {
+		t.Errorf("Unexpected changes: %+v", dst.Changes)
+	}
+}
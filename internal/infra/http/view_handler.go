@@ -0,0 +1,159 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type CreateViewUsecase interface {
+	Execute(ctx context.Context, name string, query domain.ListQuery) (*domain.View, error)
+}
+
+type ListViewUsecase interface {
+	Execute(ctx context.Context) ([]*domain.View, error)
+}
+
+type FindViewUsecase interface {
+	Execute(ctx context.Context, id string) (*domain.View, error)
+}
+
+type UpdateViewUsecase interface {
+	Execute(ctx context.Context, id string, name string, query domain.ListQuery) (*domain.View, error)
+}
+
+type DeleteViewUsecase interface {
+	Execute(ctx context.Context, id string) error
+}
+
+type ListViewTodoUsecase interface {
+	Execute(ctx context.Context, id string) ([]*domain.Todo, error)
+}
+
+type ViewHandler struct {
+	createUsecase   CreateViewUsecase
+	listUsecase     ListViewUsecase
+	findUsecase     FindViewUsecase
+	updateUsecase   UpdateViewUsecase
+	deleteUsecase   DeleteViewUsecase
+	listTodoUsecase ListViewTodoUsecase
+}
+
+func NewViewHandler(create CreateViewUsecase, list ListViewUsecase, find FindViewUsecase, update UpdateViewUsecase, del DeleteViewUsecase, listTodo ListViewTodoUsecase) *ViewHandler {
+	return &ViewHandler{
+		createUsecase:   create,
+		listUsecase:     list,
+		findUsecase:     find,
+		updateUsecase:   update,
+		deleteUsecase:   del,
+		listTodoUsecase: listTodo,
+	}
+}
+
+type ViewRequest struct {
+	Name  string           `json:"name"`
+	Query domain.ListQuery `json:"query"`
+}
+
+func (req ViewRequest) Validate() []FieldError {
+	return requireTitle("name", req.Name)
+}
+
+func (h *ViewHandler) CreateView(w http.ResponseWriter, r *http.Request) {
+	var req ViewRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	view, err := h.createUsecase.Execute(r.Context(), req.Name, req.Query)
+	if err != nil {
+		writeViewError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(view)
+}
+
+func (h *ViewHandler) ListViews(w http.ResponseWriter, r *http.Request) {
+	views, err := h.listUsecase.Execute(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(views)
+}
+
+func (h *ViewHandler) FindView(w http.ResponseWriter, r *http.Request) {
+	view, err := h.findUsecase.Execute(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeViewError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(view)
+}
+
+func (h *ViewHandler) UpdateView(w http.ResponseWriter, r *http.Request) {
+	var req ViewRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	view, err := h.updateUsecase.Execute(r.Context(), r.PathValue("id"), req.Name, req.Query)
+	if err != nil {
+		writeViewError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(view)
+}
+
+func (h *ViewHandler) DeleteView(w http.ResponseWriter, r *http.Request) {
+	if err := h.deleteUsecase.Execute(r.Context(), r.PathValue("id")); err != nil {
+		writeViewError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "view deleted successfully"})
+}
+
+func (h *ViewHandler) ListViewTodos(w http.ResponseWriter, r *http.Request) {
+	todos, err := h.listTodoUsecase.Execute(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeViewError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(todos)
+}
+
+func writeViewError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidFilter):
+		writeFilterError(w, err)
+	case errors.Is(err, domain.ErrViewNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, domain.ErrViewReadOnly):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Is(err, domain.ErrInvalidView), errors.Is(err, domain.ErrInvalidSort), errors.Is(err, domain.ErrInvalidLimit):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,277 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockCreateViewUsecase struct {
+	err   error
+	name  string
+	query domain.ListQuery
+}
+
+func (m *mockCreateViewUsecase) Execute(ctx context.Context, name string, query domain.ListQuery) (*domain.View, error) {
+	m.name = name
+	m.query = query
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.View{ID: "1", Name: name, Query: query}, nil
+}
+
+type mockListViewUsecase struct {
+	err error
+}
+
+func (m *mockListViewUsecase) Execute(ctx context.Context) ([]*domain.View, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return domain.BuiltInViews(), nil
+}
+
+type mockFindViewUsecase struct {
+	err error
+}
+
+func (m *mockFindViewUsecase) Execute(ctx context.Context, id string) (*domain.View, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.View{ID: id, Name: "Backend"}, nil
+}
+
+type mockUpdateViewUsecase struct {
+	err error
+	id  string
+}
+
+func (m *mockUpdateViewUsecase) Execute(ctx context.Context, id string, name string, query domain.ListQuery) (*domain.View, error) {
+	m.id = id
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.View{ID: id, Name: name, Query: query}, nil
+}
+
+type mockDeleteViewUsecase struct {
+	err error
+}
+
+func (m *mockDeleteViewUsecase) Execute(ctx context.Context, id string) error {
+	return m.err
+}
+
+type mockListViewTodoUsecase struct {
+	err error
+	id  string
+}
+
+func (m *mockListViewTodoUsecase) Execute(ctx context.Context, id string) ([]*domain.Todo, error) {
+	m.id = id
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*domain.Todo{{ID: 1, Title: "Buy milk"}}, nil
+}
+
+func TestCreateViewHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{name: "created", body: `{"name":"Backend","query":{"q":"status:open backend","sort":"position","limit":20}}`, wantCode: http.StatusCreated},
+		{name: "invalid json", body: `{`, wantCode: http.StatusBadRequest},
+		{name: "invalid view", body: `{"name":""}`, err: domain.ErrInvalidView, wantCode: http.StatusBadRequest},
+		{name: "invalid sort", body: `{"name":"Backend","query":{"sort":"title"}}`, err: domain.ErrInvalidSort, wantCode: http.StatusBadRequest},
+		{name: "invalid filter", body: `{"name":"Backend","query":{"q":"tag:x"}}`, err: domain.ErrInvalidFilter, wantCode: http.StatusBadRequest},
+		{name: "internal error", body: `{"name":"Backend"}`, err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCreate := &mockCreateViewUsecase{err: tt.err}
+			handler := NewViewHandler(mockCreate, nil, nil, nil, nil, nil)
+
+			req, _ := http.NewRequest("POST", "/views", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.CreateView(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestCreateViewHandler_Request(t *testing.T) {
+	// Given: 名前と条件を含むリクエスト
+	// When:  CreateView を呼び出す
+	// Then:  条件がそのまま usecase に渡される
+	mockCreate := &mockCreateViewUsecase{}
+	handler := NewViewHandler(mockCreate, nil, nil, nil, nil, nil)
+
+	body := `{"name":"Backend","query":{"q":"status:open backend","sort":"position","limit":20}}`
+	req, _ := http.NewRequest("POST", "/views", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.CreateView(w, req)
+
+	want := domain.ListQuery{Filter: "status:open backend", Sort: domain.SortPosition, Limit: 20}
+	if mockCreate.name != "Backend" || mockCreate.query != want {
+		t.Errorf("Expected Backend and %+v, got %s and %+v", want, mockCreate.name, mockCreate.query)
+	}
+}
+
+func TestListViewsHandler(t *testing.T) {
+	// Given: 組み込みビューを返す usecase
+	// When:  ListViews を呼び出す
+	// Then:  200 OK とビューの一覧が返る
+	handler := NewViewHandler(nil, &mockListViewUsecase{}, nil, nil, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/views", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListViews(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	var views []*domain.View
+	if err := json.NewDecoder(w.Body).Decode(&views); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(views) != len(domain.BuiltInViews()) {
+		t.Errorf("Expected %d views, got %d", len(domain.BuiltInViews()), len(views))
+	}
+}
+
+func TestFindViewHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "found", wantCode: http.StatusOK},
+		{name: "not found", err: domain.ErrViewNotFound, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewViewHandler(nil, nil, &mockFindViewUsecase{err: tt.err}, nil, nil, nil)
+
+			req, _ := http.NewRequest("GET", "/views/1", nil)
+			req.SetPathValue("id", "1")
+			w := httptest.NewRecorder()
+
+			handler.FindView(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestUpdateViewHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{name: "updated", body: `{"name":"API","query":{"q":"api"}}`, wantCode: http.StatusOK},
+		{name: "invalid json", body: `{`, wantCode: http.StatusBadRequest},
+		{name: "not found", body: `{"name":"API"}`, err: domain.ErrViewNotFound, wantCode: http.StatusNotFound},
+		{name: "built-in", body: `{"name":"API"}`, err: domain.ErrViewReadOnly, wantCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUpdate := &mockUpdateViewUsecase{err: tt.err}
+			handler := NewViewHandler(nil, nil, nil, mockUpdate, nil, nil)
+
+			req, _ := http.NewRequest("PUT", "/views/1", strings.NewReader(tt.body))
+			req.SetPathValue("id", "1")
+			w := httptest.NewRecorder()
+
+			handler.UpdateView(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteViewHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "deleted", wantCode: http.StatusOK},
+		{name: "not found", err: domain.ErrViewNotFound, wantCode: http.StatusNotFound},
+		{name: "built-in", err: domain.ErrViewReadOnly, wantCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewViewHandler(nil, nil, nil, nil, &mockDeleteViewUsecase{err: tt.err}, nil)
+
+			req, _ := http.NewRequest("DELETE", "/views/1", nil)
+			req.SetPathValue("id", "1")
+			w := httptest.NewRecorder()
+
+			handler.DeleteView(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestListViewTodosHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "found", wantCode: http.StatusOK},
+		{name: "not found", err: domain.ErrViewNotFound, wantCode: http.StatusNotFound},
+		{name: "broken filter", err: domain.ErrInvalidFilter, wantCode: http.StatusBadRequest},
+		{name: "internal error", err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockListTodo := &mockListViewTodoUsecase{err: tt.err}
+			handler := NewViewHandler(nil, nil, nil, nil, nil, mockListTodo)
+
+			req, _ := http.NewRequest("GET", "/views/today/todos", nil)
+			req.SetPathValue("id", "today")
+			w := httptest.NewRecorder()
+
+			handler.ListViewTodos(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if mockListTodo.id != "today" {
+				t.Errorf("Expected id 'today', got '%s'", mockListTodo.id)
+			}
+		})
+	}
+}
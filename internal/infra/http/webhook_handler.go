@@ -0,0 +1,181 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type CreateWebhookUsecase interface {
+	Execute(ctx context.Context, url string, events []domain.FeedEventType, secret string) (*domain.Webhook, error)
+}
+
+type ListWebhookUsecase interface {
+	Execute(ctx context.Context) ([]*domain.Webhook, error)
+}
+
+type FindWebhookUsecase interface {
+	Execute(ctx context.Context, id string) (*domain.Webhook, error)
+}
+
+type UpdateWebhookUsecase interface {
+	Execute(ctx context.Context, id string, url string, events []domain.FeedEventType, secret string) (*domain.Webhook, error)
+}
+
+type DeleteWebhookUsecase interface {
+	Execute(ctx context.Context, id string) error
+}
+
+type ListWebhookDeliveryUsecase interface {
+	Execute(ctx context.Context, webhookID string, limit int) ([]*domain.WebhookDelivery, error)
+}
+
+type WebhookHandler struct {
+	createUsecase       CreateWebhookUsecase
+	listUsecase         ListWebhookUsecase
+	findUsecase         FindWebhookUsecase
+	updateUsecase       UpdateWebhookUsecase
+	deleteUsecase       DeleteWebhookUsecase
+	listDeliveryUsecase ListWebhookDeliveryUsecase
+}
+
+func NewWebhookHandler(create CreateWebhookUsecase, list ListWebhookUsecase, find FindWebhookUsecase, update UpdateWebhookUsecase, del DeleteWebhookUsecase, listDelivery ListWebhookDeliveryUsecase) *WebhookHandler {
+	return &WebhookHandler{
+		createUsecase:       create,
+		listUsecase:         list,
+		findUsecase:         find,
+		updateUsecase:       update,
+		deleteUsecase:       del,
+		listDeliveryUsecase: listDelivery,
+	}
+}
+
+// WebhookRequest の Secret は省略できる。作成時に省略すると生成し、更新時に省略すると変更しない。
+type WebhookRequest struct {
+	URL    string                 `json:"url"`
+	Events []domain.FeedEventType `json:"events"`
+	Secret string                 `json:"secret"`
+}
+
+func (req WebhookRequest) Validate() []FieldError {
+	if req.URL == "" {
+		return []FieldError{{Field: "url", Message: "is required"}}
+	}
+	return nil
+}
+
+// CreateWebhook は署名の鍵を含めて返す。鍵を受け取れるのはこのレスポンスだけ。
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req WebhookRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	webhook, err := h.createUsecase.Execute(r.Context(), req.URL, req.Events, req.Secret)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(webhook)
+}
+
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.listUsecase.Execute(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	redacted := make([]*domain.Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		redacted[i] = redactWebhook(webhook)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(redacted)
+}
+
+func (h *WebhookHandler) FindWebhook(w http.ResponseWriter, r *http.Request) {
+	webhook, err := h.findUsecase.Execute(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(redactWebhook(webhook))
+}
+
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req WebhookRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	webhook, err := h.updateUsecase.Execute(r.Context(), r.PathValue("id"), req.URL, req.Events, req.Secret)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(redactWebhook(webhook))
+}
+
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := h.deleteUsecase.Execute(r.Context(), r.PathValue("id")); err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "webhook deleted successfully"})
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	var limit int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if _, err := fmt.Sscanf(limitStr, "%d", &limit); err != nil || limit < 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	deliveries, err := h.listDeliveryUsecase.Execute(r.Context(), r.PathValue("id"), limit)
+	if err != nil {
+		writeWebhookError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+func redactWebhook(webhook *domain.Webhook) *domain.Webhook {
+	redacted := *webhook
+	redacted.Secret = ""
+	return &redacted
+}
+
+func writeWebhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Is(err, domain.ErrInvalidWebhook), errors.Is(err, domain.ErrInvalidLimit):
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
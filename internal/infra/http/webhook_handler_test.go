@@ -0,0 +1,269 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockCreateWebhookUsecase struct {
+	err error
+}
+
+func (m *mockCreateWebhookUsecase) Execute(ctx context.Context, url string, events []domain.FeedEventType, secret string) (*domain.Webhook, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Webhook{ID: "1", URL: url, Events: events, Secret: "generated"}, nil
+}
+
+type mockListWebhookUsecase struct {
+	err error
+}
+
+func (m *mockListWebhookUsecase) Execute(ctx context.Context) ([]*domain.Webhook, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*domain.Webhook{{ID: "1", URL: "http://example.com/hook", Secret: "s3cret"}}, nil
+}
+
+type mockFindWebhookUsecase struct {
+	err error
+}
+
+func (m *mockFindWebhookUsecase) Execute(ctx context.Context, id string) (*domain.Webhook, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Webhook{ID: id, URL: "http://example.com/hook", Secret: "s3cret"}, nil
+}
+
+type mockUpdateWebhookUsecase struct {
+	err error
+}
+
+func (m *mockUpdateWebhookUsecase) Execute(ctx context.Context, id string, url string, events []domain.FeedEventType, secret string) (*domain.Webhook, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Webhook{ID: id, URL: url, Events: events, Secret: "s3cret"}, nil
+}
+
+type mockDeleteWebhookUsecase struct {
+	err error
+}
+
+func (m *mockDeleteWebhookUsecase) Execute(ctx context.Context, id string) error {
+	return m.err
+}
+
+type mockListWebhookDeliveryUsecase struct {
+	err   error
+	limit int
+}
+
+func (m *mockListWebhookDeliveryUsecase) Execute(ctx context.Context, webhookID string, limit int) ([]*domain.WebhookDelivery, error) {
+	m.limit = limit
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*domain.WebhookDelivery{{ID: "1", WebhookID: webhookID, Status: domain.DeliverySucceeded}}, nil
+}
+
+func TestCreateWebhookHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{name: "created", body: `{"url":"http://example.com/hook","events":["created"]}`, wantCode: http.StatusCreated},
+		{name: "invalid json", body: `{`, wantCode: http.StatusBadRequest},
+		{name: "invalid webhook", body: `{"url":"ftp://example.com"}`, err: domain.ErrInvalidWebhook, wantCode: http.StatusBadRequest},
+		{name: "internal error", body: `{"url":"http://example.com/hook"}`, err: fmt.Errorf("internal error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewWebhookHandler(&mockCreateWebhookUsecase{err: tt.err}, nil, nil, nil, nil, nil)
+
+			req, _ := http.NewRequest("POST", "/webhooks", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.CreateWebhook(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestCreateWebhookHandler_ReturnsSecret(t *testing.T) {
+	// Given: 鍵を省略したリクエスト
+	// When:  CreateWebhook を呼び出す
+	// Then:  生成された鍵がレスポンスに含まれる
+	handler := NewWebhookHandler(&mockCreateWebhookUsecase{}, nil, nil, nil, nil, nil)
+
+	req, _ := http.NewRequest("POST", "/webhooks", strings.NewReader(`{"url":"http://example.com/hook"}`))
+	w := httptest.NewRecorder()
+
+	handler.CreateWebhook(w, req)
+
+	var webhook domain.Webhook
+	if err := json.NewDecoder(w.Body).Decode(&webhook); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if webhook.Secret != "generated" {
+		t.Errorf("Expected secret 'generated', got '%s'", webhook.Secret)
+	}
+}
+
+func TestListWebhooksHandler(t *testing.T) {
+	// Given: 鍵を持つ Webhook を返す usecase
+	// When:  ListWebhooks を呼び出す
+	// Then:  鍵を除いた一覧が返る
+	handler := NewWebhookHandler(nil, &mockListWebhookUsecase{}, nil, nil, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/webhooks", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListWebhooks(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "s3cret") {
+		t.Errorf("Expected secret to be redacted, got %s", w.Body.String())
+	}
+}
+
+func TestFindWebhookHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "found", wantCode: http.StatusOK},
+		{name: "not found", err: domain.ErrWebhookNotFound, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewWebhookHandler(nil, nil, &mockFindWebhookUsecase{err: tt.err}, nil, nil, nil)
+
+			req, _ := http.NewRequest("GET", "/webhooks/1", nil)
+			req.SetPathValue("id", "1")
+			w := httptest.NewRecorder()
+
+			handler.FindWebhook(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if strings.Contains(w.Body.String(), "s3cret") {
+				t.Errorf("Expected secret to be redacted, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdateWebhookHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		wantCode int
+	}{
+		{name: "updated", body: `{"url":"http://example.com/new"}`, wantCode: http.StatusOK},
+		{name: "invalid json", body: `{`, wantCode: http.StatusBadRequest},
+		{name: "invalid webhook", body: `{"events":["reset"]}`, err: domain.ErrInvalidWebhook, wantCode: http.StatusBadRequest},
+		{name: "not found", body: `{"url":"http://example.com/new"}`, err: domain.ErrWebhookNotFound, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewWebhookHandler(nil, nil, nil, &mockUpdateWebhookUsecase{err: tt.err}, nil, nil)
+
+			req, _ := http.NewRequest("PUT", "/webhooks/1", strings.NewReader(tt.body))
+			req.SetPathValue("id", "1")
+			w := httptest.NewRecorder()
+
+			handler.UpdateWebhook(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteWebhookHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "deleted", wantCode: http.StatusOK},
+		{name: "not found", err: domain.ErrWebhookNotFound, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewWebhookHandler(nil, nil, nil, nil, &mockDeleteWebhookUsecase{err: tt.err}, nil)
+
+			req, _ := http.NewRequest("DELETE", "/webhooks/1", nil)
+			req.SetPathValue("id", "1")
+			w := httptest.NewRecorder()
+
+			handler.DeleteWebhook(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestListDeliveriesHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		err       error
+		wantCode  int
+		wantLimit int
+	}{
+		{name: "default limit", wantCode: http.StatusOK},
+		{name: "with limit", query: "?limit=10", wantCode: http.StatusOK, wantLimit: 10},
+		{name: "invalid limit", query: "?limit=abc", wantCode: http.StatusBadRequest},
+		{name: "zero limit", query: "?limit=0", wantCode: http.StatusBadRequest},
+		{name: "not found", err: domain.ErrWebhookNotFound, wantCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockList := &mockListWebhookDeliveryUsecase{err: tt.err}
+			handler := NewWebhookHandler(nil, nil, nil, nil, nil, mockList)
+
+			req, _ := http.NewRequest("GET", "/webhooks/1/deliveries"+tt.query, nil)
+			req.SetPathValue("id", "1")
+			w := httptest.NewRecorder()
+
+			handler.ListDeliveries(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if mockList.limit != tt.wantLimit {
+				t.Errorf("Expected limit %d, got %d", tt.wantLimit, mockList.limit)
+			}
+		})
+	}
+}
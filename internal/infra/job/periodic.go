@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunPeriodic は ctx がキャンセルされるまで interval ごとに fn を実行する。
+// fn のエラーはログに出力し、次回の実行を続ける。
+func RunPeriodic(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				log.Printf("job %s failed: %v", name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunPeriodic(t *testing.T) {
+	// Given: 毎回エラーを返すジョブ
+	// When:  RunPeriodic を短い間隔で実行し、数回後にキャンセルする
+	// Then:  エラーがあっても実行が継続され、キャンセルで終了する
+	ctx, cancel := context.WithCancel(context.Background())
+	var count atomic.Int32
+	done := make(chan struct{})
+
+	go func() {
+		RunPeriodic(ctx, "test", time.Millisecond, func(ctx context.Context) error {
+			if count.Add(1) == 3 {
+				cancel()
+			}
+			return errors.New("job failure")
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected RunPeriodic to return after cancel")
+	}
+	if count.Load() < 3 {
+		t.Errorf("Expected at least 3 runs, got %d", count.Load())
+	}
+}
+
+func TestRunPeriodic_CancelledBeforeTick(t *testing.T) {
+	// Given: キャンセル済みのコンテキスト
+	// When:  RunPeriodic を呼び出す
+	// Then:  fn を実行せずに終了する
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+
+	RunPeriodic(ctx, "test", time.Hour, func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	if called {
+		t.Error("Expected fn not to be called")
+	}
+}
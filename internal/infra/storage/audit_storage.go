@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// FileAuditLog は監査ログを JSON Lines ファイルに追記していく。既存の行は書き換えない。
+// サーバーと todo -file のように別のプロセスが同じファイルに追記しても連鎖が途切れないよう、ファイルロックを取って書き込む。
+type FileAuditLog struct {
+	filePath string
+	mu       sync.Mutex
+	head     *AuditHead
+	size     int64 // head を読み込んだときのファイルの大きさ。変わっていれば他のプロセスが追記している
+}
+
+// AuditHead は監査ログの最後のエントリ。Hash を別の場所に控えておけば、末尾のエントリの削除も検出できる。
+type AuditHead struct {
+	Seq  int64  `json:"seq"`
+	Hash string `json:"hash"`
+}
+
+// AuditVerifyError は検証に失敗したエントリの位置と理由。domain.ErrAuditTampered として扱える。
+type AuditVerifyError struct {
+	Line int
+	Msg  string
+}
+
+func (e *AuditVerifyError) Error() string {
+	return fmt.Sprintf("%s: line %d: %s", domain.ErrAuditTampered, e.Line, e.Msg)
+}
+
+func (e *AuditVerifyError) Unwrap() error {
+	return domain.ErrAuditTampered
+}
+
+func NewFileAuditLog(filePath string) *FileAuditLog {
+	return &FileAuditLog{filePath: filePath}
+}
+
+// scan はファイルの先頭から順に fn を呼び出す。line は 1 から数えた行番号。
+func (l *FileAuditLog) scan(fn func(line int, data []byte) error) error {
+	f, err := os.Open(l.filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if err == io.EOF && len(data) == 0 {
+			return nil
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if err := fn(line, bytes.TrimSuffix(data, []byte("\n"))); err != nil {
+			return err
+		}
+	}
+}
+
+// lockFile は別のプロセスとの間で監査ログを排他する。
+func (l *FileAuditLog) lockFile(exclusive bool) (func(), error) {
+	return lockFile(l.filePath+".lock", exclusive)
+}
+
+// fileSize は監査ログの大きさを返す。ファイルがなければ 0。
+func (l *FileAuditLog) fileSize() (int64, error) {
+	info, err := os.Stat(l.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func (l *FileAuditLog) Append(ctx context.Context, entries []*domain.AuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := l.lockFile(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	size, err := l.fileSize()
+	if err != nil {
+		return err
+	}
+	if l.head == nil || l.size != size {
+		head := &AuditHead{}
+		err := l.scan(func(line int, data []byte) error {
+			return json.Unmarshal(data, head)
+		})
+		if err != nil {
+			return err
+		}
+		l.head = head
+		l.size = size
+	}
+
+	var buf bytes.Buffer
+	head := *l.head
+	for _, e := range entries {
+		e.Seq = head.Seq + 1
+		e.PrevHash = head.Hash
+		hash, err := e.ComputeHash()
+		if err != nil {
+			return err
+		}
+		e.Hash = hash
+
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+		head = AuditHead{Seq: e.Seq, Hash: e.Hash}
+	}
+
+	f, err := os.OpenFile(l.filePath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		l.head = nil
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		l.head = nil
+		return err
+	}
+	l.head = &head
+	l.size += int64(buf.Len())
+	return nil
+}
+
+func (l *FileAuditLog) Query(ctx context.Context, q domain.AuditQuery) ([]*domain.AuditEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := l.lockFile(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	limit := q.Limit
+	if limit == 0 {
+		limit = domain.DefaultAuditLimit
+	}
+
+	var entries []*domain.AuditEntry
+	err = l.scan(func(line int, data []byte) error {
+		var e domain.AuditEntry
+		if err := json.Unmarshal(data, &e); err != nil {
+			return err
+		}
+		if q.Match(&e) {
+			entries = append(entries, &e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	result := make([]*domain.AuditEntry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		result = append(result, entries[i])
+	}
+	return result, nil
+}
+
+// Verify は先頭からハッシュの連鎖をたどり、書き換え・削除・並べ替えられたエントリがないかを確かめる。
+// 問題がなければ最後のエントリを返す。空のログでは Seq が 0 の AuditHead を返す。
+func (l *FileAuditLog) Verify(ctx context.Context) (AuditHead, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	unlock, err := l.lockFile(false)
+	if err != nil {
+		return AuditHead{}, err
+	}
+	defer unlock()
+
+	var head AuditHead
+	err = l.scan(func(line int, data []byte) error {
+		var e domain.AuditEntry
+		if err := json.Unmarshal(data, &e); err != nil {
+			return &AuditVerifyError{Line: line, Msg: "malformed entry"}
+		}
+		if e.Seq != head.Seq+1 {
+			return &AuditVerifyError{Line: line, Msg: fmt.Sprintf("expected seq %d, got %d", head.Seq+1, e.Seq)}
+		}
+		if e.PrevHash != head.Hash {
+			return &AuditVerifyError{Line: line, Msg: "previous hash does not match"}
+		}
+		hash, err := e.ComputeHash()
+		if err != nil {
+			return err
+		}
+		if hash != e.Hash {
+			return &AuditVerifyError{Line: line, Msg: "entry has been altered"}
+		}
+		head = AuditHead{Seq: e.Seq, Hash: e.Hash}
+		return nil
+	})
+	if err != nil {
+		return AuditHead{}, err
+	}
+	return head, nil
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// newTempAuditLog は actions の順に1件ずつ追記した監査ログを返す。
+func newTempAuditLog(t *testing.T, actions ...string) (*FileAuditLog, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log := NewFileAuditLog(path)
+	for i, action := range actions {
+		entry := &domain.AuditEntry{
+			At:         time.Date(2026, 1, 1+i, 0, 0, 0, 0, time.UTC),
+			Actor:      "alice",
+			Resource:   domain.AuditResourceTodo,
+			ResourceID: "1",
+			Action:     action,
+			Diff:       []domain.FieldChange{{Field: "title", To: json.RawMessage(`"Buy milk"`)}},
+		}
+		if err := log.Append(context.Background(), []*domain.AuditEntry{entry}); err != nil {
+			t.Fatalf("Failed to append: %v", err)
+		}
+	}
+	return log, path
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func writeLines(t *testing.T, path string, lines []string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}
+
+func TestFileAuditLog_AppendChainsHashes(t *testing.T) {
+	// Given: 3件追記した監査ログ
+	log, path := newTempAuditLog(t, "todo_created", "title_changed", "todo_deleted")
+
+	// When:  別のインスタンスから続けて追記する
+	reopened := NewFileAuditLog(path)
+	entry := &domain.AuditEntry{Actor: "bob", Action: "todo_created"}
+	if err := reopened.Append(context.Background(), []*domain.AuditEntry{entry}); err != nil {
+		t.Fatalf("Failed to append: %v", err)
+	}
+
+	// Then:  連番とハッシュが前のエントリに続き、全体の検証に成功する
+	if entry.Seq != 4 || entry.PrevHash == "" || entry.Hash == "" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	head, err := log.Verify(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if head.Seq != 4 || head.Hash != entry.Hash {
+		t.Errorf("Unexpected head: %+v", head)
+	}
+}
+
+func TestFileAuditLog_AppendInterleaved(t *testing.T) {
+	// Given: 同じファイルに追記したことのある2つのインスタンス（サーバーと todo -file にあたる）
+	server, path := newTempAuditLog(t, "todo_created")
+	local := NewFileAuditLog(path)
+	if err := local.Append(context.Background(), []*domain.AuditEntry{{Action: "title_changed"}}); err != nil {
+		t.Fatalf("Failed to append: %v", err)
+	}
+
+	// When:  それぞれが覚えている末尾より後に、もう一方が追記している状態で追記する
+	entry := &domain.AuditEntry{Action: "todo_deleted"}
+	if err := server.Append(context.Background(), []*domain.AuditEntry{entry}); err != nil {
+		t.Fatalf("Failed to append: %v", err)
+	}
+
+	// Then:  ファイルの末尾に続く連番で記録され、連鎖が途切れない
+	if entry.Seq != 3 {
+		t.Errorf("Expected seq 3, got %d", entry.Seq)
+	}
+	if _, err := local.Verify(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestFileAuditLog_Verify_Tampered(t *testing.T) {
+	tests := []struct {
+		name     string
+		tamper   func(lines []string) []string
+		wantLine int
+	}{
+		{
+			name: "altered",
+			tamper: func(lines []string) []string {
+				lines[1] = strings.Replace(lines[1], `"actor":"alice"`, `"actor":"mallory"`, 1)
+				return lines
+			},
+			wantLine: 2,
+		},
+		{
+			name: "removed",
+			tamper: func(lines []string) []string {
+				return append(lines[:1], lines[2:]...)
+			},
+			wantLine: 2,
+		},
+		{
+			name: "reordered",
+			tamper: func(lines []string) []string {
+				lines[1], lines[2] = lines[2], lines[1]
+				return lines
+			},
+			wantLine: 2,
+		},
+		{
+			name: "malformed",
+			tamper: func(lines []string) []string {
+				lines[2] = "{"
+				return lines
+			},
+			wantLine: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: 改ざんした監査ログ
+			log, path := newTempAuditLog(t, "todo_created", "title_changed", "todo_deleted")
+			writeLines(t, path, tt.tamper(readLines(t, path)))
+
+			// When:  検証する
+			_, err := log.Verify(context.Background())
+
+			// Then:  改ざんされた行が報告される
+			var verifyErr *AuditVerifyError
+			if !errors.As(err, &verifyErr) || !errors.Is(err, domain.ErrAuditTampered) {
+				t.Fatalf("Expected AuditVerifyError, got %v", err)
+			}
+			if verifyErr.Line != tt.wantLine {
+				t.Errorf("Expected line %d, got %d", tt.wantLine, verifyErr.Line)
+			}
+		})
+	}
+}
+
+func TestFileAuditLog_Verify_Empty(t *testing.T) {
+	log := NewFileAuditLog(filepath.Join(t.TempDir(), "audit.jsonl"))
+
+	head, err := log.Verify(context.Background())
+
+	if err != nil || head.Seq != 0 {
+		t.Errorf("Expected empty head, got %+v, %v", head, err)
+	}
+}
+
+func TestFileAuditLog_Query(t *testing.T) {
+	// Given: 3件のエントリ
+	log, _ := newTempAuditLog(t, "todo_created", "title_changed", "title_changed")
+	ctx := context.Background()
+
+	// When:  操作名で絞り込む
+	entries, err := log.Query(ctx, domain.AuditQuery{Action: "title_changed"})
+
+	// Then:  一致するエントリが新しい順に返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 2 || entries[0].Seq != 3 || entries[1].Seq != 2 {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+
+	// When:  期間と件数を指定する
+	since := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	entries, err = log.Query(ctx, domain.AuditQuery{Since: &since, Limit: 1})
+
+	// Then:  期間内の最新の1件だけが返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 1 || entries[0].Seq != 3 {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+}
@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// FileChangeIndex は Todo ごとの最後の変更番号を JSON ファイルに保存する。
+// 削除した Todo の変更番号も残し、同期するクライアントに削除を伝えられるようにする。
+// Bump は Todo のリポジトリのトランザクション内で呼ばれるため、他のプロセスとの排他はそのロックに任せる。
+type FileChangeIndex struct {
+	filePath string
+	mu       sync.Mutex
+}
+
+type changeIndexFile struct {
+	Epoch    string               `json:"epoch"`
+	Seq      int64                `json:"seq"`
+	Versions []domain.SyncVersion `json:"versions"`
+}
+
+func NewFileChangeIndex(filePath string) *FileChangeIndex {
+	return &FileChangeIndex{
+		filePath: filePath,
+	}
+}
+
+// load はファイルがなければ新しい Epoch で作成する。Epoch を先に保存しておかないと、最初の変更までに発行したトークンが無効になる。
+func (r *FileChangeIndex) load() (*changeIndexFile, error) {
+	data, err := os.ReadFile(r.filePath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	var f changeIndexFile
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, err
+		}
+	}
+	if f.Epoch != "" {
+		return &f, nil
+	}
+
+	epoch := make([]byte, 8)
+	if _, err := rand.Read(epoch); err != nil {
+		return nil, err
+	}
+	f.Epoch = hex.EncodeToString(epoch)
+	if err := r.save(&f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (r *FileChangeIndex) save(f *changeIndexFile) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(r.filePath, data)
+}
+
+func (r *FileChangeIndex) Head(ctx context.Context) (domain.SyncHead, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.load()
+	if err != nil {
+		return domain.SyncHead{}, err
+	}
+	return domain.SyncHead{Epoch: f.Epoch, Seq: f.Seq}, nil
+}
+
+func (r *FileChangeIndex) Bump(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	index := make(map[int]int, len(f.Versions))
+	for i, v := range f.Versions {
+		index[v.ID] = i
+	}
+	for _, id := range ids {
+		f.Seq++
+		if i, ok := index[id]; ok {
+			f.Versions[i].Seq = f.Seq
+			continue
+		}
+		index[id] = len(f.Versions)
+		f.Versions = append(f.Versions, domain.SyncVersion{ID: id, Seq: f.Seq})
+	}
+
+	return r.save(f)
+}
+
+func (r *FileChangeIndex) Version(ctx context.Context, id int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.load()
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range f.Versions {
+		if v.ID == id {
+			return v.Seq, nil
+		}
+	}
+	return 0, nil
+}
+
+func (r *FileChangeIndex) Since(ctx context.Context, seq int64) ([]domain.SyncVersion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []domain.SyncVersion{}
+	for _, v := range f.Versions {
+		if v.Seq > seq {
+			versions = append(versions, v)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Seq < versions[j].Seq })
+	return versions, nil
+}
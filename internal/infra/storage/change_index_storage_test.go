@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestFileChangeIndex_Bump(t *testing.T) {
+	// Given: 空の変更番号のファイル
+	path := filepath.Join(t.TempDir(), "sync.json")
+	index := NewFileChangeIndex(path)
+	ctx := context.Background()
+	before, err := index.Head(ctx)
+	if err != nil {
+		t.Fatalf("Failed to read head: %v", err)
+	}
+
+	// When:  2回に分けて変更番号を振る
+	if err := index.Bump(ctx, []int{1, 2}); err != nil {
+		t.Fatalf("Failed to bump: %v", err)
+	}
+	if err := index.Bump(ctx, []int{1}); err != nil {
+		t.Fatalf("Failed to bump: %v", err)
+	}
+
+	// Then:  Todo ごとに最後の番号が残り、別のインスタンスからも同じ Epoch で読める
+	reopened := NewFileChangeIndex(path)
+	head, _ := reopened.Head(ctx)
+	if head.Epoch == "" || head.Epoch != before.Epoch || head.Seq != 3 {
+		t.Errorf("Unexpected head: %+v (before %+v)", head, before)
+	}
+	if seq, _ := reopened.Version(ctx, 1); seq != 3 {
+		t.Errorf("Expected seq 3 for todo 1, got %d", seq)
+	}
+	if seq, _ := reopened.Version(ctx, 9); seq != 0 {
+		t.Errorf("Expected seq 0 for unknown todo, got %d", seq)
+	}
+	versions, _ := reopened.Since(ctx, 1)
+	want := []domain.SyncVersion{{ID: 2, Seq: 2}, {ID: 1, Seq: 3}}
+	if len(versions) != len(want) || versions[0] != want[0] || versions[1] != want[1] {
+		t.Errorf("Expected %+v, got %+v", want, versions)
+	}
+}
+
+func TestFileChangeIndex_NewEpoch(t *testing.T) {
+	// Given: 別々のファイル
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	// When:  それぞれの現在値を読む
+	a, _ := NewFileChangeIndex(filepath.Join(dir, "a.json")).Head(ctx)
+	b, _ := NewFileChangeIndex(filepath.Join(dir, "b.json")).Head(ctx)
+
+	// Then:  Epoch が異なり、互いのトークンを区別できる
+	if a.Epoch == b.Epoch {
+		t.Errorf("Expected different epochs, got %s", a.Epoch)
+	}
+}
@@ -0,0 +1,14 @@
+package storage
+
+import "errors"
+
+// ErrFileInUse は、Todo のファイルを他のプロセス（起動中のサーバーなど）が使っていることを表す。
+var ErrFileInUse = errors.New("file is in use by another process")
+
+// ClaimFile は filePath の Todo を1つのプロセスだけが扱うよう、"<filePath>.owner" にロックをかけて解除する関数を返す。
+// サーバーは検索インデックスをメモリに持ち、変更を監査ログ・SSE・Webhook にも流すため、
+// 動いている間に他のプロセスがファイルを直接書き換えると、それらに変更が伝わらない。
+// 他のプロセスが取得済みなら ErrFileInUse を返す。
+func ClaimFile(filePath string) (func(), error) {
+	return tryLockFile(filePath + ".owner")
+}
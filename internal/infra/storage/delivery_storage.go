@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// MaxFinishedDeliveries は Webhook ごとに残す送信済み・失敗した通知の件数。これより古いものは保存時に捨てる。
+const MaxFinishedDeliveries = 100
+
+// FileDeliveryRepository は Webhook の通知を送信待ちのキューと送信履歴を兼ねて JSON ファイルに保存する。
+// 保存してから送信するため、送信前にプロセスが止まっても再起動後に送信を続けられる。
+type FileDeliveryRepository struct {
+	filePath string
+	mu       sync.RWMutex
+}
+
+type deliveryFile struct {
+	MaxID      int                       `json:"max_id"`
+	Deliveries []*domain.WebhookDelivery `json:"deliveries"`
+}
+
+func NewFileDeliveryRepository(filePath string) *FileDeliveryRepository {
+	return &FileDeliveryRepository{
+		filePath: filePath,
+	}
+}
+
+func (r *FileDeliveryRepository) load() (*deliveryFile, error) {
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &deliveryFile{}, nil
+		}
+		return nil, err
+	}
+
+	var f deliveryFile
+	if len(data) == 0 {
+		return &f, nil
+	}
+
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (r *FileDeliveryRepository) save(f *deliveryFile) error {
+	f.Deliveries = pruneDeliveries(f.Deliveries)
+	// MarshalIndent は Payload まで整形して送信する本文が変わってしまうため使わない
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+// pruneDeliveries は Webhook ごとに新しい MaxFinishedDeliveries 件を超える終了済みの通知を取り除く。
+func pruneDeliveries(deliveries []*domain.WebhookDelivery) []*domain.WebhookDelivery {
+	finished := make(map[string]int)
+	kept := make([]*domain.WebhookDelivery, 0, len(deliveries))
+	for i := len(deliveries) - 1; i >= 0; i-- {
+		d := deliveries[i]
+		if d.Status != domain.DeliveryPending {
+			finished[d.WebhookID]++
+			if finished[d.WebhookID] > MaxFinishedDeliveries {
+				continue
+			}
+		}
+		kept = append(kept, d)
+	}
+	for i, j := 0, len(kept)-1; i < j; i, j = i+1, j-1 {
+		kept[i], kept[j] = kept[j], kept[i]
+	}
+	return kept
+}
+
+func (r *FileDeliveryRepository) Create(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	for _, d := range deliveries {
+		f.MaxID++
+		d.ID = strconv.Itoa(f.MaxID)
+		f.Deliveries = append(f.Deliveries, d)
+	}
+
+	return r.save(f)
+}
+
+func (r *FileDeliveryRepository) ListDue(ctx context.Context, now time.Time) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	f, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var due []*domain.WebhookDelivery
+	for _, d := range f.Deliveries {
+		if d.Status == domain.DeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	return due, nil
+}
+
+func (r *FileDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	for i, d := range f.Deliveries {
+		if d.ID == delivery.ID {
+			f.Deliveries[i] = delivery
+			return r.save(f)
+		}
+	}
+
+	return domain.ErrDeliveryNotFound
+}
+
+func (r *FileDeliveryRepository) ListByWebhook(ctx context.Context, webhookID string, limit int) ([]*domain.WebhookDelivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	f, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := []*domain.WebhookDelivery{}
+	for i := len(f.Deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if f.Deliveries[i].WebhookID == webhookID {
+			deliveries = append(deliveries, f.Deliveries[i])
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *FileDeliveryRepository) DeleteByWebhook(ctx context.Context, webhookID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	f, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	kept := f.Deliveries[:0]
+	for _, d := range f.Deliveries {
+		if d.WebhookID != webhookID {
+			kept = append(kept, d)
+		}
+	}
+	f.Deliveries = kept
+
+	return r.save(f)
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestFileDeliveryRepository(t *testing.T) {
+	// Given: 2つの Webhook への通知を保存したファイル
+	path := filepath.Join(t.TempDir(), "webhook_deliveries.json")
+	repo := NewFileDeliveryRepository(path)
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	deliveries := []*domain.WebhookDelivery{
+		{WebhookID: "1", Status: domain.DeliveryPending, NextAttemptAt: now, Payload: []byte(`{"type":"created"}`)},
+		{WebhookID: "2", Status: domain.DeliveryPending, NextAttemptAt: now},
+		{WebhookID: "1", Status: domain.DeliveryPending, NextAttemptAt: now.Add(time.Minute)},
+	}
+	if err := repo.Create(ctx, deliveries); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+
+	// When:  送信時刻の来た通知を取得する
+	// Then:  別のインスタンスからも古い順に取得できる
+	due, err := NewFileDeliveryRepository(path).ListDue(ctx, now)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(due) != 2 || due[0].ID != "1" || due[1].ID != "2" || string(due[0].Payload) != `{"type":"created"}` {
+		t.Errorf("Unexpected due deliveries: %+v", due)
+	}
+
+	// When:  1件を送信済みにする
+	due[0].Status = domain.DeliverySucceeded
+	if err := repo.Update(ctx, due[0]); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+
+	// Then:  送信待ちから外れ、送信履歴には新しい順に残る
+	due, _ = repo.ListDue(ctx, now)
+	if len(due) != 1 || due[0].ID != "2" {
+		t.Errorf("Unexpected due deliveries: %+v", due)
+	}
+	history, err := repo.ListByWebhook(ctx, "1", 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(history) != 2 || history[0].ID != "3" || history[1].Status != domain.DeliverySucceeded {
+		t.Errorf("Unexpected history: %+v", history)
+	}
+
+	// When:  Webhook 1 の通知を削除する
+	if err := repo.DeleteByWebhook(ctx, "1"); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+
+	// Then:  Webhook 2 の通知だけが残り、ID は再利用されない
+	if history, _ := repo.ListByWebhook(ctx, "1", 10); len(history) != 0 {
+		t.Errorf("Expected no deliveries, got %+v", history)
+	}
+	next := &domain.WebhookDelivery{WebhookID: "2", Status: domain.DeliveryPending}
+	if err := repo.Create(ctx, []*domain.WebhookDelivery{next}); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	if next.ID != "4" {
+		t.Errorf("Expected ID '4', got '%s'", next.ID)
+	}
+	if err := repo.Update(ctx, &domain.WebhookDelivery{ID: "1"}); !errors.Is(err, domain.ErrDeliveryNotFound) {
+		t.Errorf("Expected ErrDeliveryNotFound, got %v", err)
+	}
+}
+
+func TestFileDeliveryRepository_Prune(t *testing.T) {
+	// Given: 上限を超える送信済みの通知と、送信待ちの通知
+	repo := NewFileDeliveryRepository(filepath.Join(t.TempDir(), "webhook_deliveries.json"))
+	ctx := context.Background()
+	deliveries := []*domain.WebhookDelivery{{WebhookID: "1", Status: domain.DeliveryPending}}
+	for i := 0; i < MaxFinishedDeliveries+5; i++ {
+		deliveries = append(deliveries, &domain.WebhookDelivery{WebhookID: "1", Status: domain.DeliverySucceeded})
+	}
+
+	// When:  保存する
+	if err := repo.Create(ctx, deliveries); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+
+	// Then:  古い送信済みの通知だけが捨てられる
+	history, _ := repo.ListByWebhook(ctx, "1", 1000)
+	if len(history) != MaxFinishedDeliveries+1 {
+		t.Errorf("Expected %d deliveries, got %d", MaxFinishedDeliveries+1, len(history))
+	}
+	if history[len(history)-1].Status != domain.DeliveryPending {
+		t.Errorf("Expected pending delivery to be kept, got %+v", history[len(history)-1])
+	}
+}
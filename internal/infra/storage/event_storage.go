@@ -0,0 +1,442 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+const DefaultSnapshotInterval = 1000
+
+// EventRepository は Todo への変更を domain.Event として JSON Lines ファイルに追記していくリポジトリ。
+// 現在の状態はイベントを先頭から適用し直して求める。起動を速くするため、
+// snapshotInterval 件ごとに状態をスナップショットファイルに保存し、そこから続きを適用する。
+// イベントファイルは追記のみで書き換えないため、すべての変更の履歴が残る。
+// 同じファイルを使う他のプロセスとは、FileRepository と同じく "<filePath>.lock" のファイルロックで排他する。
+type EventRepository struct {
+	filePath         string
+	snapshotPath     string
+	snapshotInterval int64
+	mu               sync.Mutex
+	state            *eventState
+	offset           int64
+}
+
+// eventState はイベントを適用して得られる状態。
+// MaxID は削除済みも含めたこれまでの最大 ID で、ID を再利用して履歴が混ざらないようにする。
+type eventState struct {
+	Seq   int64          `json:"seq"`
+	MaxID int            `json:"max_id"`
+	Todos []*domain.Todo `json:"todos"`
+}
+
+type snapshot struct {
+	eventState
+	Offset int64 `json:"offset"`
+}
+
+func NewEventRepository(filePath string) *EventRepository {
+	return &EventRepository{
+		filePath:         filePath,
+		snapshotPath:     filePath + ".snapshot",
+		snapshotInterval: DefaultSnapshotInterval,
+	}
+}
+
+func (s *eventState) clone() *eventState {
+	c := &eventState{Seq: s.Seq, MaxID: s.MaxID, Todos: make([]*domain.Todo, len(s.Todos))}
+	for i, t := range s.Todos {
+		c.Todos[i] = t.Clone()
+	}
+	return c
+}
+
+func (s *eventState) find(id int) int {
+	for i, t := range s.Todos {
+		if t.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *eventState) apply(e *domain.Event) {
+	s.Seq = e.Seq
+	if e.TodoID > s.MaxID {
+		s.MaxID = e.TodoID
+	}
+	if e.Type == domain.EventIDsReserved {
+		if e.MaxID > s.MaxID {
+			s.MaxID = e.MaxID
+		}
+		return
+	}
+
+	i := s.find(e.TodoID)
+	var current *domain.Todo
+	if i >= 0 {
+		current = s.Todos[i]
+	}
+	next := e.Apply(current)
+	switch {
+	case next == nil && i >= 0:
+		s.Todos = append(s.Todos[:i], s.Todos[i+1:]...)
+	case next != nil && i >= 0:
+		s.Todos[i] = next
+	case next != nil:
+		s.Todos = append(s.Todos, next)
+	}
+}
+
+// sync はまだ読み込んでいないイベントを適用する。他のプロセスが追記したイベントもここで取り込む。
+func (r *EventRepository) sync() error {
+	if r.state == nil {
+		r.state, r.offset = r.loadSnapshot()
+	}
+
+	f, err := os.Open(r.filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(r.offset, io.SeekStart); err != nil {
+		return err
+	}
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// 改行で終わっていない行は書き込み途中とみなし、次回に読み直す
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var e domain.Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			return err
+		}
+		r.state.apply(&e)
+		r.offset += int64(len(line))
+	}
+}
+
+// loadSnapshot はスナップショットを読み込む。使えない場合は空の状態から全イベントを適用し直す。
+func (r *EventRepository) loadSnapshot() (*eventState, int64) {
+	empty := &eventState{Todos: []*domain.Todo{}}
+
+	data, err := os.ReadFile(r.snapshotPath)
+	if err != nil {
+		return empty, 0
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return empty, 0
+	}
+	if info, err := os.Stat(r.filePath); err != nil || info.Size() < snap.Offset {
+		return empty, 0
+	}
+	if snap.Todos == nil {
+		snap.Todos = []*domain.Todo{}
+	}
+	return &snap.eventState, snap.Offset
+}
+
+// saveSnapshot は一時ファイルに書いてから置き換え、読み込み途中のスナップショットが壊れないようにする。
+func (r *EventRepository) saveSnapshot() error {
+	data, err := json.Marshal(snapshot{eventState: *r.state, Offset: r.offset})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(r.snapshotPath, data)
+}
+
+// lock はプロセス内の排他に加えて、他のプロセスの追記とも排他する。
+// sync から append までを同じロックの中で行えば、他のプロセスと同じ Seq・ID を使ったり、書き込み途中の行を切り詰めたりしない。
+func (r *EventRepository) lock() (func(), error) {
+	return r.lockFile(true)
+}
+
+// rlock は読み込み用。sync で r.state を更新するため、プロセス内では rlock でも排他する。
+func (r *EventRepository) rlock() (func(), error) {
+	return r.lockFile(false)
+}
+
+func (r *EventRepository) lockFile(exclusive bool) (func(), error) {
+	r.mu.Lock()
+	unlock, err := lockFile(r.filePath+".lock", exclusive)
+	if err != nil {
+		r.mu.Unlock()
+		return nil, err
+	}
+	return func() {
+		unlock()
+		r.mu.Unlock()
+	}, nil
+}
+
+func (r *EventRepository) append(events []*domain.Event) error {
+	var buf bytes.Buffer
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	f, err := os.OpenFile(r.filePath, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// 前回の書き込みが途中で終わっていた場合は、その断片を取り除いてから書き込む
+	if err := f.Truncate(r.offset); err != nil {
+		return err
+	}
+	if _, err := f.WriteAt(buf.Bytes(), r.offset); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// WithinTx は fn 内の変更で発生したイベントをまとめて追記する。fn がエラーを返した場合は何も追記しない。
+func (r *EventRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	return r.withinTx(func(tx *eventTx) error {
+		return fn(tx)
+	})
+}
+
+// withinTx は WithinTx と同じだが、fn に書き込み中の状態も渡す。
+func (r *EventRepository) withinTx(fn func(tx *eventTx) error) error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := r.sync(); err != nil {
+		return err
+	}
+
+	tx := &eventTx{state: r.state.clone()}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if len(tx.events) == 0 {
+		return nil
+	}
+
+	if err := r.append(tx.events); err != nil {
+		return err
+	}
+	before := r.state.Seq
+	if err := r.sync(); err != nil {
+		return err
+	}
+	if r.state.Seq/r.snapshotInterval > before/r.snapshotInterval {
+		// スナップショットは起動を速くするためだけのものなので、失敗してもイベントの保存は成功として扱う
+		_ = r.saveSnapshot()
+	}
+	return nil
+}
+
+func (r *EventRepository) Create(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Create(ctx, todo)
+	})
+}
+
+func (r *EventRepository) Insert(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Insert(ctx, todo)
+	})
+}
+
+func (r *EventRepository) Update(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Update(ctx, todo)
+	})
+}
+
+func (r *EventRepository) Delete(ctx context.Context, id int) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Delete(ctx, id)
+	})
+}
+
+func (r *EventRepository) List(ctx context.Context) ([]*domain.Todo, error) {
+	unlock, err := r.rlock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := r.sync(); err != nil {
+		return nil, err
+	}
+	return r.state.clone().Todos, nil
+}
+
+func (r *EventRepository) FindByID(ctx context.Context, id int) (*domain.Todo, error) {
+	unlock, err := r.rlock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := r.sync(); err != nil {
+		return nil, err
+	}
+	if i := r.state.find(id); i >= 0 {
+		return r.state.Todos[i].Clone(), nil
+	}
+	return nil, domain.ErrTodoNotFound
+}
+
+// History はイベントファイル全体から todoID のイベントを集める。
+func (r *EventRepository) History(ctx context.Context, todoID int) ([]*domain.Event, error) {
+	unlock, err := r.rlock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	f, err := os.Open(r.filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, domain.ErrTodoNotFound
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []*domain.Event
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var e domain.Event
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, err
+		}
+		if e.TodoID == todoID {
+			events = append(events, &e)
+		}
+	}
+
+	if len(events) == 0 {
+		return nil, domain.ErrTodoNotFound
+	}
+	return events, nil
+}
+
+// eventTx は WithinTx の間だけ使うリポジトリ。変更をイベントに変換し、自身の状態に適用しながら記録する。
+type eventTx struct {
+	state  *eventState
+	events []*domain.Event
+}
+
+func (tx *eventTx) record(ctx context.Context, e *domain.Event) {
+	e.Seq = tx.state.Seq + 1
+	e.Actor = domain.ActorFromContext(ctx)
+	tx.state.apply(e)
+	tx.events = append(tx.events, e)
+}
+
+func (tx *eventTx) Create(ctx context.Context, todo *domain.Todo) error {
+	todo.ID = tx.state.MaxID + 1
+	tx.record(ctx, &domain.Event{Type: domain.EventTodoCreated, TodoID: todo.ID, At: time.Now(), Todo: todo.Clone()})
+	return nil
+}
+
+func (tx *eventTx) Insert(ctx context.Context, todo *domain.Todo) error {
+	if tx.state.find(todo.ID) >= 0 {
+		return domain.ErrTodoConflict
+	}
+	tx.record(ctx, &domain.Event{Type: domain.EventTodoInserted, TodoID: todo.ID, At: time.Now(), Todo: todo.Clone()})
+	return nil
+}
+
+func (tx *eventTx) List(ctx context.Context) ([]*domain.Todo, error) {
+	return tx.state.clone().Todos, nil
+}
+
+func (tx *eventTx) FindByID(ctx context.Context, id int) (*domain.Todo, error) {
+	if i := tx.state.find(id); i >= 0 {
+		return tx.state.Todos[i].Clone(), nil
+	}
+	return nil, domain.ErrTodoNotFound
+}
+
+func (tx *eventTx) Update(ctx context.Context, todo *domain.Todo) error {
+	i := tx.state.find(todo.ID)
+	if i < 0 {
+		return domain.ErrTodoNotFound
+	}
+	for _, e := range domain.ChangeEvents(tx.state.Todos[i], todo, time.Now()) {
+		tx.record(ctx, e)
+	}
+	return nil
+}
+
+func (tx *eventTx) Delete(ctx context.Context, id int) error {
+	if tx.state.find(id) < 0 {
+		return domain.ErrTodoNotFound
+	}
+	tx.record(ctx, &domain.Event{Type: domain.EventTodoDeleted, TodoID: id, At: time.Now()})
+	return nil
+}
+
+func (tx *eventTx) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	return fn(tx)
+}
+
+// Import はイベントが1件もない場合に限り、todos を同じ ID のま取り込む。
+// 既存の JSON ファイルからの移行に使い、取り込んだ件数を返す。maxID には移行元でこれまでに割り当てた最大の ID を渡す。
+// アーカイブやゴミ箱から消えた Todo の ID を再利用しないよう、取り込んだ Todo になくてもその ID までを使用済みにする。
+// 空かどうかの確認は書き込みのロックの中で行うため、複数のプロセスが同時に呼び出しても1回だけ取り込まれる。
+func (r *EventRepository) Import(ctx context.Context, todos []*domain.Todo, maxID int) (int, error) {
+	imported := 0
+	err := r.withinTx(func(tx *eventTx) error {
+		if tx.state.Seq != 0 {
+			return nil
+		}
+		for _, todo := range todos {
+			if err := tx.Insert(ctx, todo); err != nil {
+				return err
+			}
+		}
+		if maxID > tx.state.MaxID {
+			tx.record(ctx, &domain.Event{Type: domain.EventIDsReserved, At: time.Now(), MaxID: maxID})
+		}
+		imported = len(todos)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return imported, nil
+}
@@ -0,0 +1,361 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func newTempEventRepo(t *testing.T) *EventRepository {
+	t.Helper()
+	return NewEventRepository(filepath.Join(t.TempDir(), "todos.events.jsonl"))
+}
+
+func TestEventRepository_CRUD(t *testing.T) {
+	// Given: 空のイベントリポジトリ
+	repo := newTempEventRepo(t)
+	ctx := context.Background()
+
+	// When:  作成・更新・削除を行う
+	milk := &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}
+	book := &domain.Todo{Title: "Read book", Status: domain.StatusTodo}
+	if err := repo.Create(ctx, milk); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	if err := repo.Create(ctx, book); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	milk.Title = "Buy oat milk"
+	if err := repo.Update(ctx, milk); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	if err := repo.Delete(ctx, book.ID); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+
+	// Then:  現在の状態が反映されている
+	todos, err := repo.List(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(todos) != 1 || todos[0].ID != 1 || todos[0].Title != "Buy oat milk" {
+		t.Errorf("Unexpected todos: %+v", todos)
+	}
+	if _, err := repo.FindByID(ctx, book.ID); !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Errorf("Expected ErrTodoNotFound, got %v", err)
+	}
+}
+
+func TestEventRepository_Replay(t *testing.T) {
+	// Given: イベントを書き込んだファイル
+	path := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	repo := NewEventRepository(path)
+	ctx := context.Background()
+	now := time.Now()
+	todo := &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo, CreatedAt: now, UpdatedAt: now}
+	repo.Create(ctx, todo)
+	domain.DefaultStateMachine().Transition(todo, domain.StatusDone, now.Add(time.Minute))
+	todo.UpdatedAt = now.Add(time.Minute)
+	repo.Update(ctx, todo)
+	deletedAt := now.Add(time.Hour)
+	todo.DeletedAt = &deletedAt
+	repo.Update(ctx, todo)
+
+	// When:  別のリポジトリで同じファイルを読み込む
+	replayed, err := NewEventRepository(path).FindByID(ctx, todo.ID)
+
+	// Then:  同じ状態が復元される
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if replayed.Status != domain.StatusDone || replayed.CompletedAt == nil || !replayed.IsDeleted() {
+		t.Errorf("Unexpected replayed todo: %+v", replayed)
+	}
+	if len(replayed.StatusHistory) != 1 || !replayed.UpdatedAt.Equal(todo.UpdatedAt) {
+		t.Errorf("Expected history and updated_at to be restored, got %+v", replayed)
+	}
+}
+
+func TestEventRepository_IDsAreNotReused(t *testing.T) {
+	// Given: 最大 ID の Todo を削除したリポジトリ
+	repo := newTempEventRepo(t)
+	ctx := context.Background()
+	repo.Create(ctx, &domain.Todo{Title: "Buy milk"})
+	repo.Create(ctx, &domain.Todo{Title: "Read book"})
+	repo.Delete(ctx, 2)
+
+	// When:  新しく作成する
+	todo := &domain.Todo{Title: "Walk dog"}
+	repo.Create(ctx, todo)
+
+	// Then:  削除された ID は使われず、履歴が混ざらない
+	if todo.ID != 3 {
+		t.Errorf("Expected ID 3, got %d", todo.ID)
+	}
+}
+
+func TestEventRepository_History(t *testing.T) {
+	// Given: 利用者を設定して作成・更新した Todo
+	repo := newTempEventRepo(t)
+	ctx := domain.WithActor(context.Background(), "alice")
+	todo := &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}
+	repo.Create(ctx, todo)
+	repo.Create(ctx, &domain.Todo{Title: "Read book", Status: domain.StatusTodo})
+	todo.Title = "Buy oat milk"
+	repo.Update(domain.WithActor(context.Background(), "bob"), todo)
+
+	// When:  History を呼び出す
+	events, err := repo.History(context.Background(), todo.ID)
+
+	// Then:  その Todo のイベントだけが古い順に利用者付きで返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Type != domain.EventTodoCreated || events[0].Actor != "alice" || events[0].Seq != 1 {
+		t.Errorf("Unexpected first event: %+v", events[0])
+	}
+	if events[1].Type != domain.EventTitleChanged || events[1].Actor != "bob" || events[1].Title != "Buy oat milk" || events[1].Seq != 3 {
+		t.Errorf("Unexpected second event: %+v", events[1])
+	}
+}
+
+func TestEventRepository_History_NotFound(t *testing.T) {
+	repo := newTempEventRepo(t)
+
+	_, err := repo.History(context.Background(), 1)
+
+	if !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Errorf("Expected ErrTodoNotFound, got %v", err)
+	}
+}
+
+func TestEventRepository_WithinTx_Rollback(t *testing.T) {
+	// Given: 1件の Todo があるリポジトリ
+	repo := newTempEventRepo(t)
+	ctx := context.Background()
+	repo.Create(ctx, &domain.Todo{Title: "Buy milk"})
+	txErr := errors.New("abort")
+
+	// When:  WithinTx 内で作成した後にエラーを返す
+	err := repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		if err := tx.Create(ctx, &domain.Todo{Title: "Read book"}); err != nil {
+			return err
+		}
+		return txErr
+	})
+
+	// Then:  イベントは追記されない
+	if !errors.Is(err, txErr) {
+		t.Fatalf("Expected txErr, got %v", err)
+	}
+	data, _ := os.ReadFile(repo.filePath)
+	if lines := strings.Count(string(data), "\n"); lines != 1 {
+		t.Errorf("Expected 1 event line, got %d", lines)
+	}
+}
+
+func TestEventRepository_Snapshot(t *testing.T) {
+	// Given: 2件ごとにスナップショットを取るリポジトリ
+	path := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	repo := NewEventRepository(path)
+	repo.snapshotInterval = 2
+	ctx := context.Background()
+	for _, title := range []string{"Buy milk", "Read book", "Walk dog"} {
+		repo.Create(ctx, &domain.Todo{Title: title})
+	}
+
+	// When:  スナップショットから読み込む
+	reloaded := NewEventRepository(path)
+	state, offset := reloaded.loadSnapshot()
+	todos, err := reloaded.List(ctx)
+
+	// Then:  スナップショットの続きのイベントも適用される
+	if state.Seq != 2 || offset == 0 {
+		t.Errorf("Expected snapshot at seq 2, got seq %d offset %d", state.Seq, offset)
+	}
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(todos) != 3 || todos[2].Title != "Walk dog" {
+		t.Errorf("Unexpected todos: %+v", todos)
+	}
+}
+
+func TestEventRepository_SeesOtherWriters(t *testing.T) {
+	// Given: 同じファイルを使う2つのリポジトリ
+	path := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	a := NewEventRepository(path)
+	b := NewEventRepository(path)
+	ctx := context.Background()
+	a.List(ctx)
+	b.List(ctx)
+
+	// When:  一方で作成する
+	a.Create(ctx, &domain.Todo{Title: "Buy milk"})
+
+	// Then:  もう一方からも見える
+	todos, _ := b.List(ctx)
+	if len(todos) != 1 {
+		t.Errorf("Expected 1 todo, got %d", len(todos))
+	}
+}
+
+func TestEventRepository_WithinTx_BlocksOtherWriters(t *testing.T) {
+	// Given: 同じファイルを使う2つのリポジトリ（別々のプロセスに相当する）
+	path := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	a := NewEventRepository(path)
+	b := NewEventRepository(path)
+	ctx := context.Background()
+	started := make(chan struct{})
+	created := make(chan error)
+
+	// When:  一方の WithinTx の実行中に、もう一方から作成する
+	err := a.WithinTx(ctx, func(tx domain.IRepository) error {
+		go func() {
+			close(started)
+			created <- b.Create(ctx, &domain.Todo{Title: "Other writer"})
+		}()
+		<-started
+		select {
+		case err := <-created:
+			t.Fatalf("Expected Create to wait for the transaction, got %v", err)
+		case <-time.After(20 * time.Millisecond):
+		}
+		return tx.Create(ctx, &domain.Todo{Title: "In transaction"})
+	})
+
+	// Then:  作成はコミット後に行われ、どちらの変更も別々の ID で残る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := <-created; err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	todos, _ := NewEventRepository(path).List(ctx)
+	if len(todos) != 2 || todos[0].Title != "In transaction" || todos[1].ID != 2 {
+		t.Errorf("Expected both todos in commit order, got %+v", todos)
+	}
+}
+
+func TestEventRepository_PartialLine(t *testing.T) {
+	// Given: 末尾に書き込み途中の行が残ったファイル
+	path := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	repo := NewEventRepository(path)
+	ctx := context.Background()
+	repo.Create(ctx, &domain.Todo{Title: "Buy milk"})
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	f.WriteString(`{"seq":2,"type":"todo_cr`)
+	f.Close()
+
+	// When:  別のリポジトリで読み込み、新たに作成する
+	reloaded := NewEventRepository(path)
+	err := reloaded.Create(ctx, &domain.Todo{Title: "Read book"})
+
+	// Then:  途中の行は無視・除去され、以降も読み込める
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	todos, err := NewEventRepository(path).List(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(todos) != 2 || todos[1].Title != "Read book" {
+		t.Errorf("Unexpected todos: %+v", todos)
+	}
+}
+
+func TestEventRepository_Import(t *testing.T) {
+	// Given: 空のイベントリポジトリ
+	repo := newTempEventRepo(t)
+	ctx := context.Background()
+	todos := []*domain.Todo{{ID: 3, Title: "Buy milk"}, {ID: 7, Title: "Read book"}}
+
+	// When:  Import を2回呼び出す
+	first, err := repo.Import(ctx, todos, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, _ := repo.Import(ctx, todos, 0)
+
+	// Then:  1回目だけ ID を保ったまま取り込まれる
+	if first != 2 || second != 0 {
+		t.Errorf("Expected 2 then 0 imported, got %d and %d", first, second)
+	}
+	todo := &domain.Todo{Title: "Walk dog"}
+	repo.Create(ctx, todo)
+	if todo.ID != 8 {
+		t.Errorf("Expected next ID 8, got %d", todo.ID)
+	}
+}
+
+func TestEventRepository_Import_ReservesMaxID(t *testing.T) {
+	// Given: ID 9 までを割り当て済みの移行元から、残っている Todo を取り込んだリポジトリ
+	path := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	ctx := context.Background()
+	if _, err := NewEventRepository(path).Import(ctx, []*domain.Todo{{ID: 3, Title: "Buy milk"}}, 9); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// When:  イベントファイルを開き直して作成する
+	repo := NewEventRepository(path)
+	todo := &domain.Todo{Title: "Walk dog"}
+	if err := repo.Create(ctx, todo); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then:  アーカイブなどで移行元から消えた ID も再利用されない
+	if todo.ID != 10 {
+		t.Errorf("Expected next ID 10, got %d", todo.ID)
+	}
+	todos, _ := repo.List(ctx)
+	if len(todos) != 2 {
+		t.Errorf("Expected 2 todos, got %d", len(todos))
+	}
+}
+
+func TestEventRepository_Import_Concurrent(t *testing.T) {
+	// Given: 同じイベントファイルを使う複数のリポジトリ（別々のプロセスにあたる）
+	path := filepath.Join(t.TempDir(), "todos.events.jsonl")
+	ctx := context.Background()
+	todos := []*domain.Todo{{ID: 1, Title: "Buy milk"}, {ID: 2, Title: "Read book"}}
+
+	// When:  同時に取り込む
+	const n = 8
+	var wg sync.WaitGroup
+	imported := make([]int, n)
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			imported[i], errs[i] = NewEventRepository(path).Import(ctx, todos, 2)
+		}(i)
+	}
+	wg.Wait()
+
+	// Then:  どれも失敗せず、1回分だけ取り込まれる
+	total := 0
+	for i := 0; i < n; i++ {
+		if errs[i] != nil {
+			t.Errorf("Expected no error, got %v", errs[i])
+		}
+		total += imported[i]
+	}
+	if total != len(todos) {
+		t.Errorf("Expected %d imported in total, got %d", len(todos), total)
+	}
+	list, _ := NewEventRepository(path).List(ctx)
+	if len(list) != len(todos) {
+		t.Errorf("Expected %d todos, got %d", len(todos), len(list))
+	}
+}
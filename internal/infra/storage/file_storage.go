@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/k98a73/go-todo/internal/domain"
@@ -48,25 +50,111 @@ func (r *FileRepository) save(todos []*domain.Todo) error {
 		return err
 	}
 
-	return os.WriteFile(r.filePath, data, 0644)
+	return writeFileAtomic(r.filePath, data)
 }
 
-func (r *FileRepository) Create(ctx context.Context, todo *domain.Todo) error {
+// loadMaxID は削除・アーカイブした Todo も含めて、これまでに割り当てた最大の ID を返す。
+// ファイルから消えた ID を再利用すると、アーカイブから戻すときなどに ID が衝突するため、todos とは別に記録する。
+func (r *FileRepository) loadMaxID(todos []*domain.Todo) (int, error) {
+	maxID := 0
+	data, err := os.ReadFile(r.maxIDPath())
+	switch {
+	case err == nil:
+		if maxID, err = strconv.Atoi(strings.TrimSpace(string(data))); err != nil {
+			return 0, err
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return 0, err
+	}
+
+	for _, t := range todos {
+		if t.ID > maxID {
+			maxID = t.ID
+		}
+	}
+	return maxID, nil
+}
+
+func (r *FileRepository) saveMaxID(maxID int) error {
+	return writeFileAtomic(r.maxIDPath(), []byte(strconv.Itoa(maxID)+"\n"))
+}
+
+func (r *FileRepository) maxIDPath() string {
+	return r.filePath + ".maxid"
+}
+
+// lock はプロセス内の排他に加えて、同じファイルを使う他のプロセス（サーバーとローカルモードの todo コマンドなど）とも排他する。
+func (r *FileRepository) lock() (func(), error) {
 	r.mu.Lock()
-	defer r.mu.Unlock()
+	unlock, err := lockFile(r.filePath+".lock", true)
+	if err != nil {
+		r.mu.Unlock()
+		return nil, err
+	}
+	return func() {
+		unlock()
+		r.mu.Unlock()
+	}, nil
+}
+
+func (r *FileRepository) rlock() (func(), error) {
+	r.mu.RLock()
+	unlock, err := lockFile(r.filePath+".lock", false)
+	if err != nil {
+		r.mu.RUnlock()
+		return nil, err
+	}
+	return func() {
+		unlock()
+		r.mu.RUnlock()
+	}, nil
+}
+
+func (r *FileRepository) Create(ctx context.Context, todo *domain.Todo) error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	todos, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	maxID, err := r.loadMaxID(todos)
+	if err != nil {
+		return err
+	}
+	todo.ID = maxID + 1
+	// 先に記録しておけば、todos の保存に失敗しても ID は再利用されない
+	if err := r.saveMaxID(todo.ID); err != nil {
+		return err
+	}
+
+	todos = append(todos, todo)
+
+	return r.save(todos)
+}
+
+// Insert は Create と異なり、todo.ID をそのまま使って保存する。
+func (r *FileRepository) Insert(ctx context.Context, todo *domain.Todo) error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	todos, err := r.load()
 	if err != nil {
 		return err
 	}
 
-	maxID := 0
 	for _, t := range todos {
-		if t.ID > maxID {
-			maxID = t.ID
+		if t.ID == todo.ID {
+			return domain.ErrTodoConflict
 		}
 	}
-	todo.ID = maxID + 1
 
 	todos = append(todos, todo)
 
@@ -74,15 +162,41 @@ func (r *FileRepository) Create(ctx context.Context, todo *domain.Todo) error {
 }
 
 func (r *FileRepository) List(ctx context.Context) ([]*domain.Todo, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	unlock, err := r.rlock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
 	return r.load()
 }
 
+// Export は Todo と、削除・アーカイブした Todo も含めてこれまでに割り当てた最大の ID を返す。
+// EventRepository.Import への移行で、ID を再利用しないために使う。
+func (r *FileRepository) Export(ctx context.Context) ([]*domain.Todo, int, error) {
+	unlock, err := r.rlock()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer unlock()
+
+	todos, err := r.load()
+	if err != nil {
+		return nil, 0, err
+	}
+	maxID, err := r.loadMaxID(todos)
+	if err != nil {
+		return nil, 0, err
+	}
+	return todos, maxID, nil
+}
+
 func (r *FileRepository) FindByID(ctx context.Context, id int) (*domain.Todo, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	unlock, err := r.rlock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
 	todos, err := r.load()
 	if err != nil {
@@ -95,12 +209,15 @@ func (r *FileRepository) FindByID(ctx context.Context, id int) (*domain.Todo, er
 		}
 	}
 
-	return nil, errors.New("todo not found")
+	return nil, domain.ErrTodoNotFound
 }
 
 func (r *FileRepository) Update(ctx context.Context, todo *domain.Todo) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	todos, err := r.load()
 	if err != nil {
@@ -114,12 +231,15 @@ func (r *FileRepository) Update(ctx context.Context, todo *domain.Todo) error {
 		}
 	}
 
-	return errors.New("todo not found")
+	return domain.ErrTodoNotFound
 }
 
 func (r *FileRepository) Delete(ctx context.Context, id int) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
 
 	todos, err := r.load()
 	if err != nil {
@@ -133,5 +253,5 @@ func (r *FileRepository) Delete(ctx context.Context, id int) error {
 		}
 	}
 
-	return errors.New("todo not found")
+	return domain.ErrTodoNotFound
 }
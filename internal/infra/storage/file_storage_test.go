@@ -2,10 +2,15 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/usecase"
 )
 
 func newTempRepo(t *testing.T, content string) (*FileRepository, func()) {
@@ -20,7 +25,11 @@ func newTempRepo(t *testing.T, content string) (*FileRepository, func()) {
 		}
 	}
 	tmpfile.Close()
-	return NewFileRepository(tmpfile.Name()), func() { os.Remove(tmpfile.Name()) }
+	return NewFileRepository(tmpfile.Name()), func() {
+		os.Remove(tmpfile.Name())
+		os.Remove(tmpfile.Name() + ".lock")
+		os.Remove(tmpfile.Name() + ".maxid")
+	}
 }
 
 func TestFileRepository_Create(t *testing.T) {
@@ -95,7 +104,7 @@ func TestFileRepository_List_FileNotExist(t *testing.T) {
 	// Given: 存在しないファイルパスのリポジトリ
 	// When:  List を呼び出す
 	// Then:  エラーなし・空スライスが返る
-	repo := NewFileRepository("/tmp/nonexistent_todo_file_12345.json")
+	repo := NewFileRepository(filepath.Join(t.TempDir(), "nonexistent.json"))
 
 	todos, err := repo.List(context.Background())
 
@@ -199,7 +208,7 @@ func TestFileRepository_Update(t *testing.T) {
 	repo, cleanup := newTempRepo(t, `[{"id":1,"title":"Buy milk","completed":false}]`)
 	defer cleanup()
 
-	updated := &domain.Todo{ID: 1, Title: "Buy milk and eggs", Completed: true}
+	updated := &domain.Todo{ID: 1, Title: "Buy milk and eggs", Status: domain.StatusDone}
 	err := repo.Update(context.Background(), updated)
 
 	if err != nil {
@@ -213,7 +222,7 @@ func TestFileRepository_Update(t *testing.T) {
 	if todo.Title != "Buy milk and eggs" {
 		t.Errorf("Expected updated title, got '%s'", todo.Title)
 	}
-	if !todo.Completed {
+	if !todo.IsCompleted() {
 		t.Error("Expected completed to be true")
 	}
 }
@@ -301,3 +310,221 @@ func TestFileRepository_Delete_LoadError(t *testing.T) {
 		t.Error("Expected error for invalid JSON, got nil")
 	}
 }
+
+func TestFileRepository_List_MigratesLegacyCompleted(t *testing.T) {
+	// Given: status を持たない旧形式のファイル
+	// When:  List を呼び出し、1件を Update で保存し直す
+	// Then:  completed から status が復元され、保存後は status が書き込まれる
+	repo, cleanup := newTempRepo(t, `[{"id":1,"title":"Buy milk","completed":true,"updated_at":"2026-01-17T15:30:00Z"},{"id":2,"title":"Read book","completed":false}]`)
+	defer cleanup()
+
+	todos, err := repo.List(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if todos[0].Status != domain.StatusDone {
+		t.Errorf("Expected status done, got %q", todos[0].Status)
+	}
+	if todos[0].CompletedAt == nil {
+		t.Error("Expected CompletedAt to be restored from updated_at")
+	}
+	if todos[1].Status != domain.StatusTodo {
+		t.Errorf("Expected status todo, got %q", todos[1].Status)
+	}
+
+	if err := repo.Update(context.Background(), todos[1]); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	data, err := os.ReadFile(repo.filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"status": "done"`) {
+		t.Errorf("Expected migrated status to be saved, got %s", data)
+	}
+}
+
+func TestFileRepository_Insert(t *testing.T) {
+	// Given: ID 1 の Todo が入ったリポジトリ
+	// When:  ID 7 を指定して Insert を呼び出す
+	// Then:  ID が採番されず 7 のまま保存される
+	repo, cleanup := newTempRepo(t, `[{"id":1,"title":"Buy milk","status":"todo"}]`)
+	defer cleanup()
+
+	err := repo.Insert(context.Background(), &domain.Todo{ID: 7, Title: "Archived", Status: domain.StatusDone})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	todo, err := repo.FindByID(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("FindByID failed: %v", err)
+	}
+	if todo.Title != "Archived" {
+		t.Errorf("Expected title 'Archived', got '%s'", todo.Title)
+	}
+}
+
+func TestFileRepository_Insert_Conflict(t *testing.T) {
+	// Given: ID 1 の Todo が入ったリポジトリ
+	// When:  同じ ID 1 で Insert を呼び出す
+	// Then:  ErrTodoConflict が返り、既存の Todo は変わらない
+	repo, cleanup := newTempRepo(t, `[{"id":1,"title":"Buy milk","status":"todo"}]`)
+	defer cleanup()
+
+	err := repo.Insert(context.Background(), &domain.Todo{ID: 1, Title: "Duplicate"})
+
+	if !errors.Is(err, domain.ErrTodoConflict) {
+		t.Errorf("Expected ErrTodoConflict, got %v", err)
+	}
+	todo, _ := repo.FindByID(context.Background(), 1)
+	if todo.Title != "Buy milk" {
+		t.Errorf("Expected existing todo to be kept, got '%s'", todo.Title)
+	}
+}
+
+func TestFileRepository_Insert_LoadError(t *testing.T) {
+	repo, cleanup := newTempRepo(t, "bad json")
+	defer cleanup()
+
+	err := repo.Insert(context.Background(), &domain.Todo{ID: 1, Title: "Test"})
+
+	if err == nil {
+		t.Error("Expected error for invalid JSON, got nil")
+	}
+}
+
+func TestFileRepository_ConcurrentInstances(t *testing.T) {
+	// Given: 同じファイルを指す2つのリポジトリ（サーバーとローカルモードのコマンドに相当する）
+	path := filepath.Join(t.TempDir(), "todos.json")
+	repos := []*FileRepository{NewFileRepository(path), NewFileRepository(path)}
+
+	// When:  両方から同時に作成する
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		for _, repo := range repos {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := repo.Create(context.Background(), &domain.Todo{Title: "Buy milk"}); err != nil {
+					t.Errorf("Failed to create: %v", err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	// Then:  ファイルのロックで排他され、すべての Todo が別々の ID で保存される
+	todos, err := repos[0].List(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ids := make(map[int]bool)
+	for _, todo := range todos {
+		ids[todo.ID] = true
+	}
+	if len(todos) != 40 || len(ids) != 40 {
+		t.Errorf("Expected 40 todos with distinct IDs, got %d todos and %d IDs", len(todos), len(ids))
+	}
+}
+
+func TestFileRepository_Create_DoesNotReuseRemovedID(t *testing.T) {
+	tests := []struct {
+		name   string
+		create func(repo *FileRepository, todo *domain.Todo) error
+	}{
+		{name: "Create", create: func(repo *FileRepository, todo *domain.Todo) error {
+			return repo.Create(context.Background(), todo)
+		}},
+		{name: "WithinTx", create: func(repo *FileRepository, todo *domain.Todo) error {
+			return repo.WithinTx(context.Background(), func(tx domain.IRepository) error {
+				return tx.Create(context.Background(), todo)
+			})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: 最大の ID の Todo を削除したリポジトリ
+			repo := NewFileRepository(filepath.Join(t.TempDir(), "todos.json"))
+			for range 2 {
+				if err := tt.create(repo, &domain.Todo{Title: "Buy milk"}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			repo.Delete(context.Background(), 2)
+
+			// When:  作成する
+			todo := &domain.Todo{Title: "New todo"}
+			if err := tt.create(repo, todo); err != nil {
+				t.Fatal(err)
+			}
+
+			// Then:  削除した ID は使わない
+			if todo.ID != 3 {
+				t.Errorf("Expected ID 3, got %d", todo.ID)
+			}
+		})
+	}
+}
+
+func TestFileRepository_ArchiveCreateUnarchive(t *testing.T) {
+	// Given: 最大の ID の Todo をアーカイブした後に、新しい Todo を作成する
+	ctx := context.Background()
+	dir := t.TempDir()
+	repo := NewFileRepository(filepath.Join(dir, "todos.json"))
+	archive := NewFileRepository(filepath.Join(dir, "archive.json"))
+	machine := domain.DefaultStateMachine()
+
+	done, err := usecase.NewCreateTodoUsecase(repo, machine).Execute(ctx, "Buy milk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := usecase.NewChangeStatusTodoUsecase(repo, machine).Execute(ctx, done.ID, domain.StatusDone); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := usecase.NewArchiveTodoUsecase(repo, archive).Execute(ctx, done.ID); err != nil {
+		t.Fatal(err)
+	}
+	created, err := usecase.NewCreateTodoUsecase(repo, machine).Execute(ctx, "Read book")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// When:  アーカイブから戻す
+	_, err = usecase.NewUnarchiveTodoUsecase(repo, archive).Execute(ctx, done.ID)
+
+	// Then:  ID が衝突せずに戻せる
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if created.ID == done.ID {
+		t.Errorf("Expected a new ID, got archived ID %d again", created.ID)
+	}
+	todos, _ := repo.List(ctx)
+	if len(todos) != 2 {
+		t.Errorf("Expected 2 todos, got %d", len(todos))
+	}
+}
+
+func TestFileRepository_Export(t *testing.T) {
+	// Given: 最大の ID の Todo を削除したリポジトリ
+	repo := NewFileRepository(filepath.Join(t.TempDir(), "todos.json"))
+	for range 3 {
+		if err := repo.Create(context.Background(), &domain.Todo{Title: "Buy milk"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo.Delete(context.Background(), 3)
+
+	// When:  書き出す
+	todos, maxID, err := repo.Export(context.Background())
+
+	// Then:  残っている Todo と、削除した分も含めた最大の ID が返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(todos) != 2 || maxID != 3 {
+		t.Errorf("Expected 2 todos and max ID 3, got %d and %d", len(todos), maxID)
+	}
+}
@@ -0,0 +1,48 @@
+//go:build !unix
+
+package storage
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+const (
+	lockTimeout = 10 * time.Second
+	// staleLockAge より古いロックファイルは、異常終了したプロセスが残したものとみなして取り除く。
+	staleLockAge = time.Minute
+)
+
+var errLockTimeout = errors.New("timed out waiting for file lock")
+
+// lockFile は flock のない環境向けに、path のファイルを排他的に作成できたことをロックとみなす。
+// 共有ロックは区別せず排他ロックとして扱う。
+func lockFile(path string, exclusive bool) (func(), error) {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, errLockTimeout
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// tryLockFile は flock のない環境では使えない。作成したファイルでは、Ctrl+C などで終了したプロセスのロックと
+// 動いているプロセスのロックを区別できないため、確認せずに成功として扱う。
+func tryLockFile(path string) (func(), error) {
+	return func() {}, nil
+}
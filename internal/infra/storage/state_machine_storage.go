@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// stateMachineFile は状態遷移の設定ファイルの形式。
+//
+//	{"initial": "todo", "transitions": {"todo": ["review"], "review": ["done", "todo"], "done": ["todo"]}, "terminal": ["done"]}
+//
+// terminal には作業を終えたとみなすステータスを並べる。フィルタの status:closed はこれらに、status:open は残りに一致する。
+type stateMachineFile struct {
+	Initial     domain.Status                     `json:"initial"`
+	Transitions map[domain.Status][]domain.Status `json:"transitions"`
+	Terminal    []domain.Status                   `json:"terminal"`
+}
+
+// LoadStateMachine は filePath の設定ファイルを読み、domain.NewStateMachine で検証したワークフローを返す。
+func LoadStateMachine(filePath string) (*domain.StateMachine, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var file stateMachineFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	machine, err := domain.NewStateMachine(file.Initial, file.Transitions, file.Terminal)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return machine, nil
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func writeStateMachineFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "workflow.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	return path
+}
+
+func TestLoadStateMachine(t *testing.T) {
+	// Given: review を含むワークフローの設定ファイル
+	path := writeStateMachineFile(t, `{"initial": "todo", "transitions": {"todo": ["review"], "review": ["done", "todo"], "done": ["todo"]}, "terminal": ["done"]}`)
+
+	// When:  読み込む
+	machine, err := LoadStateMachine(path)
+
+	// Then:  定義した遷移だけが許可される
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if machine.Initial() != domain.StatusTodo {
+		t.Errorf("Expected initial %q, got %q", domain.StatusTodo, machine.Initial())
+	}
+	if !machine.CanTransition(domain.StatusTodo, domain.Status("review")) || machine.CanTransition(domain.StatusTodo, domain.StatusDone) {
+		t.Error("Expected only todo -> review to be allowed from todo")
+	}
+	if !machine.IsTerminal(domain.StatusDone) || machine.IsTerminal(domain.Status("review")) {
+		t.Error("Expected only done to be terminal")
+	}
+	if machine.IsValid(domain.StatusInProgress) {
+		t.Errorf("Expected %q to be undefined", domain.StatusInProgress)
+	}
+}
+
+func TestLoadStateMachine_Invalid(t *testing.T) {
+	// Given: 定義されていないステータスへ遷移する設定ファイル
+	path := writeStateMachineFile(t, `{"initial": "todo", "transitions": {"todo": ["done"]}}`)
+
+	// When:  読み込む
+	_, err := LoadStateMachine(path)
+
+	// Then:  NewStateMachine の検証で失敗する
+	if !errors.Is(err, domain.ErrInvalidStatus) {
+		t.Errorf("Expected ErrInvalidStatus, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// WithinTx はロックを保持したままファイルを1回だけ読み込み、fn 内の操作をメモリ上で行ってから1回だけ保存する。
+// fn がエラーを返した場合は何も保存しない（ロールバック）。
+func (r *FileRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	todos, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	maxID, err := r.loadMaxID(todos)
+	if err != nil {
+		return err
+	}
+
+	tx := &txRepository{todos: todos, maxID: maxID}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if !tx.dirty {
+		return nil
+	}
+	if tx.maxID > maxID {
+		if err := r.saveMaxID(tx.maxID); err != nil {
+			return err
+		}
+	}
+
+	return r.save(tx.todos)
+}
+
+// txRepository は WithinTx の間だけ使うメモリ上のリポジトリ。
+// FileRepository と同じく呼び出し側に保存済みデータとは別のコピーを返す。
+type txRepository struct {
+	todos []*domain.Todo
+	maxID int
+	dirty bool
+}
+
+func (tx *txRepository) Create(ctx context.Context, todo *domain.Todo) error {
+	tx.maxID++
+	todo.ID = tx.maxID
+
+	tx.todos = append(tx.todos, todo.Clone())
+	tx.dirty = true
+	return nil
+}
+
+func (tx *txRepository) Insert(ctx context.Context, todo *domain.Todo) error {
+	for _, t := range tx.todos {
+		if t.ID == todo.ID {
+			return domain.ErrTodoConflict
+		}
+	}
+	// 同じトランザクションの後の Create が、この ID を割り当てないようにする
+	tx.maxID = max(tx.maxID, todo.ID)
+
+	tx.todos = append(tx.todos, todo.Clone())
+	tx.dirty = true
+	return nil
+}
+
+func (tx *txRepository) List(ctx context.Context) ([]*domain.Todo, error) {
+	todoList := make([]*domain.Todo, len(tx.todos))
+	for i, t := range tx.todos {
+		todoList[i] = t.Clone()
+	}
+	return todoList, nil
+}
+
+func (tx *txRepository) FindByID(ctx context.Context, id int) (*domain.Todo, error) {
+	for _, t := range tx.todos {
+		if t.ID == id {
+			return t.Clone(), nil
+		}
+	}
+	return nil, domain.ErrTodoNotFound
+}
+
+func (tx *txRepository) Update(ctx context.Context, todo *domain.Todo) error {
+	for i, t := range tx.todos {
+		if t.ID == todo.ID {
+			tx.todos[i] = todo.Clone()
+			tx.dirty = true
+			return nil
+		}
+	}
+	return domain.ErrTodoNotFound
+}
+
+func (tx *txRepository) Delete(ctx context.Context, id int) error {
+	for i, t := range tx.todos {
+		if t.ID == id {
+			tx.todos = append(tx.todos[:i:i], tx.todos[i+1:]...)
+			tx.dirty = true
+			return nil
+		}
+	}
+	return domain.ErrTodoNotFound
+}
+
+// 入れ子の WithinTx は外側のトランザクションにそのまま参加する。
+func (tx *txRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	return fn(tx)
+}
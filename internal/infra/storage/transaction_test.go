@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestFileRepository_WithinTx_Commit(t *testing.T) {
+	// Given: 1件の Todo が入ったリポジトリ
+	// When:  WithinTx 内で作成・更新・削除を行う
+	// Then:  すべての変更がまとめて保存される
+	repo, cleanup := newTempRepo(t, `[{"id":1,"title":"Buy milk","status":"todo"},{"id":2,"title":"Read book","status":"todo"}]`)
+	defer cleanup()
+
+	err := repo.WithinTx(context.Background(), func(tx domain.IRepository) error {
+		if err := tx.Create(context.Background(), &domain.Todo{Title: "New todo", Status: domain.StatusTodo}); err != nil {
+			return err
+		}
+		todo, err := tx.FindByID(context.Background(), 1)
+		if err != nil {
+			return err
+		}
+		todo.Title = "Buy oat milk"
+		if err := tx.Update(context.Background(), todo); err != nil {
+			return err
+		}
+		return tx.Delete(context.Background(), 2)
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	todos, _ := repo.List(context.Background())
+	if len(todos) != 2 {
+		t.Fatalf("Expected 2 todos, got %d", len(todos))
+	}
+	if todos[0].Title != "Buy oat milk" || todos[1].ID != 3 {
+		t.Errorf("Unexpected todos after transaction: %+v, %+v", todos[0], todos[1])
+	}
+}
+
+func TestFileRepository_WithinTx_Rollback(t *testing.T) {
+	// Given: 1件の Todo が入ったリポジトリ
+	// When:  WithinTx 内で更新した後にエラーを返す
+	// Then:  ファイルは一切書き換えられない
+	content := `[{"id":1,"title":"Buy milk","status":"todo"}]`
+	repo, cleanup := newTempRepo(t, content)
+	defer cleanup()
+	txErr := errors.New("abort")
+
+	err := repo.WithinTx(context.Background(), func(tx domain.IRepository) error {
+		todo, _ := tx.FindByID(context.Background(), 1)
+		todo.Title = "Changed"
+		if err := tx.Update(context.Background(), todo); err != nil {
+			return err
+		}
+		return txErr
+	})
+
+	if !errors.Is(err, txErr) {
+		t.Fatalf("Expected transaction error, got %v", err)
+	}
+	data, _ := os.ReadFile(repo.filePath)
+	if string(data) != content {
+		t.Errorf("Expected file to be unchanged, got %s", data)
+	}
+}
+
+func TestFileRepository_WithinTx_ReturnsCopies(t *testing.T) {
+	// Given: WithinTx 内で FindByID した Todo
+	// When:  Update せずにフィールドを書き換える
+	// Then:  バッチ内の状態は変わらない
+	repo, cleanup := newTempRepo(t, `[{"id":1,"title":"Buy milk","status":"todo"}]`)
+	defer cleanup()
+
+	err := repo.WithinTx(context.Background(), func(tx domain.IRepository) error {
+		todo, _ := tx.FindByID(context.Background(), 1)
+		todo.Title = "Changed"
+		again, _ := tx.FindByID(context.Background(), 1)
+		if again.Title != "Buy milk" {
+			t.Errorf("Expected unchanged title, got %q", again.Title)
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestFileRepository_WithinTx_Errors(t *testing.T) {
+	// Given: 1件の Todo が入ったリポジトリ
+	// When:  WithinTx 内で存在しない ID や重複 ID を操作する
+	// Then:  FileRepository と同じエラーが返る
+	repo, cleanup := newTempRepo(t, `[{"id":1,"title":"Buy milk","status":"todo"}]`)
+	defer cleanup()
+
+	repo.WithinTx(context.Background(), func(tx domain.IRepository) error {
+		if _, err := tx.FindByID(context.Background(), 99); !errors.Is(err, domain.ErrTodoNotFound) {
+			t.Errorf("FindByID: expected ErrTodoNotFound, got %v", err)
+		}
+		if err := tx.Update(context.Background(), &domain.Todo{ID: 99}); !errors.Is(err, domain.ErrTodoNotFound) {
+			t.Errorf("Update: expected ErrTodoNotFound, got %v", err)
+		}
+		if err := tx.Delete(context.Background(), 99); !errors.Is(err, domain.ErrTodoNotFound) {
+			t.Errorf("Delete: expected ErrTodoNotFound, got %v", err)
+		}
+		if err := tx.Insert(context.Background(), &domain.Todo{ID: 1}); !errors.Is(err, domain.ErrTodoConflict) {
+			t.Errorf("Insert: expected ErrTodoConflict, got %v", err)
+		}
+		return nil
+	})
+}
+
+func TestFileRepository_WithinTx_LoadError(t *testing.T) {
+	repo, cleanup := newTempRepo(t, "bad json")
+	defer cleanup()
+	called := false
+
+	err := repo.WithinTx(context.Background(), func(tx domain.IRepository) error {
+		called = true
+		return nil
+	})
+
+	if err == nil {
+		t.Error("Expected error for invalid JSON, got nil")
+	}
+	if called {
+		t.Error("Expected fn not to be called")
+	}
+}
+
+func TestFileRepository_WithinTx_Nested(t *testing.T) {
+	// Given: WithinTx 内でさらに WithinTx を呼び出す
+	// When:  内側でエラーを返す
+	// Then:  外側ごとロールバックされ、何も保存されない
+	repo, cleanup := newTempRepo(t, `[]`)
+	defer cleanup()
+	innerErr := errors.New("inner failure")
+
+	err := repo.WithinTx(context.Background(), func(tx domain.IRepository) error {
+		if err := tx.Create(context.Background(), &domain.Todo{Title: "Outer"}); err != nil {
+			return err
+		}
+		return tx.WithinTx(context.Background(), func(inner domain.IRepository) error {
+			inner.Create(context.Background(), &domain.Todo{Title: "Inner"})
+			return innerErr
+		})
+	})
+
+	if !errors.Is(err, innerErr) {
+		t.Fatalf("Expected inner error, got %v", err)
+	}
+	todos, _ := repo.List(context.Background())
+	if len(todos) != 0 {
+		t.Errorf("Expected no todos to be saved, got %d", len(todos))
+	}
+}
+
+func TestFileRepository_WithinTx_BlocksConcurrentWrites(t *testing.T) {
+	// Given: WithinTx の実行中に別の goroutine から Create する
+	// When:  トランザクションがコミットされる
+	// Then:  Create はコミット後に実行され、どちらの変更も失われない
+	repo, cleanup := newTempRepo(t, `[]`)
+	defer cleanup()
+	started := make(chan struct{})
+	created := make(chan error)
+
+	err := repo.WithinTx(context.Background(), func(tx domain.IRepository) error {
+		go func() {
+			close(started)
+			created <- repo.Create(context.Background(), &domain.Todo{Title: "Concurrent"})
+		}()
+		<-started
+		select {
+		case err := <-created:
+			t.Errorf("Expected Create to wait for the transaction, got %v", err)
+		case <-time.After(20 * time.Millisecond):
+		}
+		return tx.Create(context.Background(), &domain.Todo{Title: "In transaction"})
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := <-created; err != nil {
+		t.Fatalf("Concurrent Create failed: %v", err)
+	}
+	todos, _ := repo.List(context.Background())
+	if len(todos) != 2 || todos[0].Title != "In transaction" || todos[1].ID != 2 {
+		t.Errorf("Expected both todos in commit order, got %+v", todos)
+	}
+}
@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// FileViewRepository は保存したビューを Todo とは別の JSON ファイルに保存する。
+type FileViewRepository struct {
+	filePath string
+	mu       sync.RWMutex
+}
+
+func NewFileViewRepository(filePath string) *FileViewRepository {
+	return &FileViewRepository{
+		filePath: filePath,
+	}
+}
+
+func (r *FileViewRepository) load() ([]*domain.View, error) {
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*domain.View{}, nil
+		}
+		return nil, err
+	}
+
+	var views []*domain.View
+	if len(data) == 0 {
+		return []*domain.View{}, nil
+	}
+
+	if err := json.Unmarshal(data, &views); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+func (r *FileViewRepository) save(views []*domain.View) error {
+	data, err := json.MarshalIndent(views, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+// loadMaxID は削除したビューも含めて、これまでに割り当てた最大の ID を返す。
+// 削除した ID を再利用すると、古い ID を覚えているクライアントが別のビューを参照してしまうため、views とは別に記録する。
+func (r *FileViewRepository) loadMaxID(views []*domain.View) (int, error) {
+	maxID := 0
+	data, err := os.ReadFile(r.maxIDPath())
+	switch {
+	case err == nil:
+		if maxID, err = strconv.Atoi(strings.TrimSpace(string(data))); err != nil {
+			return 0, err
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return 0, err
+	}
+
+	for _, v := range views {
+		if id, err := strconv.Atoi(v.ID); err == nil && id > maxID {
+			maxID = id
+		}
+	}
+	return maxID, nil
+}
+
+func (r *FileViewRepository) saveMaxID(maxID int) error {
+	return writeFileAtomic(r.maxIDPath(), []byte(strconv.Itoa(maxID)+"\n"))
+}
+
+func (r *FileViewRepository) maxIDPath() string {
+	return r.filePath + ".maxid"
+}
+
+func (r *FileViewRepository) Create(ctx context.Context, view *domain.View) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	views, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	maxID, err := r.loadMaxID(views)
+	if err != nil {
+		return err
+	}
+	view.ID = strconv.Itoa(maxID + 1)
+	// 先に記録しておけば、views の保存に失敗しても ID は再利用されない
+	if err := r.saveMaxID(maxID + 1); err != nil {
+		return err
+	}
+
+	views = append(views, view)
+
+	return r.save(views)
+}
+
+func (r *FileViewRepository) List(ctx context.Context) ([]*domain.View, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.load()
+}
+
+func (r *FileViewRepository) FindByID(ctx context.Context, id string) (*domain.View, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	views, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range views {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+
+	return nil, domain.ErrViewNotFound
+}
+
+func (r *FileViewRepository) Update(ctx context.Context, view *domain.View) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	views, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	for i, v := range views {
+		if v.ID == view.ID {
+			views[i] = view
+			return r.save(views)
+		}
+	}
+
+	return domain.ErrViewNotFound
+}
+
+func (r *FileViewRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	views, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	for i, v := range views {
+		if v.ID == id {
+			views = append(views[:i], views[i+1:]...)
+			return r.save(views)
+		}
+	}
+
+	return domain.ErrViewNotFound
+}
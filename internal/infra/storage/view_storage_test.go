@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func newTempViewRepo(t *testing.T, content string) (*FileViewRepository, func()) {
+	t.Helper()
+	tmpfile, err := os.CreateTemp("", "views*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != "" {
+		if _, err := tmpfile.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tmpfile.Close()
+	return NewFileViewRepository(tmpfile.Name()), func() {
+		os.Remove(tmpfile.Name())
+		os.Remove(tmpfile.Name() + ".maxid")
+	}
+}
+
+func TestFileViewRepository_Create(t *testing.T) {
+	// Given: ビューが1件保存されたファイル
+	// When:  Create を呼び出す
+	// Then:  続きの ID が割り当てられ、検索条件ごと保存される
+	repo, cleanup := newTempViewRepo(t, `[{"id":"1","name":"Backend","query":{"q":"backend"}}]`)
+	defer cleanup()
+
+	view := &domain.View{Name: "Open", Query: domain.ListQuery{Filter: "status:open", Sort: domain.SortPosition, Limit: 10}}
+	err := repo.Create(context.Background(), view)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if view.ID != "2" {
+		t.Errorf("Expected ID '2', got '%s'", view.ID)
+	}
+	found, err := repo.FindByID(context.Background(), "2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if found.Query != view.Query {
+		t.Errorf("Expected query %+v, got %+v", view.Query, found.Query)
+	}
+}
+
+func TestFileViewRepository_List_NoFile(t *testing.T) {
+	// Given: 存在しないファイル
+	// When:  List を呼び出す
+	// Then:  空のリストが返る
+	repo := NewFileViewRepository(t.TempDir() + "/views.json")
+
+	views, err := repo.List(context.Background())
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(views) != 0 {
+		t.Errorf("Expected 0 views, got %d", len(views))
+	}
+}
+
+func TestFileViewRepository_Update(t *testing.T) {
+	// Given: ビューが1件保存されたファイル
+	// When:  名前を変えて Update を呼び出す
+	// Then:  変更が保存される
+	repo, cleanup := newTempViewRepo(t, `[{"id":"1","name":"Backend","query":{"q":"backend"}}]`)
+	defer cleanup()
+
+	err := repo.Update(context.Background(), &domain.View{ID: "1", Name: "API", Query: domain.ListQuery{Filter: "api"}})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	found, _ := repo.FindByID(context.Background(), "1")
+	if found.Name != "API" || found.Query.Filter != "api" {
+		t.Errorf("Expected updated view, got %+v", found)
+	}
+}
+
+func TestFileViewRepository_Delete(t *testing.T) {
+	// Given: ビューが1件保存されたファイル
+	// When:  Delete を呼び出す
+	// Then:  ビューが削除される
+	repo, cleanup := newTempViewRepo(t, `[{"id":"1","name":"Backend","query":{"q":"backend"}}]`)
+	defer cleanup()
+
+	err := repo.Delete(context.Background(), "1")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := repo.FindByID(context.Background(), "1"); !errors.Is(err, domain.ErrViewNotFound) {
+		t.Errorf("Expected ErrViewNotFound, got %v", err)
+	}
+}
+
+func TestFileViewRepository_Create_DoesNotReuseDeletedID(t *testing.T) {
+	// Given: 最大の ID のビューを削除したリポジトリ
+	repo, cleanup := newTempViewRepo(t, "")
+	defer cleanup()
+	for range 2 {
+		if err := repo.Create(context.Background(), &domain.View{Name: "Open"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo.Delete(context.Background(), "2")
+
+	// When:  作成する
+	view := &domain.View{Name: "Backend"}
+	if err := repo.Create(context.Background(), view); err != nil {
+		t.Fatal(err)
+	}
+
+	// Then:  削除した ID は使わない
+	if view.ID != "3" {
+		t.Errorf("Expected ID '3', got '%s'", view.ID)
+	}
+}
+
+func TestFileViewRepository_NotFound(t *testing.T) {
+	repo, cleanup := newTempViewRepo(t, "[]")
+	defer cleanup()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "find", call: func() error { _, err := repo.FindByID(context.Background(), "1"); return err }},
+		{name: "update", call: func() error { return repo.Update(context.Background(), &domain.View{ID: "1", Name: "API"}) }},
+		{name: "delete", call: func() error { return repo.Delete(context.Background(), "1") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, domain.ErrViewNotFound) {
+				t.Errorf("Expected ErrViewNotFound, got %v", err)
+			}
+		})
+	}
+}
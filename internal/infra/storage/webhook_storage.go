@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// FileWebhookRepository は Webhook の登録を JSON ファイルに保存する。
+type FileWebhookRepository struct {
+	filePath string
+	mu       sync.RWMutex
+}
+
+func NewFileWebhookRepository(filePath string) *FileWebhookRepository {
+	return &FileWebhookRepository{
+		filePath: filePath,
+	}
+}
+
+func (r *FileWebhookRepository) load() ([]*domain.Webhook, error) {
+	data, err := os.ReadFile(r.filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []*domain.Webhook{}, nil
+		}
+		return nil, err
+	}
+
+	var webhooks []*domain.Webhook
+	if len(data) == 0 {
+		return []*domain.Webhook{}, nil
+	}
+
+	if err := json.Unmarshal(data, &webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r *FileWebhookRepository) save(webhooks []*domain.Webhook) error {
+	data, err := json.MarshalIndent(webhooks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.filePath, data, 0644)
+}
+
+func (r *FileWebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	maxID := 0
+	for _, w := range webhooks {
+		if id, err := strconv.Atoi(w.ID); err == nil && id > maxID {
+			maxID = id
+		}
+	}
+	webhook.ID = strconv.Itoa(maxID + 1)
+
+	webhooks = append(webhooks, webhook)
+
+	return r.save(webhooks)
+}
+
+func (r *FileWebhookRepository) List(ctx context.Context) ([]*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.load()
+}
+
+func (r *FileWebhookRepository) FindByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range webhooks {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+
+	return nil, domain.ErrWebhookNotFound
+}
+
+func (r *FileWebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	for i, w := range webhooks {
+		if w.ID == webhook.ID {
+			webhooks[i] = webhook
+			return r.save(webhooks)
+		}
+	}
+
+	return domain.ErrWebhookNotFound
+}
+
+func (r *FileWebhookRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhooks, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	for i, w := range webhooks {
+		if w.ID == id {
+			webhooks = append(webhooks[:i], webhooks[i+1:]...)
+			return r.save(webhooks)
+		}
+	}
+
+	return domain.ErrWebhookNotFound
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestFileWebhookRepository_CRUD(t *testing.T) {
+	// Given: 空のファイル
+	repo := NewFileWebhookRepository(filepath.Join(t.TempDir(), "webhooks.json"))
+	ctx := context.Background()
+
+	// When:  作成・更新・削除を行う
+	// Then:  鍵や種類を含めて保存され、削除後は見つからない
+	webhook := &domain.Webhook{URL: "https://example.com/a", Events: []domain.FeedEventType{domain.FeedTodoCreated}, Secret: "s3cret"}
+	if err := repo.Create(ctx, webhook); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	if webhook.ID != "1" {
+		t.Errorf("Expected ID '1', got '%s'", webhook.ID)
+	}
+
+	webhook.URL = "https://example.com/b"
+	if err := repo.Update(ctx, webhook); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	found, err := repo.FindByID(ctx, "1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if found.URL != "https://example.com/b" || found.Secret != "s3cret" || len(found.Events) != 1 {
+		t.Errorf("Unexpected webhook: %+v", found)
+	}
+
+	if err := repo.Delete(ctx, "1"); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, "1"); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("Expected ErrWebhookNotFound, got %v", err)
+	}
+	if err := repo.Update(ctx, webhook); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("Expected ErrWebhookNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+// Package openapi は HTTP のルートを登録しながら、その OpenAPI 3.1 の文書を作る。
+// 文書はルートの登録とリクエスト・レスポンスの Go の型から作るため、実装と食い違わない。
+package openapi
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const jsonContentType = "application/json"
+
+// Operation はルートの説明。Request と Response には本文の型の値（nil のポインタでもよい）を指定する。
+// Response が nil なら本文を返さない。ContentType と RequestContentType が空なら JSON とみなす。
+// ID は operationId で、HandleFunc では省略するとメソッド名になる。
+// Alternatives は Response を文字列で返す JSON 以外のメディアタイプ（Accept で選ぶもの）。
+type Operation struct {
+	ID                 string
+	Summary            string
+	Deprecated         bool
+	Params             []Param
+	Request            any
+	RequestContentType string
+	Response           any
+	ContentType        string
+	Alternatives       []string
+	Status             int
+	Errors             []int
+}
+
+// Param はクエリ・ヘッダー・パスのパラメータ。パターンの {name} で宣言していないパスのパラメータは文字列とみなす。
+type Param struct {
+	Name        string
+	In          string
+	Type        string
+	Description string
+	Required    bool
+}
+
+func Query(name, typ, description string) Param {
+	return Param{Name: name, In: "query", Type: typ, Description: description}
+}
+
+func Path(name, typ, description string) Param {
+	return Param{Name: name, In: "path", Type: typ, Description: description, Required: true}
+}
+
+func Header(name, typ, description string) Param {
+	return Param{Name: name, In: "header", Type: typ, Description: description}
+}
+
+type route struct {
+	pattern   string
+	operation Operation
+	name      string
+	pkg       string
+}
+
+// Router は http.ServeMux にルートを登録し、登録したルートを記録する。
+type Router struct {
+	mux    *http.ServeMux
+	info   Info
+	routes []route
+	errors any
+
+	mu  sync.Mutex
+	gen *schemaGenerator
+}
+
+func NewRouter(title, version string) *Router {
+	return &Router{
+		mux:  http.NewServeMux(),
+		info: Info{Title: title, Version: version},
+		gen:  newSchemaGenerator(),
+	}
+}
+
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mux.ServeHTTP(w, req)
+}
+
+// HandleFunc は pattern（"GET /todo/{id}" の形式）に handler を登録する。
+// op.ID を省略すると handler のメソッド名を operationId にし、重複すればパッケージ名を前に付ける。
+func (r *Router) HandleFunc(pattern string, handler http.HandlerFunc, op Operation) {
+	pkg, name := funcName(handler)
+	if op.ID != "" {
+		name = op.ID
+	}
+	r.mux.Handle(pattern, handler)
+	r.routes = append(r.routes, route{pattern: pattern, operation: op, name: name, pkg: pkg})
+}
+
+// Handle は HandleFunc と同じだが、関数ではない handler のため op.ID を省略できない。
+func (r *Router) Handle(pattern string, handler http.Handler, op Operation) {
+	r.mux.Handle(pattern, handler)
+	r.routes = append(r.routes, route{pattern: pattern, operation: op, name: op.ID})
+}
+
+// SetErrorBody は本文を検証するルートが 400・413・415 で返す本文の型を設定する。
+func (r *Router) SetErrorBody(v any) {
+	r.errors = v
+}
+
+// Customize は v の型の Schema を変更する。MarshalJSON で出力を変える型や、値が決まっている文字列型に使う。
+func (r *Router) Customize(v any, fn func(*Schema)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.gen.customizer[reflect.TypeOf(v)] = fn
+}
+
+// ServeDocument は文書を JSON で返す。GET /openapi.json に登録する。
+func (r *Router) ServeDocument(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", jsonContentType)
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(r.Document())
+}
+
+// Document は登録済みのルートから文書を作る。
+func (r *Router) Document() *Document {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc := &Document{
+		OpenAPI: "3.1.0",
+		Info:    r.info,
+		Paths:   map[string]*PathItem{},
+	}
+	usedIDs := map[string]bool{}
+	for _, rt := range r.routes {
+		method, path := SplitPattern(rt.pattern)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		op := r.operation(rt, path)
+		if usedIDs[op.OperationID] {
+			op.OperationID = rt.pkg + op.OperationID
+		}
+		usedIDs[op.OperationID] = true
+		(*item)[strings.ToLower(method)] = op
+	}
+	doc.Components.Schemas = maps.Clone(r.gen.schemas)
+	return doc
+}
+
+func (r *Router) operation(rt route, path string) *OperationObject {
+	op := rt.operation
+	obj := &OperationObject{
+		OperationID: rt.name,
+		Summary:     op.Summary,
+		Deprecated:  op.Deprecated,
+		Responses:   map[string]*Response{},
+	}
+	if tag := strings.Split(strings.TrimPrefix(path, "/"), "/")[0]; tag != "" {
+		obj.Tags = []string{strings.TrimSuffix(tag, ".json")}
+	}
+
+	declared := map[string]bool{}
+	for _, p := range op.Params {
+		obj.Parameters = append(obj.Parameters, &Parameter{
+			Name: p.Name, In: p.In, Description: p.Description, Required: p.Required || p.In == "path",
+			Schema: &Schema{Type: p.Type},
+		})
+		if p.In == "path" {
+			declared[p.Name] = true
+		}
+	}
+	for _, name := range pathParams(path) {
+		if !declared[name] {
+			obj.Parameters = append(obj.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+
+	if op.Request != nil {
+		requestType := op.RequestContentType
+		if requestType == "" {
+			requestType = jsonContentType
+		}
+		obj.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{requestType: {Schema: r.gen.schema(reflect.TypeOf(op.Request))}},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	resp := &Response{Description: http.StatusText(status)}
+	contentType := op.ContentType
+	if contentType == "" && op.Response != nil {
+		contentType = jsonContentType
+	}
+	if contentType != "" {
+		media := &MediaType{}
+		if op.Response != nil {
+			media.Schema = r.gen.schema(reflect.TypeOf(op.Response))
+		}
+		resp.Content = map[string]*MediaType{contentType: media}
+	}
+	for _, alt := range op.Alternatives {
+		if resp.Content == nil {
+			resp.Content = map[string]*MediaType{}
+		}
+		resp.Content[alt] = &MediaType{Schema: &Schema{Type: "string"}}
+	}
+	obj.Responses[strconv.Itoa(status)] = resp
+	for _, code := range op.Errors {
+		obj.Responses[strconv.Itoa(code)] = &Response{Description: http.StatusText(code)}
+	}
+	if op.Request != nil {
+		for _, code := range []int{http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnsupportedMediaType} {
+			resp := &Response{Description: http.StatusText(code)}
+			if r.errors != nil {
+				resp.Content = map[string]*MediaType{jsonContentType: {Schema: r.gen.schema(reflect.TypeOf(r.errors))}}
+			}
+			obj.Responses[strconv.Itoa(code)] = resp
+		}
+	}
+	return obj
+}
+
+// SplitPattern は http.ServeMux のパターンを HTTP メソッドと OpenAPI のパスに分ける。
+// 残りのパスすべてに一致する {name...} は {name} にする。
+func SplitPattern(pattern string) (string, string) {
+	method, path, ok := strings.Cut(pattern, " ")
+	if !ok {
+		method, path = "", pattern
+	}
+	path = strings.ReplaceAll(path, "...}", "}")
+	return method, path
+}
+
+func pathParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, strings.Trim(segment, "{}"))
+		}
+	}
+	return names
+}
+
+// funcName は関数のパッケージ名と名前を返す。メソッドの値（web.(*Handler).CreateTodo-fm）なら web と CreateTodo。
+func funcName(handler http.HandlerFunc) (string, string) {
+	name := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+	name = strings.TrimSuffix(name, "-fm")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	pkg, _, _ := strings.Cut(name, ".")
+	return pkg, name[strings.LastIndex(name, ".")+1:]
+}
@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testHandler struct{}
+
+type testRequest struct {
+	Title string `json:"title"`
+}
+
+func (h *testHandler) CreateItem(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *testHandler) GetItem(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(r.PathValue("id")))
+}
+
+func TestRouter_Document(t *testing.T) {
+	// Given: 2つのルート
+	h := &testHandler{}
+	r := NewRouter("test", "1.0.0")
+	r.HandleFunc("POST /items", h.CreateItem, Operation{Summary: "Create", Request: testRequest{}, Response: testRequest{}, Status: http.StatusCreated})
+	r.HandleFunc("GET /items/{id}/files/{path...}", h.GetItem, Operation{Params: []Param{Path("id", "integer", "")}, Errors: []int{http.StatusNotFound}})
+
+	// When:  文書を作る
+	doc := r.Document()
+
+	// Then:  メソッド名が operationId になり、パスのパラメータと本文の型が載る
+	create := (*doc.Paths["/items"])["post"]
+	if create.OperationID != "CreateItem" || create.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/testRequest" {
+		t.Errorf("Unexpected create operation: %+v", create)
+	}
+	if create.Responses["201"] == nil || create.Responses["201"].Content["application/json"] == nil {
+		t.Errorf("Expected 201 JSON response, got %+v", create.Responses)
+	}
+	get := (*doc.Paths["/items/{id}/files/{path}"])["get"]
+	if get == nil || get.OperationID != "GetItem" {
+		t.Fatalf("Expected GetItem, got %+v", doc.Paths)
+	}
+	if len(get.Parameters) != 2 || get.Parameters[0].Schema.Type != "integer" || get.Parameters[1].Name != "path" || !get.Parameters[1].Required {
+		t.Errorf("Unexpected parameters: %+v %+v", get.Parameters[0], get.Parameters[1])
+	}
+	if get.Responses["200"].Content != nil || get.Responses["404"] == nil {
+		t.Errorf("Unexpected responses: %+v", get.Responses)
+	}
+}
+
+func TestRouter_DuplicateOperationID(t *testing.T) {
+	h := &testHandler{}
+	r := NewRouter("test", "1.0.0")
+	r.HandleFunc("GET /a/{id}", h.GetItem, Operation{})
+	r.HandleFunc("GET /b/{id}", h.GetItem, Operation{})
+
+	doc := r.Document()
+
+	if id := (*doc.Paths["/b/{id}"])["get"].OperationID; id != "openapiGetItem" {
+		t.Errorf("Expected openapiGetItem, got %s", id)
+	}
+}
+
+func TestRouter_Alternatives(t *testing.T) {
+	// Given: JSON 以外の形式でも返すルート
+	h := &testHandler{}
+	r := NewRouter("test", "1.0.0")
+	r.HandleFunc("GET /items/{id}", h.GetItem, Operation{Response: testRequest{}, Alternatives: []string{"text/csv"}})
+
+	// When:  文書を作る
+	doc := r.Document()
+
+	// Then:  200 に JSON と文字列の text/csv が載る
+	content := (*doc.Paths["/items/{id}"])["get"].Responses["200"].Content
+	if content["application/json"] == nil || content["text/csv"] == nil || content["text/csv"].Schema.Type != "string" {
+		t.Errorf("Unexpected content: %+v", content)
+	}
+}
+
+func TestRouter_ServeHTTP(t *testing.T) {
+	// Given: 登録したルート
+	r := NewRouter("test", "1.0.0")
+	r.HandleFunc("GET /items/{id}", (&testHandler{}).GetItem, Operation{})
+
+	// When:  リクエストを送る
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/items/42", nil))
+
+	// Then:  登録したハンドラーが呼ばれる
+	if w.Code != http.StatusOK || w.Body.String() != "42" {
+		t.Errorf("Unexpected response: %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema は JSON Schema（OpenAPI 3.1 は JSON Schema 2020-12 に準拠する）のうち、この API で使う部分。
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// schemaGenerator は Go の型から Schema を作る。名前のある構造体は components に登録して $ref で参照する。
+type schemaGenerator struct {
+	schemas    map[string]*Schema
+	names      map[reflect.Type]string
+	customizer map[reflect.Type]func(*Schema)
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas:    map[string]*Schema{},
+		names:      map[reflect.Type]string{},
+		customizer: map[reflect.Type]func(*Schema){},
+	}
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		return &Schema{Ref: "#/components/schemas/" + g.component(t)}
+	}
+
+	s := g.inline(t)
+	if customize, ok := g.customizer[t]; ok {
+		customize(s)
+	}
+	return s
+}
+
+func (g *schemaGenerator) inline(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if t.Kind() == reflect.Int64 || t.Kind() == reflect.Uint64 {
+			return &Schema{Type: "integer", Format: "int64"}
+		}
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		return g.object(t)
+	default:
+		// interface などは任意の値
+		return &Schema{}
+	}
+}
+
+// component は構造体を components に登録し、その名前を返す。再帰する型のため、中身を作る前に名前を予約する。
+// 別のパッケージに同じ名前の型があれば、パッケージ名を前に付けて区別する。
+func (g *schemaGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := g.schemas[name]; taken {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + name
+	}
+	g.names[t] = name
+	g.schemas[name] = &Schema{}
+
+	s := g.object(t)
+	if customize, ok := g.customizer[t]; ok {
+		customize(s)
+	}
+	*g.schemas[name] = *s
+	return name
+}
+
+// object は encoding/json と同じ規則で構造体のフィールドを properties にする。
+// omitempty のないフィールドは常に出力されるため required とする。
+func (g *schemaGenerator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.fields(t, s)
+	return s
+}
+
+func (g *schemaGenerator) fields(t reflect.Type, s *Schema) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// 名前のない埋め込みの構造体はフィールドを展開する
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.fields(ft, s)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s.Properties[name] = g.schema(field.Type)
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
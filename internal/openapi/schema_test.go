@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testBase struct {
+	ID int64 `json:"id"`
+}
+
+type testNode struct {
+	testBase
+	Name     string          `json:"name"`
+	Note     string          `json:"note,omitempty"`
+	Secret   string          `json:"-"`
+	At       time.Time       `json:"at"`
+	Children []*testNode     `json:"children,omitempty"`
+	Labels   map[string]bool `json:"labels"`
+	Raw      json.RawMessage `json:"raw"`
+	internal int
+}
+
+func TestSchemaGenerator_Struct(t *testing.T) {
+	// Given: 埋め込み・omitempty・再帰を含む構造体
+	g := newSchemaGenerator()
+
+	// When:  Schema を作る
+	ref := g.schema(reflect.TypeFor[*testNode]())
+
+	// Then:  components に登録され、encoding/json と同じフィールドになる
+	if ref.Ref != "#/components/schemas/testNode" {
+		t.Fatalf("Expected $ref to testNode, got %+v", ref)
+	}
+	s := g.schemas["testNode"]
+	want := map[string]string{"id": "integer", "name": "string", "note": "string", "at": "string", "children": "array", "labels": "object", "raw": ""}
+	if len(s.Properties) != len(want) {
+		t.Errorf("Expected %d properties, got %d", len(want), len(s.Properties))
+	}
+	for name, typ := range want {
+		if p, ok := s.Properties[name]; !ok || p.Type != typ {
+			t.Errorf("Expected %s of type %q, got %+v", name, typ, p)
+		}
+	}
+	if s.Properties["at"].Format != "date-time" || s.Properties["id"].Format != "int64" {
+		t.Errorf("Unexpected formats: %+v %+v", s.Properties["at"], s.Properties["id"])
+	}
+	if s.Properties["children"].Items.Ref != "#/components/schemas/testNode" {
+		t.Errorf("Expected recursive $ref, got %+v", s.Properties["children"].Items)
+	}
+	if !reflect.DeepEqual(s.Required, []string{"id", "name", "at", "labels", "raw"}) {
+		t.Errorf("Unexpected required: %v", s.Required)
+	}
+}
+
+type testStatus string
+
+func TestSchemaGenerator_Customize(t *testing.T) {
+	// Given: 値が決まっている文字列型
+	g := newSchemaGenerator()
+	g.customizer[reflect.TypeFor[testStatus]()] = func(s *Schema) { s.Enum = []any{"open", "closed"} }
+
+	// When:  Schema を作る
+	s := g.schema(reflect.TypeFor[[]testStatus]())
+
+	// Then:  要素に enum が付く
+	if s.Type != "array" || s.Items.Type != "string" || len(s.Items.Enum) != 2 {
+		t.Errorf("Unexpected schema: %+v %+v", s, s.Items)
+	}
+}
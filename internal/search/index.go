@@ -0,0 +1,238 @@
+package search
+
+import (
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+const (
+	DefaultLimit = 20
+
+	snippetLength = 80
+	bm25K1        = 1.2
+	bm25B         = 0.75
+)
+
+type document struct {
+	todo   *domain.Todo
+	text   string
+	tokens []token
+}
+
+// Index は Todo のタイトルを対象にした転置インデックス。
+type Index struct {
+	mu       sync.RWMutex
+	docs     map[int]*document
+	postings map[string]map[int]int
+	totalLen int
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[int]*document),
+		postings: make(map[string]map[int]int),
+	}
+}
+
+// Put は todo を索引に追加する。ゴミ箱の Todo は検索対象にしない。
+func (idx *Index) Put(todo *domain.Todo) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(todo.ID)
+	if todo.IsDeleted() {
+		return
+	}
+
+	doc := &document{todo: todo.Clone(), text: todo.Title}
+	doc.tokens = tokenize(doc.text)
+	for _, tok := range doc.tokens {
+		if idx.postings[tok.term] == nil {
+			idx.postings[tok.term] = make(map[int]int)
+		}
+		idx.postings[tok.term][todo.ID]++
+	}
+	idx.docs[todo.ID] = doc
+	idx.totalLen += len(doc.tokens)
+}
+
+func (idx *Index) Remove(id int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(id)
+}
+
+func (idx *Index) remove(id int) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+	for _, tok := range doc.tokens {
+		delete(idx.postings[tok.term], id)
+		if len(idx.postings[tok.term]) == 0 {
+			delete(idx.postings, tok.term)
+		}
+	}
+	idx.totalLen -= len(doc.tokens)
+	delete(idx.docs, id)
+}
+
+// Search は q に一致する Todo をスコアの高い順に最大 limit 件返す。
+func (idx *Index) Search(q string, limit int) ([]domain.SearchHit, error) {
+	clauseList, err := parseQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	type candidate struct {
+		doc      *document
+		score    float64
+		spanList [][2]int
+	}
+	candidates := make(map[int]*candidate)
+	for id := range idx.candidateIDs(clauseList) {
+		candidates[id] = &candidate{doc: idx.docs[id]}
+	}
+
+	avgLen := 1.0
+	if len(idx.docs) > 0 && idx.totalLen > 0 {
+		avgLen = float64(idx.totalLen) / float64(len(idx.docs))
+	}
+	for _, c := range clauseList {
+		matches := make(map[int][][2]int)
+		for id, cand := range candidates {
+			if spanList := c.match(cand.doc.tokens); len(spanList) > 0 {
+				matches[id] = spanList
+			}
+		}
+
+		if c.negate {
+			for id := range matches {
+				delete(candidates, id)
+			}
+			continue
+		}
+
+		idf := math.Log(1 + (float64(len(idx.docs))-float64(len(matches))+0.5)/(float64(len(matches))+0.5))
+		for id, cand := range candidates {
+			spanList, ok := matches[id]
+			if !ok {
+				delete(candidates, id)
+				continue
+			}
+			tf := float64(len(spanList))
+			norm := 1 - bm25B + bm25B*float64(len(cand.doc.tokens))/avgLen
+			cand.score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+			cand.spanList = append(cand.spanList, spanList...)
+		}
+	}
+
+	hitList := make([]domain.SearchHit, 0, len(candidates))
+	for _, cand := range candidates {
+		hitList = append(hitList, domain.SearchHit{
+			Todo:    cand.doc.todo.Clone(),
+			Score:   cand.score,
+			Snippet: highlight(cand.doc.text, cand.spanList),
+		})
+	}
+	sort.Slice(hitList, func(i, j int) bool {
+		if hitList[i].Score != hitList[j].Score {
+			return hitList[i].Score > hitList[j].Score
+		}
+		return hitList[i].Todo.ID < hitList[j].Todo.ID
+	})
+	if len(hitList) > limit {
+		hitList = hitList[:limit]
+	}
+	return hitList, nil
+}
+
+// candidateIDs は最初の肯定条件の先頭語を持つ文書を転置インデックスから集める。
+func (idx *Index) candidateIDs(clauseList []clause) map[int]bool {
+	idSet := make(map[int]bool)
+	for _, c := range clauseList {
+		if c.negate {
+			continue
+		}
+		for term, posting := range idx.postings {
+			if !c.matchTerm(term, 0) {
+				continue
+			}
+			for id := range posting {
+				idSet[id] = true
+			}
+		}
+		break
+	}
+	return idSet
+}
+
+// highlight は一致箇所を <mark> で囲み、長い文字列は最初の一致箇所の周辺だけを切り出す。
+func highlight(text string, spanList [][2]int) string {
+	sort.Slice(spanList, func(i, j int) bool { return spanList[i][0] < spanList[j][0] })
+	var merged [][2]int
+	for _, span := range spanList {
+		if n := len(merged); n > 0 && span[0] <= merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], span[1])
+			continue
+		}
+		merged = append(merged, span)
+	}
+
+	from, to := 0, len(text)
+	if utf8.RuneCountInString(text) > snippetLength && len(merged) > 0 {
+		from = backRunes(text, merged[0][0], snippetLength/4)
+		to = forwardRunes(text, from, snippetLength)
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	pos := from
+	for _, span := range merged {
+		if span[1] <= from || span[0] >= to {
+			continue
+		}
+		start, end := max(span[0], from), min(span[1], to)
+		b.WriteString(html.EscapeString(text[pos:start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[start:end]))
+		b.WriteString("</mark>")
+		pos = end
+	}
+	b.WriteString(html.EscapeString(text[pos:to]))
+	if to < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+func backRunes(text string, pos int, n int) int {
+	for ; n > 0 && pos > 0; n-- {
+		_, size := utf8.DecodeLastRuneInString(text[:pos])
+		pos -= size
+	}
+	return pos
+}
+
+func forwardRunes(text string, pos int, n int) int {
+	for ; n > 0 && pos < len(text); n-- {
+		_, size := utf8.DecodeRuneInString(text[pos:])
+		pos += size
+	}
+	return pos
+}
@@ -0,0 +1,209 @@
+package search
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func newTestIndex(titles ...string) *Index {
+	index := NewIndex()
+	for i, title := range titles {
+		index.Put(&domain.Todo{ID: i + 1, Title: title, Status: domain.StatusTodo})
+	}
+	return index
+}
+
+func hitIDs(hitList []domain.SearchHit) []int {
+	ids := []int{}
+	for _, hit := range hitList {
+		ids = append(ids, hit.Todo.ID)
+	}
+	return ids
+}
+
+func TestIndex_Search(t *testing.T) {
+	index := newTestIndex(
+		"Buy milk",
+		"Buy oat milk",
+		"Read a book about milking",
+		"牛乳を買う",
+		"本を読む",
+		"Go言語の本を買う",
+	)
+
+	tests := []struct {
+		name    string
+		q       string
+		wantIDs []int
+	}{
+		{name: "word", q: "milk", wantIDs: []int{1, 2}},
+		{name: "case insensitive", q: "MILK", wantIDs: []int{1, 2}},
+		{name: "all words must match", q: "buy oat", wantIDs: []int{2}},
+		{name: "phrase", q: `"buy milk"`, wantIDs: []int{1}},
+		{name: "prefix", q: "mil*", wantIDs: []int{1, 2, 3}},
+		{name: "negation", q: "milk -oat", wantIDs: []int{1}},
+		{name: "japanese word", q: "買う", wantIDs: []int{4, 6}},
+		{name: "japanese phrase", q: "牛乳を買う", wantIDs: []int{4}},
+		{name: "single japanese character", q: "本", wantIDs: []int{5, 6}},
+		{name: "japanese negation", q: "買う -牛乳", wantIDs: []int{6}},
+		{name: "no match", q: "gym", wantIDs: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hitList, err := index.Search(tt.q, 0)
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if ids := hitIDs(hitList); !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("Expected %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
+
+func TestIndex_Search_InvalidQuery(t *testing.T) {
+	// Given: 空の索引
+	index := NewIndex()
+
+	// When:  閉じていない引用符で検索する
+	_, err := index.Search(`"buy milk`, 0)
+
+	// Then:  ErrInvalidQuery が返る
+	if !errors.Is(err, domain.ErrInvalidQuery) {
+		t.Errorf("Expected ErrInvalidQuery, got %v", err)
+	}
+}
+
+func TestIndex_Search_Ranking(t *testing.T) {
+	// Given: 検索語の出現回数とタイトルの長さが異なる Todo
+	index := newTestIndex(
+		"Call the plumber about the leaking kitchen sink milk",
+		"milk milk",
+		"milk",
+	)
+
+	// When:  milk で検索する
+	hitList, _ := index.Search("milk", 0)
+
+	// Then:  短く、検索語を多く含む Todo ほど上位になる
+	if ids := hitIDs(hitList); !reflect.DeepEqual(ids, []int{2, 3, 1}) {
+		t.Errorf("Expected [2 3 1], got %v", ids)
+	}
+	if hitList[0].Score <= hitList[2].Score {
+		t.Errorf("Expected scores in descending order, got %v and %v", hitList[0].Score, hitList[2].Score)
+	}
+}
+
+func TestIndex_Search_Limit(t *testing.T) {
+	// Given: 3件一致する索引
+	index := newTestIndex("milk", "milk", "milk")
+
+	// When:  limit 2 で検索する
+	hitList, _ := index.Search("milk", 2)
+
+	// Then:  2件だけ返る
+	if len(hitList) != 2 {
+		t.Errorf("Expected 2 hits, got %d", len(hitList))
+	}
+}
+
+func TestIndex_Put_Update(t *testing.T) {
+	// Given: milk を含む Todo
+	index := newTestIndex("Buy milk")
+
+	// When:  タイトルを変更して再登録する
+	index.Put(&domain.Todo{ID: 1, Title: "Buy eggs"})
+
+	// Then:  古いタイトルでは見つからず、新しいタイトルで見つかる
+	if hitList, _ := index.Search("milk", 0); len(hitList) != 0 {
+		t.Errorf("Expected no hits for old title, got %v", hitIDs(hitList))
+	}
+	if hitList, _ := index.Search("eggs", 0); len(hitList) != 1 {
+		t.Errorf("Expected 1 hit for new title, got %v", hitIDs(hitList))
+	}
+}
+
+func TestIndex_Put_Deleted(t *testing.T) {
+	// Given: 索引済みの Todo
+	index := newTestIndex("Buy milk")
+
+	// When:  ゴミ箱に移動した状態で登録し直す
+	now := time.Now()
+	index.Put(&domain.Todo{ID: 1, Title: "Buy milk", DeletedAt: &now})
+
+	// Then:  検索結果に含まれない
+	if hitList, _ := index.Search("milk", 0); len(hitList) != 0 {
+		t.Errorf("Expected no hits, got %v", hitIDs(hitList))
+	}
+}
+
+func TestIndex_Remove(t *testing.T) {
+	// Given: 索引済みの Todo
+	index := newTestIndex("Buy milk")
+
+	// When:  Remove する
+	index.Remove(1)
+
+	// Then:  検索結果に含まれず、転置リストも空になる
+	if hitList, _ := index.Search("milk", 0); len(hitList) != 0 {
+		t.Errorf("Expected no hits, got %v", hitIDs(hitList))
+	}
+	if len(index.postings) != 0 || index.totalLen != 0 {
+		t.Errorf("Expected empty postings, got %v (total %d)", index.postings, index.totalLen)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		q    string
+		want string
+	}{
+		{name: "word", text: "Buy milk", q: "milk", want: "Buy <mark>milk</mark>"},
+		{name: "multiple clauses", text: "Buy oat milk", q: "buy milk", want: "<mark>Buy</mark> oat <mark>milk</mark>"},
+		{name: "overlapping bigrams merged", text: "牛乳を買う", q: "牛乳を", want: "<mark>牛乳を</mark>買う"},
+		{name: "single japanese character", text: "Go言語の本を買う", q: "本", want: "Go言語の<mark>本</mark>を買う"},
+		{name: "html escaped", text: "<b>milk</b> & eggs", q: "milk", want: "&lt;b&gt;<mark>milk</mark>&lt;/b&gt; &amp; eggs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index := newTestIndex(tt.text)
+
+			hitList, _ := index.Search(tt.q, 0)
+
+			if len(hitList) != 1 {
+				t.Fatalf("Expected 1 hit, got %d", len(hitList))
+			}
+			if hitList[0].Snippet != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, hitList[0].Snippet)
+			}
+		})
+	}
+}
+
+func TestHighlight_LongText(t *testing.T) {
+	// Given: 検索語が中ほどにある長いタイトル
+	title := strings.Repeat("a ", 60) + "milk" + strings.Repeat(" b", 60)
+	index := newTestIndex(title)
+
+	// When:  milk で検索する
+	hitList, _ := index.Search("milk", 0)
+
+	// Then:  一致箇所の周辺だけが省略記号付きで返る
+	snippet := hitList[0].Snippet
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") {
+		t.Errorf("Expected ellipsis on both sides, got %q", snippet)
+	}
+	if !strings.Contains(snippet, "<mark>milk</mark>") {
+		t.Errorf("Expected highlighted match, got %q", snippet)
+	}
+}
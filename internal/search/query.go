@@ -0,0 +1,130 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// clause は検索語1つ分の条件。terms が複数ある場合は連続して現れる必要がある（フレーズ）。
+type clause struct {
+	terms  []string
+	prefix bool
+	negate bool
+}
+
+// parseQuery は次の構文を解釈する。
+//
+//	milk          単語
+//	"buy milk"    フレーズ
+//	mil*          前方一致
+//	-book         否定（"..." や前方一致にも付けられる）
+//
+// 日本語はフレーズとして扱うため、"牛乳を買う" のように引用符で囲まなくてもよい。
+func parseQuery(q string) ([]clause, error) {
+	var clauseList []clause
+	hasPositive := false
+
+	rest := strings.TrimSpace(q)
+	for rest != "" {
+		c := clause{}
+		if strings.HasPrefix(rest, "-") && len(rest) > 1 {
+			c.negate = true
+			rest = rest[1:]
+		}
+
+		var text string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				return nil, domain.ErrInvalidQuery
+			}
+			text = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, " \t　")
+			if end < 0 {
+				end = len(rest)
+			}
+			text = rest[:end]
+			rest = rest[end:]
+			if strings.HasSuffix(text, "*") {
+				c.prefix = true
+				text = strings.TrimRight(text, "*")
+			}
+		}
+		rest = strings.TrimLeft(rest, " \t　")
+
+		for _, tok := range tokenize(text) {
+			c.terms = append(c.terms, tok.term)
+		}
+		if len(c.terms) == 0 {
+			continue
+		}
+		if !c.negate {
+			hasPositive = true
+		}
+		clauseList = append(clauseList, c)
+	}
+
+	if !hasPositive {
+		return nil, domain.ErrInvalidQuery
+	}
+	return clauseList, nil
+}
+
+// matchTerm は位置 i のトークンが検索語 j に一致するかを判定する。
+// 1文字だけの日本語は bigram のどちらの文字に含まれていても一致とみなす。
+func (c clause) matchTerm(term string, j int) bool {
+	want := c.terms[j]
+	if term == want {
+		return true
+	}
+	if len(c.terms) == 1 && isSingleCJK(want) {
+		return strings.Contains(term, want)
+	}
+	return c.prefix && j == len(c.terms)-1 && strings.HasPrefix(term, want)
+}
+
+// match は doc 内で clause が出現する箇所を返す。
+func (c clause) match(tokens []token) [][2]int {
+	if len(c.terms) == 1 && isSingleCJK(c.terms[0]) {
+		return c.matchChar(tokens)
+	}
+
+	var spanList [][2]int
+	for i := 0; i+len(c.terms) <= len(tokens); i++ {
+		matched := true
+		for j := range c.terms {
+			if !c.matchTerm(tokens[i+j].term, j) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			spanList = append(spanList, [2]int{tokens[i].start, tokens[i+len(c.terms)-1].end})
+		}
+	}
+	return spanList
+}
+
+// matchChar は1文字の日本語が出現する位置を返す。隣り合う bigram で同じ文字を二重に数えない。
+func (c clause) matchChar(tokens []token) [][2]int {
+	want := c.terms[0]
+	var spanList [][2]int
+	for _, tok := range tokens {
+		var span [2]int
+		switch {
+		case strings.HasPrefix(tok.term, want):
+			span = [2]int{tok.start, tok.start + len(want)}
+		case strings.HasSuffix(tok.term, want):
+			span = [2]int{tok.end - len(want), tok.end}
+		default:
+			continue
+		}
+		if n := len(spanList); n == 0 || spanList[n-1] != span {
+			spanList = append(spanList, span)
+		}
+	}
+	return spanList
+}
@@ -0,0 +1,41 @@
+package search
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       string
+		want    []clause
+		wantErr error
+	}{
+		{name: "words", q: "buy milk", want: []clause{{terms: []string{"buy"}}, {terms: []string{"milk"}}}},
+		{name: "phrase", q: `"buy milk"`, want: []clause{{terms: []string{"buy", "milk"}}}},
+		{name: "prefix", q: "mil*", want: []clause{{terms: []string{"mil"}, prefix: true}}},
+		{name: "negation", q: "milk -oat", want: []clause{{terms: []string{"milk"}}, {terms: []string{"oat"}, negate: true}}},
+		{name: "negated phrase", q: `milk -"oat milk"`, want: []clause{{terms: []string{"milk"}}, {terms: []string{"oat", "milk"}, negate: true}}},
+		{name: "japanese", q: "牛乳　買う", want: []clause{{terms: []string{"牛乳"}}, {terms: []string{"買う"}}}},
+		{name: "unterminated quote", q: `"buy milk`, wantErr: domain.ErrInvalidQuery},
+		{name: "only negation", q: "-milk", wantErr: domain.ErrInvalidQuery},
+		{name: "empty", q: "  ", wantErr: domain.ErrInvalidQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseQuery(tt.q)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package search
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// IndexedRepository は repo への変更を Index に反映しながら委譲するデコレータ。
+type IndexedRepository struct {
+	domain.IRepository
+	index *Index
+}
+
+// NewIndexedRepository は repo の現在の内容から索引を作る。
+func NewIndexedRepository(ctx context.Context, repo domain.IRepository) (*IndexedRepository, error) {
+	todoList, err := repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := NewIndex()
+	for _, todo := range todoList {
+		index.Put(todo)
+	}
+	return &IndexedRepository{IRepository: repo, index: index}, nil
+}
+
+func (r *IndexedRepository) Search(ctx context.Context, query string, limit int) ([]domain.SearchHit, error) {
+	return r.index.Search(query, limit)
+}
+
+func (r *IndexedRepository) Create(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Create(ctx, todo)
+	})
+}
+
+func (r *IndexedRepository) Insert(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Insert(ctx, todo)
+	})
+}
+
+func (r *IndexedRepository) Update(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Update(ctx, todo)
+	})
+}
+
+func (r *IndexedRepository) Delete(ctx context.Context, id int) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Delete(ctx, id)
+	})
+}
+
+// WithinTx はトランザクション内の変更を記録しておき、fn が成功したら repo のロックを持ったまま索引に反映する。
+// ロックを外してから反映すると、並行するトランザクションの反映と順序が入れ替わり、索引が古い内容で上書きされうる。
+// 反映した後にコミットが失敗した場合は、変更した Todo を repo から読み直して索引を戻す。
+func (r *IndexedRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	var rec *recordingTx
+	applied := false
+	err := r.IRepository.WithinTx(ctx, func(tx domain.IRepository) error {
+		rec = &recordingTx{IRepository: tx, changes: make(map[int]*domain.Todo)}
+		if err := fn(rec); err != nil {
+			return err
+		}
+		r.apply(rec.changes)
+		applied = true
+		return nil
+	})
+	if err != nil && applied {
+		r.reload(ctx, rec.changes)
+	}
+	return err
+}
+
+func (r *IndexedRepository) apply(changes map[int]*domain.Todo) {
+	for id, todo := range changes {
+		if todo == nil {
+			r.index.Remove(id)
+			continue
+		}
+		r.index.Put(todo)
+	}
+}
+
+func (r *IndexedRepository) reload(ctx context.Context, changes map[int]*domain.Todo) {
+	for id := range changes {
+		todo, err := r.IRepository.FindByID(ctx, id)
+		if err != nil {
+			r.index.Remove(id)
+			continue
+		}
+		r.index.Put(todo)
+	}
+}
+
+// recordingTx は変更された Todo の最終状態を記録する。削除された場合は nil を記録する。
+type recordingTx struct {
+	domain.IRepository
+	changes map[int]*domain.Todo
+}
+
+func (tx *recordingTx) Create(ctx context.Context, todo *domain.Todo) error {
+	if err := tx.IRepository.Create(ctx, todo); err != nil {
+		return err
+	}
+	tx.changes[todo.ID] = todo.Clone()
+	return nil
+}
+
+func (tx *recordingTx) Insert(ctx context.Context, todo *domain.Todo) error {
+	if err := tx.IRepository.Insert(ctx, todo); err != nil {
+		return err
+	}
+	tx.changes[todo.ID] = todo.Clone()
+	return nil
+}
+
+func (tx *recordingTx) Update(ctx context.Context, todo *domain.Todo) error {
+	if err := tx.IRepository.Update(ctx, todo); err != nil {
+		return err
+	}
+	tx.changes[todo.ID] = todo.Clone()
+	return nil
+}
+
+func (tx *recordingTx) Delete(ctx context.Context, id int) error {
+	if err := tx.IRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	tx.changes[id] = nil
+	return nil
+}
+
+func (tx *recordingTx) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	return fn(tx)
+}
@@ -0,0 +1,214 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+)
+
+func newTestRepository(t *testing.T, content string) *IndexedRepository {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "todos.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	repo, err := NewIndexedRepository(context.Background(), storage.NewFileRepository(path))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	return repo
+}
+
+func searchIDs(t *testing.T, repo *IndexedRepository, q string) []int {
+	t.Helper()
+	hitList, err := repo.Search(context.Background(), q, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return hitIDs(hitList)
+}
+
+func TestNewIndexedRepository(t *testing.T) {
+	// Given: 既存の Todo とゴミ箱の Todo を含むファイル
+	// When:  IndexedRepository を作る
+	// Then:  ゴミ箱以外の Todo が検索できる
+	repo := newTestRepository(t, `[{"id":1,"title":"Buy milk","status":"todo"},{"id":2,"title":"Old milk","status":"todo","deleted_at":"2024-01-01T00:00:00Z"}]`)
+
+	if ids := searchIDs(t, repo, "milk"); len(ids) != 1 || ids[0] != 1 {
+		t.Errorf("Expected [1], got %v", ids)
+	}
+}
+
+func TestIndexedRepository_Mutations(t *testing.T) {
+	// Given: 空のリポジトリ
+	repo := newTestRepository(t, `[]`)
+	ctx := context.Background()
+
+	// When:  作成・更新・削除を行う
+	// Then:  それぞれの変更が直ちに検索結果に反映される
+	todo := &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}
+	if err := repo.Create(ctx, todo); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	if ids := searchIDs(t, repo, "milk"); len(ids) != 1 {
+		t.Errorf("Expected created todo to be found, got %v", ids)
+	}
+
+	todo.Title = "Buy eggs"
+	if err := repo.Update(ctx, todo); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	if ids := searchIDs(t, repo, "milk"); len(ids) != 0 {
+		t.Errorf("Expected old title not to be found, got %v", ids)
+	}
+
+	now := time.Now()
+	todo.DeletedAt = &now
+	if err := repo.Update(ctx, todo); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	if ids := searchIDs(t, repo, "eggs"); len(ids) != 0 {
+		t.Errorf("Expected trashed todo not to be found, got %v", ids)
+	}
+
+	todo.DeletedAt = nil
+	if err := repo.Update(ctx, todo); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	if err := repo.Delete(ctx, todo.ID); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+	if ids := searchIDs(t, repo, "eggs"); len(ids) != 0 {
+		t.Errorf("Expected deleted todo not to be found, got %v", ids)
+	}
+}
+
+func TestIndexedRepository_WithinTx_Commit(t *testing.T) {
+	// Given: 1件の Todo を含むリポジトリ
+	repo := newTestRepository(t, `[{"id":1,"title":"Buy milk","status":"todo"}]`)
+	ctx := context.Background()
+
+	// When:  WithinTx 内で作成と削除を行う
+	err := repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		if err := tx.Create(ctx, &domain.Todo{Title: "Read book", Status: domain.StatusTodo}); err != nil {
+			return err
+		}
+		return tx.Delete(ctx, 1)
+	})
+
+	// Then:  コミット後の状態が索引に反映される
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ids := searchIDs(t, repo, "milk"); len(ids) != 0 {
+		t.Errorf("Expected deleted todo not to be found, got %v", ids)
+	}
+	if ids := searchIDs(t, repo, "book"); len(ids) != 1 || ids[0] != 2 {
+		t.Errorf("Expected [2], got %v", ids)
+	}
+}
+
+func TestIndexedRepository_WithinTx_Rollback(t *testing.T) {
+	// Given: 1件の Todo を含むリポジトリ
+	repo := newTestRepository(t, `[{"id":1,"title":"Buy milk","status":"todo"}]`)
+	ctx := context.Background()
+	txErr := errors.New("abort")
+
+	// When:  WithinTx 内で更新した後にエラーを返す
+	err := repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		todo, _ := tx.FindByID(ctx, 1)
+		todo.Title = "Buy eggs"
+		if err := tx.Update(ctx, todo); err != nil {
+			return err
+		}
+		return txErr
+	})
+
+	// Then:  索引は変更されない
+	if !errors.Is(err, txErr) {
+		t.Fatalf("Expected txErr, got %v", err)
+	}
+	if ids := searchIDs(t, repo, "milk"); len(ids) != 1 {
+		t.Errorf("Expected original title to be found, got %v", ids)
+	}
+	if ids := searchIDs(t, repo, "eggs"); len(ids) != 0 {
+		t.Errorf("Expected rolled back title not to be found, got %v", ids)
+	}
+}
+
+// commitHookRepository は fn が成功した後、WithinTx から戻る前に afterFn を呼び、その結果を WithinTx の結果にする。
+type commitHookRepository struct {
+	domain.IRepository
+	afterFn func() error
+}
+
+func (r *commitHookRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	if err := r.IRepository.WithinTx(ctx, fn); err != nil {
+		return err
+	}
+	return r.afterFn()
+}
+
+func TestIndexedRepository_WithinTx_AppliesBeforeRelease(t *testing.T) {
+	// Given: 内側の WithinTx が戻る直前に索引を確認するリポジトリ
+	path := filepath.Join(t.TempDir(), "todos.json")
+	inner := &commitHookRepository{IRepository: storage.NewFileRepository(path)}
+	repo, err := NewIndexedRepository(context.Background(), inner)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	var idsBeforeRelease []int
+	inner.afterFn = func() error {
+		idsBeforeRelease = searchIDs(t, repo, "milk")
+		return nil
+	}
+
+	// When:  作成する
+	if err := repo.Create(context.Background(), &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+
+	// Then:  内側の WithinTx から戻る前に索引に反映されている
+	if len(idsBeforeRelease) != 1 {
+		t.Errorf("Expected created todo to be indexed before release, got %v", idsBeforeRelease)
+	}
+}
+
+func TestIndexedRepository_WithinTx_CommitFailure(t *testing.T) {
+	// Given: fn の後のコミットで失敗するリポジトリ
+	path := filepath.Join(t.TempDir(), "todos.json")
+	if err := os.WriteFile(path, []byte(`[{"id":1,"title":"Buy milk","status":"todo"}]`), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	commitErr := errors.New("disk full")
+	inner := &commitHookRepository{IRepository: storage.NewFileRepository(path), afterFn: func() error { return nil }}
+	repo, err := NewIndexedRepository(context.Background(), inner)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+	ctx := context.Background()
+
+	// When:  更新を保存した後にコミットが失敗したことにし、その後ファイルを元に戻す
+	inner.afterFn = func() error {
+		os.WriteFile(path, []byte(`[{"id":1,"title":"Buy milk","status":"todo"}]`), 0644)
+		return commitErr
+	}
+	err = repo.Update(ctx, &domain.Todo{ID: 1, Title: "Buy eggs", Status: domain.StatusTodo})
+
+	// Then:  索引は保存されている内容に戻る
+	if !errors.Is(err, commitErr) {
+		t.Fatalf("Expected commitErr, got %v", err)
+	}
+	if ids := searchIDs(t, repo, "milk"); len(ids) != 1 {
+		t.Errorf("Expected saved title to be found, got %v", ids)
+	}
+	if ids := searchIDs(t, repo, "eggs"); len(ids) != 0 {
+		t.Errorf("Expected failed title not to be found, got %v", ids)
+	}
+}
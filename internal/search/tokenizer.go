@@ -0,0 +1,90 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type token struct {
+	term  string
+	start int
+	end   int
+}
+
+// tokenize は英数字を単語単位に、日本語（漢字・ひらがな・カタカナ）を文字 bigram に分割する。
+// start / end は元の文字列でのバイト位置で、スニペットの強調表示に使う。
+func tokenize(text string) []token {
+	var tokens []token
+
+	var word strings.Builder
+	wordStart := -1
+	flushWord := func(end int) {
+		if wordStart >= 0 {
+			tokens = append(tokens, token{term: word.String(), start: wordStart, end: end})
+			word.Reset()
+			wordStart = -1
+		}
+	}
+
+	var cjk []rune
+	var cjkOffset []int
+	flushCJK := func(end int) {
+		switch len(cjk) {
+		case 0:
+			return
+		case 1:
+			tokens = append(tokens, token{term: string(cjk), start: cjkOffset[0], end: end})
+		default:
+			for i := 0; i < len(cjk)-1; i++ {
+				bigramEnd := end
+				if i+2 < len(cjk) {
+					bigramEnd = cjkOffset[i+2]
+				}
+				tokens = append(tokens, token{term: string(cjk[i : i+2]), start: cjkOffset[i], end: bigramEnd})
+			}
+		}
+		cjk = cjk[:0]
+		cjkOffset = cjkOffset[:0]
+	}
+
+	for i, r := range text {
+		r = normalizeRune(r)
+		switch {
+		case isCJK(r):
+			flushWord(i)
+			cjk = append(cjk, r)
+			cjkOffset = append(cjkOffset, i)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK(i)
+			if wordStart < 0 {
+				wordStart = i
+			}
+			word.WriteRune(r)
+		default:
+			flushWord(i)
+			flushCJK(i)
+		}
+	}
+	flushWord(len(text))
+	flushCJK(len(text))
+
+	return tokens
+}
+
+// normalizeRune は大文字・全角英数字の違いを吸収する。
+func normalizeRune(r rune) rune {
+	if r >= '！' && r <= '～' {
+		r -= '！' - '!'
+	}
+	return unicode.ToLower(r)
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) || r == 'ー' || r == '々'
+}
+
+func isSingleCJK(term string) bool {
+	r, size := utf8.DecodeRuneInString(term)
+	return size == len(term) && isCJK(r)
+}
@@ -0,0 +1,54 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+	}{
+		{name: "english words", text: "Buy milk, then eggs", terms: []string{"buy", "milk", "then", "eggs"}},
+		{name: "fullwidth and uppercase", text: "ＢＵＹ　Ｍｉｌｋ", terms: []string{"buy", "milk"}},
+		{name: "japanese bigrams", text: "牛乳を買う", terms: []string{"牛乳", "乳を", "を買", "買う"}},
+		{name: "single japanese character", text: "本 を", terms: []string{"本", "を"}},
+		{name: "mixed", text: "Go言語の本", terms: []string{"go", "言語", "語の", "の本"}},
+		{name: "katakana with long vowel", text: "コーヒー", terms: []string{"コー", "ーヒ", "ヒー"}},
+		{name: "empty", text: " ,. ", terms: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var terms []string
+			for _, tok := range tokenize(tt.text) {
+				terms = append(terms, tok.term)
+			}
+
+			if !reflect.DeepEqual(terms, tt.terms) {
+				t.Errorf("Expected %v, got %v", tt.terms, terms)
+			}
+		})
+	}
+}
+
+func TestTokenize_Offsets(t *testing.T) {
+	// Given: 英語と日本語が混在した文字列
+	text := "Buy 牛乳"
+
+	// When:  tokenize する
+	tokens := tokenize(text)
+
+	// Then:  start / end が元の文字列の該当部分を指す
+	want := []string{"Buy", "牛乳"}
+	if len(tokens) != len(want) {
+		t.Fatalf("Expected %d tokens, got %d", len(want), len(tokens))
+	}
+	for i, tok := range tokens {
+		if got := text[tok.start:tok.end]; got != want[i] {
+			t.Errorf("Expected %q at %d, got %q", want[i], i, got)
+		}
+	}
+}
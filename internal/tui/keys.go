@@ -0,0 +1,62 @@
+package tui
+
+import "unicode/utf8"
+
+type KeyType int
+
+const (
+	KeyRune KeyType = iota
+	KeyEnter
+	KeyEsc
+	KeyBackspace
+	KeyUp
+	KeyDown
+	KeyCtrlC
+	KeyUnknown
+)
+
+// Key は1回のキー入力。Type が KeyRune のときだけ Rune に文字が入る。
+type Key struct {
+	Type KeyType
+	Rune rune
+}
+
+// parseKeys は端末から1回で読んだバイト列をキー入力に分ける。
+// 単独の ESC と矢印キーなどのエスケープシーケンスは、同じ読み込みに続きがあるかどうかで区別する。
+func parseKeys(b []byte) []Key {
+	var keys []Key
+	for len(b) > 0 {
+		switch {
+		case b[0] == 0x1b && len(b) >= 3 && (b[1] == '[' || b[1] == 'O'):
+			switch b[2] {
+			case 'A':
+				keys = append(keys, Key{Type: KeyUp})
+			case 'B':
+				keys = append(keys, Key{Type: KeyDown})
+			default:
+				keys = append(keys, Key{Type: KeyUnknown})
+			}
+			b = b[3:]
+		case b[0] == 0x1b:
+			keys = append(keys, Key{Type: KeyEsc})
+			b = b[1:]
+		case b[0] == '\r' || b[0] == '\n':
+			keys = append(keys, Key{Type: KeyEnter})
+			b = b[1:]
+		case b[0] == 0x7f || b[0] == 0x08:
+			keys = append(keys, Key{Type: KeyBackspace})
+			b = b[1:]
+		case b[0] == 0x03:
+			keys = append(keys, Key{Type: KeyCtrlC})
+			b = b[1:]
+		case b[0] < 0x20:
+			keys = append(keys, Key{Type: KeyUnknown})
+			b = b[1:]
+		default:
+			r, size := utf8.DecodeRune(b)
+			keys = append(keys, Key{Type: KeyRune, Rune: r})
+			b = b[size:]
+		}
+	}
+	return keys
+}
@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Key
+	}{
+		{name: "runes", input: "aあ", want: []Key{{Type: KeyRune, Rune: 'a'}, {Type: KeyRune, Rune: 'あ'}}},
+		{name: "arrows", input: "\x1b[A\x1b[B", want: []Key{{Type: KeyUp}, {Type: KeyDown}}},
+		{name: "application mode arrows", input: "\x1bOA", want: []Key{{Type: KeyUp}}},
+		{name: "escape", input: "\x1b", want: []Key{{Type: KeyEsc}}},
+		{name: "control keys", input: "\r\x7f\x03", want: []Key{{Type: KeyEnter}, {Type: KeyBackspace}, {Type: KeyCtrlC}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseKeys([]byte(tt.input))
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package tui
+
+import (
+	"context"
+	"strings"
+
+	"github.com/k98a73/go-todo/pkg/client"
+)
+
+// Backend は TUI が操作する先。サーバーの API（*client.Client）か、todo コマンドのローカルモードのファイル。
+type Backend interface {
+	CreateTodo(ctx context.Context, title string) (*client.Todo, error)
+	ListTodos(ctx context.Context, opts client.ListOptions) ([]*client.Todo, error)
+	UpdateTodo(ctx context.Context, id int, title string, completed bool) (*client.Todo, error)
+	DeleteTodo(ctx context.Context, id int) error
+	ChangeStatus(ctx context.Context, id int, status string) (*client.Todo, error)
+}
+
+// Msg は Update に渡す出来事。キー入力・端末の大きさの変化・Cmd の結果がある。
+type Msg any
+
+// Cmd は Update が要求する副作用。Program が別の goroutine で実行し、結果の Msg を Update に渡す。
+type Cmd func() Msg
+
+type KeyMsg Key
+
+type ResizeMsg struct {
+	Width, Height int
+}
+
+// TickMsg は定期的な再読み込みの合図。他の端末やサーバーでの変更を反映する。
+type TickMsg struct{}
+
+type loadedMsg struct {
+	todos []*client.Todo
+	err   error
+}
+
+// savedMsg は変更の結果。成功しても失敗しても一覧を読み込み直す。
+type savedMsg struct {
+	err error
+}
+
+type mode int
+
+const (
+	modeList mode = iota
+	modeEdit
+	modeAdd
+	modeFilter
+)
+
+// Model は画面の状態。Update は Model を書き換えずに新しい Model を返す。
+type Model struct {
+	ctx     context.Context
+	backend Backend
+
+	todos  []*client.Todo
+	cursor int
+	mode   mode
+	input  []rune
+	filter string
+	status string
+	width  int
+	height int
+	quit   bool
+}
+
+func NewModel(ctx context.Context, backend Backend) Model {
+	return Model{ctx: ctx, backend: backend, width: 80, height: 24}
+}
+
+// Init は最初の一覧の読み込みを返す。
+func (m Model) Init() Cmd {
+	return m.load()
+}
+
+// Quitting は利用者が終了を選んだかを返す。
+func (m Model) Quitting() bool {
+	return m.quit
+}
+
+// visible は絞り込みに一致する Todo を返す。絞り込みはタイトルの大文字小文字を区別しない部分一致。
+func (m Model) visible() []*client.Todo {
+	if m.filter == "" {
+		return m.todos
+	}
+	filter := strings.ToLower(m.filter)
+	var todos []*client.Todo
+	for _, todo := range m.todos {
+		if strings.Contains(strings.ToLower(todo.Title), filter) {
+			todos = append(todos, todo)
+		}
+	}
+	return todos
+}
+
+func (m Model) selected() *client.Todo {
+	todos := m.visible()
+	if m.cursor < 0 || m.cursor >= len(todos) {
+		return nil
+	}
+	return todos[m.cursor]
+}
+
+// clamp は一覧の件数が変わった後でカーソルを範囲内に収める。
+func (m Model) clamp() Model {
+	if n := len(m.visible()); m.cursor >= n {
+		m.cursor = n - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	return m
+}
+
+func (m Model) Update(msg Msg) (Model, Cmd) {
+	switch msg := msg.(type) {
+	case KeyMsg:
+		return m.updateKey(Key(msg))
+	case ResizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case TickMsg:
+		return m, m.load()
+	case loadedMsg:
+		if msg.err != nil {
+			m.status = "Failed to load: " + msg.err.Error()
+			return m, nil
+		}
+		// 読み込み直しても同じ Todo を選んだままにする
+		var selectedID int
+		if todo := m.selected(); todo != nil {
+			selectedID = todo.ID
+		}
+		m.todos = msg.todos
+		for i, todo := range m.visible() {
+			if todo.ID == selectedID {
+				m.cursor = i
+			}
+		}
+		return m.clamp(), nil
+	case savedMsg:
+		if msg.err != nil {
+			m.status = "Error: " + msg.err.Error()
+		}
+		return m, m.load()
+	}
+	return m, nil
+}
+
+func (m Model) updateKey(key Key) (Model, Cmd) {
+	if key.Type == KeyCtrlC {
+		m.quit = true
+		return m, nil
+	}
+	if m.mode != modeList {
+		return m.updateInput(key)
+	}
+
+	m.status = ""
+	switch {
+	case key.Type == KeyUp || key.Rune == 'k':
+		m.cursor--
+		return m.clamp(), nil
+	case key.Type == KeyDown || key.Rune == 'j':
+		m.cursor++
+		return m.clamp(), nil
+	case key.Rune == 'q':
+		m.quit = true
+		return m, nil
+	case key.Rune == ' ' || key.Rune == 'x':
+		if todo := m.selected(); todo != nil {
+			return m, m.toggle(todo)
+		}
+	case key.Type == KeyEnter || key.Rune == 'e':
+		if todo := m.selected(); todo != nil {
+			m.mode = modeEdit
+			m.input = []rune(todo.Title)
+		}
+	case key.Rune == 'a':
+		m.mode = modeAdd
+		m.input = nil
+	case key.Rune == 'd':
+		if todo := m.selected(); todo != nil {
+			return m, m.remove(todo)
+		}
+	case key.Rune == '/':
+		m.mode = modeFilter
+		m.input = []rune(m.filter)
+	case key.Rune == 'r':
+		return m, m.load()
+	case key.Type == KeyEsc:
+		m.filter = ""
+		return m.clamp(), nil
+	}
+	return m, nil
+}
+
+// updateInput は入力欄を編集する。絞り込みは入力するたびに一覧に反映する。
+func (m Model) updateInput(key Key) (Model, Cmd) {
+	switch key.Type {
+	case KeyRune:
+		m.input = append(append([]rune(nil), m.input...), key.Rune)
+	case KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case KeyEsc:
+		if m.mode == modeFilter {
+			m.filter = ""
+		}
+		m.mode = modeList
+		m.input = nil
+		return m.clamp(), nil
+	case KeyEnter:
+		return m.submit()
+	}
+
+	if m.mode == modeFilter {
+		m.filter = string(m.input)
+		m.cursor = 0
+	}
+	return m, nil
+}
+
+func (m Model) submit() (Model, Cmd) {
+	input := strings.TrimSpace(string(m.input))
+	mode := m.mode
+	m.mode = modeList
+	m.input = nil
+
+	switch mode {
+	case modeEdit:
+		if todo := m.selected(); todo != nil && input != "" && input != todo.Title {
+			return m, m.edit(todo, input)
+		}
+	case modeAdd:
+		if input != "" {
+			return m, m.create(input)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) load() Cmd {
+	return func() Msg {
+		todos, err := m.backend.ListTodos(m.ctx, client.ListOptions{Sort: "position"})
+		return loadedMsg{todos: todos, err: err}
+	}
+}
+
+func (m Model) create(title string) Cmd {
+	return func() Msg {
+		_, err := m.backend.CreateTodo(m.ctx, title)
+		return savedMsg{err: err}
+	}
+}
+
+func (m Model) edit(todo *client.Todo, title string) Cmd {
+	id, completed := todo.ID, todo.Completed
+	return func() Msg {
+		_, err := m.backend.UpdateTodo(m.ctx, id, title, completed)
+		return savedMsg{err: err}
+	}
+}
+
+// toggle は完了していれば未着手に戻し、それ以外は完了にする。
+func (m Model) toggle(todo *client.Todo) Cmd {
+	id, status := todo.ID, "done"
+	if todo.Completed {
+		status = "todo"
+	}
+	return func() Msg {
+		_, err := m.backend.ChangeStatus(m.ctx, id, status)
+		return savedMsg{err: err}
+	}
+}
+
+func (m Model) remove(todo *client.Todo) Cmd {
+	id := todo.ID
+	return func() Msg {
+		return savedMsg{err: m.backend.DeleteTodo(m.ctx, id)}
+	}
+}
@@ -0,0 +1,208 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/pkg/client"
+)
+
+type fakeBackend struct {
+	todos   []*client.Todo
+	calls   []string
+	failErr error
+}
+
+func (f *fakeBackend) CreateTodo(ctx context.Context, title string) (*client.Todo, error) {
+	f.calls = append(f.calls, "create "+title)
+	return &client.Todo{}, f.failErr
+}
+
+func (f *fakeBackend) ListTodos(ctx context.Context, opts client.ListOptions) ([]*client.Todo, error) {
+	return f.todos, nil
+}
+
+func (f *fakeBackend) UpdateTodo(ctx context.Context, id int, title string, completed bool) (*client.Todo, error) {
+	f.calls = append(f.calls, "update "+title)
+	return &client.Todo{}, f.failErr
+}
+
+func (f *fakeBackend) DeleteTodo(ctx context.Context, id int) error {
+	f.calls = append(f.calls, "delete")
+	return f.failErr
+}
+
+func (f *fakeBackend) ChangeStatus(ctx context.Context, id int, status string) (*client.Todo, error) {
+	f.calls = append(f.calls, "status "+status)
+	return &client.Todo{}, f.failErr
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{todos: []*client.Todo{
+		{ID: 1, Title: "Buy milk", Status: "todo"},
+		{ID: 2, Title: "Read book", Status: "done", Completed: true},
+		{ID: 3, Title: "Buy bread", Status: "todo"},
+	}}
+}
+
+// loadedModel は初期の読み込みを済ませた Model を返す。
+func loadedModel(t *testing.T, backend Backend) Model {
+	t.Helper()
+	m := NewModel(context.Background(), backend)
+	m, _ = m.Update(m.Init()())
+	return m
+}
+
+// typeKeys は keys を順に入力し、最後に返った Cmd を返す。
+func typeKeys(m Model, keys ...Key) (Model, Cmd) {
+	var cmd Cmd
+	for _, key := range keys {
+		m, cmd = m.Update(KeyMsg(key))
+	}
+	return m, cmd
+}
+
+func runes(s string) []Key {
+	var keys []Key
+	for _, r := range s {
+		keys = append(keys, Key{Type: KeyRune, Rune: r})
+	}
+	return keys
+}
+
+func TestModel_Navigate(t *testing.T) {
+	// Given: 3件の Todo
+	m := loadedModel(t, newFakeBackend())
+
+	// When:  下に3回、上に1回移動する
+	m, _ = typeKeys(m, Key{Type: KeyDown}, Key{Type: KeyRune, Rune: 'j'}, Key{Type: KeyDown}, Key{Type: KeyUp})
+
+	// Then:  末尾で止まり、2件目が選ばれる
+	if m.selected().ID != 2 {
+		t.Errorf("Expected todo 2, got %d", m.selected().ID)
+	}
+}
+
+func TestModel_Toggle(t *testing.T) {
+	// Given: 完了した Todo を選んだ状態
+	backend := newFakeBackend()
+	m := loadedModel(t, backend)
+	m, _ = typeKeys(m, Key{Type: KeyDown})
+
+	// When:  space を押し、返った Cmd を実行する
+	m, cmd := typeKeys(m, Key{Type: KeyRune, Rune: ' '})
+	m, reload := m.Update(cmd())
+
+	// Then:  未着手に戻し、一覧を読み込み直す
+	if len(backend.calls) != 1 || backend.calls[0] != "status todo" {
+		t.Errorf("Unexpected calls: %v", backend.calls)
+	}
+	if reload == nil {
+		t.Error("Expected reload command")
+	}
+}
+
+func TestModel_InlineEdit(t *testing.T) {
+	// Given: 1件目を選んだ状態
+	backend := newFakeBackend()
+	m := loadedModel(t, backend)
+
+	// When:  e で編集を始め、末尾の2文字を消して入力し直して確定する
+	m, _ = typeKeys(m, Key{Type: KeyRune, Rune: 'e'}, Key{Type: KeyBackspace}, Key{Type: KeyBackspace}, Key{Type: KeyBackspace}, Key{Type: KeyBackspace})
+	keys := append(runes("oat milk"), Key{Type: KeyEnter})
+	m, cmd := typeKeys(m, keys...)
+	cmd()
+
+	// Then:  新しいタイトルで更新し、一覧の表示に戻る
+	if len(backend.calls) != 1 || backend.calls[0] != "update Buy oat milk" {
+		t.Errorf("Unexpected calls: %v", backend.calls)
+	}
+	if m.mode != modeList {
+		t.Errorf("Expected list mode, got %d", m.mode)
+	}
+}
+
+func TestModel_EditCancel(t *testing.T) {
+	// Given: 編集中の状態
+	backend := newFakeBackend()
+	m := loadedModel(t, backend)
+	m, _ = typeKeys(m, Key{Type: KeyRune, Rune: 'e'}, Key{Type: KeyRune, Rune: '!'})
+
+	// When:  esc を押す
+	m, cmd := typeKeys(m, Key{Type: KeyEsc})
+
+	// Then:  何も保存せずに一覧の表示に戻る
+	if cmd != nil || m.mode != modeList {
+		t.Errorf("Expected cancel, got mode %d and cmd %v", m.mode, cmd != nil)
+	}
+}
+
+func TestModel_FilterAsYouType(t *testing.T) {
+	// Given: 3件の Todo
+	m := loadedModel(t, newFakeBackend())
+
+	// When:  / の後に1文字ずつ入力する
+	m, _ = typeKeys(m, append([]Key{{Type: KeyRune, Rune: '/'}}, runes("bu")...)...)
+
+	// Then:  入力するたびに絞り込まれる
+	if got := len(m.visible()); got != 2 {
+		t.Errorf("Expected 2 todos for 'bu', got %d", got)
+	}
+	m, _ = typeKeys(m, runes("y b")...)
+	if got := m.visible(); len(got) != 1 || got[0].ID != 3 {
+		t.Errorf("Expected only todo 3 for 'buy b', got %+v", got)
+	}
+
+	// When:  esc を押す
+	m, _ = typeKeys(m, Key{Type: KeyEsc})
+
+	// Then:  絞り込みが解除される
+	if got := len(m.visible()); got != 3 {
+		t.Errorf("Expected 3 todos, got %d", got)
+	}
+}
+
+func TestModel_AddAndError(t *testing.T) {
+	// Given: 保存に失敗するバックエンド
+	backend := newFakeBackend()
+	backend.failErr = errors.New("409 Conflict")
+	m := loadedModel(t, backend)
+
+	// When:  a で追加し、結果を受け取る
+	keys := append(append([]Key{{Type: KeyRune, Rune: 'a'}}, runes("Call mom")...), Key{Type: KeyEnter})
+	m, cmd := typeKeys(m, keys...)
+	m, _ = m.Update(cmd())
+
+	// Then:  エラーが表示される
+	if backend.calls[0] != "create Call mom" || m.status != "Error: 409 Conflict" {
+		t.Errorf("Unexpected calls %v and status %q", backend.calls, m.status)
+	}
+}
+
+func TestModel_RefreshKeepsSelection(t *testing.T) {
+	// Given: 3件目を選んだ状態
+	backend := newFakeBackend()
+	m := loadedModel(t, backend)
+	m, _ = typeKeys(m, Key{Type: KeyDown}, Key{Type: KeyDown})
+
+	// When:  他の端末で先頭の Todo が削除され、定期的な再読み込みが行われる
+	backend.todos = backend.todos[1:]
+	m, cmd := m.Update(TickMsg{})
+	m, _ = m.Update(cmd())
+
+	// Then:  同じ Todo を選んだまま
+	if m.selected().ID != 3 {
+		t.Errorf("Expected todo 3 to stay selected, got %d", m.selected().ID)
+	}
+}
+
+func TestModel_Quit(t *testing.T) {
+	m := loadedModel(t, newFakeBackend())
+
+	m, _ = typeKeys(m, Key{Type: KeyRune, Rune: 'q'})
+
+	if !m.Quitting() {
+		t.Error("Expected to quit")
+	}
+}
@@ -0,0 +1,82 @@
+// Package tui は todo コマンドの全画面の端末 UI。
+// 状態（Model）・状態の更新（Update）・描画（View）を分け、端末の入出力は Program だけが扱う。
+package tui
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// DefaultRefresh は一覧を読み込み直す間隔。
+const DefaultRefresh = 2 * time.Second
+
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+)
+
+// Run は in を raw モードにして TUI を表示し、利用者が終了するか ctx が終了するまで続ける。
+func Run(ctx context.Context, backend Backend, in *os.File, out io.Writer, refresh time.Duration) error {
+	fd := int(in.Fd())
+	restore, err := makeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	io.WriteString(out, enterAltScreen)
+	defer io.WriteString(out, leaveAltScreen)
+
+	msgs := make(chan Msg, 16)
+	go readKeys(in, msgs)
+
+	ticker := time.NewTicker(refresh)
+	defer ticker.Stop()
+
+	m := NewModel(ctx, backend)
+	if width, height, err := terminalSize(fd); err == nil {
+		m, _ = m.Update(ResizeMsg{Width: width, Height: height})
+	}
+	cmd := m.Init()
+	for {
+		if cmd != nil {
+			go func(cmd Cmd) { msgs <- cmd() }(cmd)
+		}
+		io.WriteString(out, clearScreen+m.View())
+
+		var msg Msg
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg = <-msgs:
+		case <-ticker.C:
+			// SIGWINCH を扱わない代わりに、定期的に端末の大きさも確かめる
+			if width, height, err := terminalSize(fd); err == nil && (width != m.width || height != m.height) {
+				m, _ = m.Update(ResizeMsg{Width: width, Height: height})
+			}
+			msg = TickMsg{}
+		}
+
+		m, cmd = m.Update(msg)
+		if m.Quitting() {
+			return nil
+		}
+	}
+}
+
+// readKeys は in から読んだキー入力を msgs に送る。読み込みが失敗したら終了する。
+func readKeys(in io.Reader, msgs chan<- Msg) {
+	buf := make([]byte, 64)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			msgs <- KeyMsg(key)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package tui
+
+import "errors"
+
+var errUnsupported = errors.New("terminal UI is not supported on this platform")
+
+func makeRaw(fd int) (func(), error) {
+	return nil, errUnsupported
+}
+
+func terminalSize(fd int) (width, height int, err error) {
+	return 0, 0, errUnsupported
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ansiReverse = "\x1b[7m"
+	ansiDim     = "\x1b[2m"
+	ansiBold    = "\x1b[1m"
+	ansiReset   = "\x1b[0m"
+
+	// headerLines と footerLines は一覧以外に使う行数。
+	headerLines = 2
+	footerLines = 2
+)
+
+const helpText = "↑/↓ move  space toggle  e edit  a add  d delete  / filter  r reload  q quit"
+
+// View は画面全体を描画した文字列を返す。端末は raw モードのため、改行は \r\n で出力する。
+func (m Model) View() string {
+	var lines []string
+
+	todos := m.visible()
+	title := fmt.Sprintf("Todos (%d/%d)", len(todos), len(m.todos))
+	if m.filter != "" {
+		title += "  filter: " + m.filter
+	}
+	lines = append(lines, ansiBold+truncate(title, m.width)+ansiReset, "")
+
+	rows := m.height - headerLines - footerLines
+	if rows < 1 {
+		rows = 1
+	}
+	// カーソルが常に見えるように表示する範囲をずらす
+	start := 0
+	if m.cursor >= rows {
+		start = m.cursor - rows + 1
+	}
+	for i := start; i < len(todos) && i < start+rows; i++ {
+		todo := todos[i]
+		check := " "
+		if todo.Completed {
+			check = "x"
+		}
+		line := fmt.Sprintf("[%s] %-4d %s", check, todo.ID, todo.Title)
+		if i == m.cursor && m.mode == modeEdit {
+			line = fmt.Sprintf("[%s] %-4d %s", check, todo.ID, string(m.input)+"_")
+		}
+		line = truncate(line, m.width)
+		switch {
+		case i == m.cursor:
+			line = ansiReverse + line + ansiReset
+		case todo.Status == "cancelled":
+			line = ansiDim + line + ansiReset
+		}
+		lines = append(lines, line)
+	}
+	if len(todos) == 0 {
+		lines = append(lines, ansiDim+"(no todos)"+ansiReset)
+	}
+	for len(lines) < headerLines+rows {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, truncate(m.statusLine(), m.width), ansiDim+truncate(helpText, m.width)+ansiReset)
+	return strings.Join(lines, "\r\n")
+}
+
+// statusLine は入力中なら入力欄を、そうでなければ直前のエラーを返す。
+func (m Model) statusLine() string {
+	switch m.mode {
+	case modeAdd:
+		return "New todo: " + string(m.input) + "_"
+	case modeFilter:
+		return "/" + string(m.input) + "_"
+	case modeEdit:
+		return "Editing (enter to save, esc to cancel)"
+	}
+	return m.status
+}
+
+// truncate は表示幅が width を超えないよう文字数で切り詰める。全角文字の幅は考慮しない。
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width])
+}
@@ -0,0 +1,47 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestModel_View(t *testing.T) {
+	// Given: 3件の Todo と 7行の端末
+	m := loadedModel(t, newFakeBackend())
+	m, _ = m.Update(ResizeMsg{Width: 40, Height: 6})
+
+	// When:  2件目まで移動して描画する
+	m, _ = typeKeys(m, Key{Type: KeyDown}, Key{Type: KeyDown})
+	lines := strings.Split(m.View(), "\r\n")
+
+	// Then:  端末の高さに収まるようにスクロールし、選んだ行が反転表示される
+	if len(lines) != 6 {
+		t.Fatalf("Expected 6 lines, got %d: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "Todos (3/3)") {
+		t.Errorf("Unexpected header: %q", lines[0])
+	}
+	if lines[2] != "[x] 2    Read book" {
+		t.Errorf("Unexpected row: %q", lines[2])
+	}
+	if lines[3] != ansiReverse+"[ ] 3    Buy bread"+ansiReset {
+		t.Errorf("Expected selected row, got %q", lines[3])
+	}
+}
+
+func TestModel_View_Input(t *testing.T) {
+	m := loadedModel(t, newFakeBackend())
+
+	m, _ = typeKeys(m, append([]Key{{Type: KeyRune, Rune: '/'}}, runes("milk")...)...)
+	view := m.View()
+
+	if !strings.Contains(view, "Todos (1/3)  filter: milk") || !strings.Contains(view, "/milk_") {
+		t.Errorf("Unexpected view: %q", view)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("あいうえお", 3); got != "あいう" {
+		t.Errorf("Expected あいう, got %q", got)
+	}
+}
@@ -0,0 +1,214 @@
+package undo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+const (
+	DefaultLimit = 50
+
+	// maxSessions を超えたら最も長く使われていないセッションの履歴を捨てる。
+	maxSessions = 1000
+)
+
+// UndoableRepository は repo への変更をセッションごとに記録し、取り消し・やり直しができるようにするデコレータ。
+// 1回の Create / Update / Delete、または1回の WithinTx を1つの操作として扱う。
+// セッションが設定されていない ctx（バックグラウンドジョブなど）の変更は記録しない。
+type UndoableRepository struct {
+	domain.IRepository
+	limit int
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	clock    int64
+}
+
+// session の undo / redo は古い順に並んだ操作のスタック。
+type session struct {
+	undo     [][]domain.Change
+	redo     [][]domain.Change
+	lastUsed int64
+}
+
+func NewUndoableRepository(repo domain.IRepository, limit int) *UndoableRepository {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	return &UndoableRepository{
+		IRepository: repo,
+		limit:       limit,
+		sessions:    make(map[string]*session),
+	}
+}
+
+func (r *UndoableRepository) Create(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Create(ctx, todo)
+	})
+}
+
+func (r *UndoableRepository) Insert(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Insert(ctx, todo)
+	})
+}
+
+func (r *UndoableRepository) Update(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Update(ctx, todo)
+	})
+}
+
+func (r *UndoableRepository) Delete(ctx context.Context, id int) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Delete(ctx, id)
+	})
+}
+
+// WithinTx は fn 内の変更を記録し、コミットに成功した場合だけ1つの操作として積む。
+func (r *UndoableRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	name := domain.SessionFromContext(ctx)
+	if name == "" {
+		return r.IRepository.WithinTx(ctx, fn)
+	}
+
+	var rec *domain.ChangeRecorder
+	err := r.IRepository.WithinTx(ctx, func(tx domain.IRepository) error {
+		rec = domain.NewChangeRecorder(tx)
+		return fn(rec)
+	})
+	if err != nil {
+		return err
+	}
+
+	if changes := rec.Changes(); len(changes) > 0 {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		s := r.session(name)
+		s.undo = r.push(s.undo, changes)
+		s.redo = nil
+	}
+	return nil
+}
+
+// Undo はセッションで最後に行った操作を取り消す。
+// 対象の Todo がその後に変更されていた場合は ErrUndoConflict を返し、その操作は取り消せないものとして捨てる。
+func (r *UndoableRepository) Undo(ctx context.Context) ([]domain.Change, error) {
+	return r.replay(ctx, domain.ErrNothingToUndo, func(s *session) *[][]domain.Change { return &s.undo }, func(s *session) *[][]domain.Change { return &s.redo }, invert)
+}
+
+// Redo は最後に取り消した操作をやり直す。新たな変更を行うとやり直せる操作はなくなる。
+func (r *UndoableRepository) Redo(ctx context.Context) ([]domain.Change, error) {
+	return r.replay(ctx, domain.ErrNothingToRedo, func(s *session) *[][]domain.Change { return &s.redo }, func(s *session) *[][]domain.Change { return &s.undo }, forward)
+}
+
+// replay は from の最後の操作を direction の向きに適用し、成功したら to に移す。
+// 競合した操作は二度と適用できないため捨てるが、それ以外のエラーでは from に戻す。
+func (r *UndoableRepository) replay(ctx context.Context, empty error, from, to func(s *session) *[][]domain.Change, direction func([]domain.Change) []domain.Change) ([]domain.Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.sessions[domain.SessionFromContext(ctx)]
+	if !ok || len(*from(s)) == 0 {
+		return nil, empty
+	}
+	stack := from(s)
+	changes := (*stack)[len(*stack)-1]
+	*stack = (*stack)[:len(*stack)-1]
+
+	applied := direction(changes)
+	if err := r.apply(ctx, applied); err != nil {
+		if !errors.Is(err, domain.ErrUndoConflict) {
+			*stack = append(*stack, changes)
+		}
+		return nil, err
+	}
+	*to(s) = r.push(*to(s), changes)
+	s.lastUsed = r.tick()
+	return applied, nil
+}
+
+// apply は各 Todo が Before の状態のままであることを確かめてから After の状態にする。記録はしない。
+func (r *UndoableRepository) apply(ctx context.Context, changes []domain.Change) error {
+	return r.IRepository.WithinTx(ctx, func(tx domain.IRepository) error {
+		for _, c := range changes {
+			current, err := tx.FindByID(ctx, c.ID)
+			if errors.Is(err, domain.ErrTodoNotFound) {
+				current = nil
+			} else if err != nil {
+				return err
+			}
+			if !domain.SameTodo(current, c.Before) {
+				return domain.ErrUndoConflict
+			}
+
+			switch {
+			case c.After == nil:
+				err = tx.Delete(ctx, c.ID)
+			case current == nil:
+				err = tx.Insert(ctx, c.After.Clone())
+			default:
+				err = tx.Update(ctx, c.After.Clone())
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// push は上限を超えた古い操作を捨てながら積む。
+func (r *UndoableRepository) push(stack [][]domain.Change, changes []domain.Change) [][]domain.Change {
+	stack = append(stack, changes)
+	if len(stack) > r.limit {
+		stack = stack[len(stack)-r.limit:]
+	}
+	return stack
+}
+
+func (r *UndoableRepository) session(name string) *session {
+	s, ok := r.sessions[name]
+	if !ok {
+		if len(r.sessions) >= maxSessions {
+			r.evict()
+		}
+		s = &session{}
+		r.sessions[name] = s
+	}
+	s.lastUsed = r.tick()
+	return s
+}
+
+func (r *UndoableRepository) evict() {
+	oldest := ""
+	for name, s := range r.sessions {
+		if oldest == "" || s.lastUsed < r.sessions[oldest].lastUsed {
+			oldest = name
+		}
+	}
+	delete(r.sessions, oldest)
+}
+
+func (r *UndoableRepository) tick() int64 {
+	r.clock++
+	return r.clock
+}
+
+// invert は取り消すための変更を返す。後に行った変更から順に戻す。
+func invert(changes []domain.Change) []domain.Change {
+	inverted := make([]domain.Change, len(changes))
+	for i, c := range changes {
+		inverted[len(changes)-1-i] = domain.Change{ID: c.ID, Before: c.After, After: c.Before}
+	}
+	return inverted
+}
+
+func forward(changes []domain.Change) []domain.Change {
+	return changes
+}
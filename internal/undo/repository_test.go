@@ -0,0 +1,385 @@
+package undo
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+	"github.com/k98a73/go-todo/internal/usecase"
+)
+
+func newTestRepository(t *testing.T, limit int) *UndoableRepository {
+	t.Helper()
+	return NewUndoableRepository(storage.NewFileRepository(filepath.Join(t.TempDir(), "todos.json")), limit)
+}
+
+func createTodo(t *testing.T, ctx context.Context, repo *UndoableRepository, title string) *domain.Todo {
+	t.Helper()
+	todo := &domain.Todo{Title: title, Status: domain.StatusTodo}
+	if err := repo.Create(ctx, todo); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	return todo
+}
+
+func updateTitle(t *testing.T, ctx context.Context, repo *UndoableRepository, id int, title string) {
+	t.Helper()
+	todo, err := repo.FindByID(ctx, id)
+	if err != nil {
+		t.Fatalf("Failed to find: %v", err)
+	}
+	todo.Title = title
+	if err := repo.Update(ctx, todo); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+}
+
+func titleOf(t *testing.T, repo *UndoableRepository, id int) string {
+	t.Helper()
+	todo, err := repo.FindByID(context.Background(), id)
+	if errors.Is(err, domain.ErrTodoNotFound) {
+		return ""
+	}
+	if err != nil {
+		t.Fatalf("Failed to find: %v", err)
+	}
+	return todo.Title
+}
+
+func TestUndoableRepository_UndoRedo(t *testing.T) {
+	// Given: 作成・更新・削除を順に行ったセッション
+	repo := newTestRepository(t, 0)
+	ctx := domain.WithSession(context.Background(), "alice")
+	todo := createTodo(t, ctx, repo, "Buy milk")
+	updateTitle(t, ctx, repo, todo.ID, "Buy oat milk")
+	if err := repo.Delete(ctx, todo.ID); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+
+	// When:  新しいものから順に取り消す
+	// Then:  削除・更新・作成の順に元に戻る
+	steps := []string{"Buy oat milk", "Buy milk", ""}
+	for _, want := range steps {
+		if _, err := repo.Undo(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got := titleOf(t, repo, todo.ID); got != want {
+			t.Errorf("Expected title '%s' after undo, got '%s'", want, got)
+		}
+	}
+	if _, err := repo.Undo(ctx); !errors.Is(err, domain.ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo, got %v", err)
+	}
+
+	// When:  やり直す
+	// Then:  作成・更新・削除の順にもう一度適用される
+	for _, want := range []string{"Buy milk", "Buy oat milk", ""} {
+		if _, err := repo.Redo(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got := titleOf(t, repo, todo.ID); got != want {
+			t.Errorf("Expected title '%s' after redo, got '%s'", want, got)
+		}
+	}
+	if _, err := repo.Redo(ctx); !errors.Is(err, domain.ErrNothingToRedo) {
+		t.Errorf("Expected ErrNothingToRedo, got %v", err)
+	}
+}
+
+func TestUndoableRepository_UndoReturnsChanges(t *testing.T) {
+	// Given: タイトルを変更したセッション
+	repo := newTestRepository(t, 0)
+	ctx := domain.WithSession(context.Background(), "alice")
+	todo := createTodo(t, ctx, repo, "Buy milk")
+	updateTitle(t, ctx, repo, todo.ID, "Buy eggs")
+
+	// When:  取り消す
+	changes, err := repo.Undo(ctx)
+
+	// Then:  変更前後の状態が返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(changes) != 1 || changes[0].ID != todo.ID || changes[0].Before.Title != "Buy eggs" || changes[0].After.Title != "Buy milk" {
+		t.Errorf("Unexpected changes: %+v", changes)
+	}
+}
+
+func TestUndoableRepository_InterleavedSessions(t *testing.T) {
+	// Given: 2つのセッションがそれぞれ別の Todo を交互に編集している
+	repo := newTestRepository(t, 0)
+	alice := domain.WithSession(context.Background(), "alice")
+	bob := domain.WithSession(context.Background(), "bob")
+	a := createTodo(t, alice, repo, "Alice 1")
+	b := createTodo(t, bob, repo, "Bob 1")
+	updateTitle(t, alice, repo, a.ID, "Alice 2")
+	updateTitle(t, bob, repo, b.ID, "Bob 2")
+	updateTitle(t, alice, repo, a.ID, "Alice 3")
+
+	// When:  alice が2回取り消す
+	for i := 0; i < 2; i++ {
+		if _, err := repo.Undo(alice); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// Then:  alice の変更だけが取り消され、bob の変更は残る
+	if got := titleOf(t, repo, a.ID); got != "Alice 1" {
+		t.Errorf("Expected 'Alice 1', got '%s'", got)
+	}
+	if got := titleOf(t, repo, b.ID); got != "Bob 2" {
+		t.Errorf("Expected 'Bob 2', got '%s'", got)
+	}
+
+	// When:  bob が取り消す
+	if _, err := repo.Undo(bob); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then:  bob の直前の変更だけが取り消される
+	if got := titleOf(t, repo, b.ID); got != "Bob 1" {
+		t.Errorf("Expected 'Bob 1', got '%s'", got)
+	}
+	if got := titleOf(t, repo, a.ID); got != "Alice 1" {
+		t.Errorf("Expected 'Alice 1', got '%s'", got)
+	}
+}
+
+func TestUndoableRepository_Conflict(t *testing.T) {
+	// Given: alice が更新した Todo を、その後 bob が更新した
+	repo := newTestRepository(t, 0)
+	alice := domain.WithSession(context.Background(), "alice")
+	bob := domain.WithSession(context.Background(), "bob")
+	todo := createTodo(t, alice, repo, "Buy milk")
+	updateTitle(t, alice, repo, todo.ID, "Buy oat milk")
+	updateTitle(t, bob, repo, todo.ID, "Buy soy milk")
+
+	// When:  alice が取り消す
+	_, err := repo.Undo(alice)
+
+	// Then:  競合となり、bob の変更は上書きされない
+	if !errors.Is(err, domain.ErrUndoConflict) {
+		t.Fatalf("Expected ErrUndoConflict, got %v", err)
+	}
+	if got := titleOf(t, repo, todo.ID); got != "Buy soy milk" {
+		t.Errorf("Expected 'Buy soy milk', got '%s'", got)
+	}
+
+	// When:  alice がもう一度取り消す
+	// Then:  競合した操作は捨てられ、その前の作成の取り消しも競合する
+	if _, err := repo.Undo(alice); !errors.Is(err, domain.ErrUndoConflict) {
+		t.Errorf("Expected ErrUndoConflict, got %v", err)
+	}
+	if _, err := repo.Undo(alice); !errors.Is(err, domain.ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo, got %v", err)
+	}
+}
+
+func TestUndoableRepository_RedoConflict(t *testing.T) {
+	// Given: alice が取り消した後に、bob が同じ Todo を更新した
+	repo := newTestRepository(t, 0)
+	alice := domain.WithSession(context.Background(), "alice")
+	bob := domain.WithSession(context.Background(), "bob")
+	todo := createTodo(t, alice, repo, "Buy milk")
+	updateTitle(t, alice, repo, todo.ID, "Buy oat milk")
+	if _, err := repo.Undo(alice); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	updateTitle(t, bob, repo, todo.ID, "Buy soy milk")
+
+	// When:  alice がやり直す
+	_, err := repo.Redo(alice)
+
+	// Then:  競合となる
+	if !errors.Is(err, domain.ErrUndoConflict) {
+		t.Errorf("Expected ErrUndoConflict, got %v", err)
+	}
+	if got := titleOf(t, repo, todo.ID); got != "Buy soy milk" {
+		t.Errorf("Expected 'Buy soy milk', got '%s'", got)
+	}
+}
+
+func TestUndoableRepository_NewChangeClearsRedo(t *testing.T) {
+	// Given: 取り消した操作がある
+	repo := newTestRepository(t, 0)
+	ctx := domain.WithSession(context.Background(), "alice")
+	todo := createTodo(t, ctx, repo, "Buy milk")
+	updateTitle(t, ctx, repo, todo.ID, "Buy oat milk")
+	if _, err := repo.Undo(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// When:  新たに変更する
+	updateTitle(t, ctx, repo, todo.ID, "Buy eggs")
+
+	// Then:  やり直せる操作はなくなる
+	if _, err := repo.Redo(ctx); !errors.Is(err, domain.ErrNothingToRedo) {
+		t.Errorf("Expected ErrNothingToRedo, got %v", err)
+	}
+}
+
+func TestUndoableRepository_Limit(t *testing.T) {
+	// Given: 上限2件で3回変更したセッション
+	repo := newTestRepository(t, 2)
+	ctx := domain.WithSession(context.Background(), "alice")
+	todo := createTodo(t, ctx, repo, "v1")
+	updateTitle(t, ctx, repo, todo.ID, "v2")
+	updateTitle(t, ctx, repo, todo.ID, "v3")
+
+	// When:  取り消せるだけ取り消す
+	for i := 0; i < 2; i++ {
+		if _, err := repo.Undo(ctx); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	_, err := repo.Undo(ctx)
+
+	// Then:  新しい2件だけが取り消され、最も古い作成は残る
+	if !errors.Is(err, domain.ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo, got %v", err)
+	}
+	if got := titleOf(t, repo, todo.ID); got != "v1" {
+		t.Errorf("Expected 'v1', got '%s'", got)
+	}
+}
+
+func TestUndoableRepository_WithinTx(t *testing.T) {
+	// Given: 1つのトランザクションで2件作成し、1件を更新した
+	repo := newTestRepository(t, 0)
+	ctx := domain.WithSession(context.Background(), "alice")
+	first := &domain.Todo{Title: "First", Status: domain.StatusTodo}
+	second := &domain.Todo{Title: "Second", Status: domain.StatusTodo}
+	err := repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		if err := tx.Create(ctx, first); err != nil {
+			return err
+		}
+		if err := tx.Create(ctx, second); err != nil {
+			return err
+		}
+		first.Title = "First updated"
+		return tx.Update(ctx, first)
+	})
+	if err != nil {
+		t.Fatalf("Failed to run tx: %v", err)
+	}
+
+	// When:  1回取り消す
+	changes, err := repo.Undo(ctx)
+
+	// Then:  トランザクション内の変更がまとめて取り消される
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(changes) != 2 {
+		t.Errorf("Expected 2 changes, got %d", len(changes))
+	}
+	todos, _ := repo.List(ctx)
+	if len(todos) != 0 {
+		t.Errorf("Expected no todos, got %d", len(todos))
+	}
+}
+
+func TestUndoableRepository_FailedTxNotRecorded(t *testing.T) {
+	// Given: エラーで終わったトランザクション
+	repo := newTestRepository(t, 0)
+	ctx := domain.WithSession(context.Background(), "alice")
+	_ = repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		if err := tx.Create(ctx, &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}); err != nil {
+			return err
+		}
+		return errors.New("failed")
+	})
+
+	// When:  取り消す
+	_, err := repo.Undo(ctx)
+
+	// Then:  取り消せる操作はない
+	if !errors.Is(err, domain.ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo, got %v", err)
+	}
+}
+
+func TestUndoableRepository_NoSession(t *testing.T) {
+	// Given: セッションのない ctx での変更
+	repo := newTestRepository(t, 0)
+	createTodo(t, context.Background(), repo, "Buy milk")
+
+	// When:  取り消す
+	_, err := repo.Undo(context.Background())
+
+	// Then:  記録されていないため取り消せない
+	if !errors.Is(err, domain.ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo, got %v", err)
+	}
+}
+
+func newArchiveTest(t *testing.T) (*UndoableRepository, *storage.FileRepository, *usecase.ArchiveTodoUsecase, *usecase.UnarchiveTodoUsecase) {
+	t.Helper()
+	repo := newTestRepository(t, 0)
+	archive := storage.NewFileRepository(filepath.Join(t.TempDir(), "archive.json"))
+	return repo, archive, usecase.NewArchiveTodoUsecase(repo, archive), usecase.NewUnarchiveTodoUsecase(repo, archive)
+}
+
+func TestUndoableRepository_UndoAfterArchive(t *testing.T) {
+	// Given: 作成して完了にした Todo をアーカイブしたセッション
+	repo, archive, archiveTodo, unarchiveTodo := newArchiveTest(t)
+	ctx := domain.WithSession(context.Background(), "alice")
+	todo := createTodo(t, ctx, repo, "Buy milk")
+	todo.Status = domain.StatusDone
+	if err := repo.Update(ctx, todo); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	if _, err := archiveTodo.Execute(ctx, todo.ID); err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+
+	// When:  取り消す
+	_, err := repo.Undo(ctx)
+
+	// Then:  アーカイブは取り消しの対象にならず、Todo はアーカイブにだけあり、元に戻せる
+	if !errors.Is(err, domain.ErrUndoConflict) {
+		t.Errorf("Expected ErrUndoConflict, got %v", err)
+	}
+	if title := titleOf(t, repo, todo.ID); title != "" {
+		t.Errorf("Expected todo not to be restored to the list, got %q", title)
+	}
+	if _, err := unarchiveTodo.Execute(ctx, todo.ID); err != nil {
+		t.Errorf("Expected unarchive to succeed, got %v", err)
+	}
+	if todos, _ := archive.List(ctx); len(todos) != 0 {
+		t.Errorf("Expected archive to be empty, got %d", len(todos))
+	}
+}
+
+func TestUndoableRepository_UndoAfterUnarchive(t *testing.T) {
+	// Given: アーカイブした Todo を戻したセッション
+	repo, archive, archiveTodo, unarchiveTodo := newArchiveTest(t)
+	ctx := domain.WithSession(context.Background(), "alice")
+	todo := &domain.Todo{Title: "Buy milk", Status: domain.StatusDone}
+	if err := repo.Create(context.Background(), todo); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	if _, err := archiveTodo.Execute(ctx, todo.ID); err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+	if _, err := unarchiveTodo.Execute(ctx, todo.ID); err != nil {
+		t.Fatalf("Failed to unarchive: %v", err)
+	}
+
+	// When:  取り消す
+	_, err := repo.Undo(ctx)
+
+	// Then:  戻した操作は取り消しの対象にならず、Todo は一覧に残る
+	if !errors.Is(err, domain.ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo, got %v", err)
+	}
+	if title := titleOf(t, repo, todo.ID); title != "Buy milk" {
+		t.Errorf("Expected todo to remain in the list, got %q", title)
+	}
+	if todos, _ := archive.List(ctx); len(todos) != 0 {
+		t.Errorf("Expected archive to be empty, got %d", len(todos))
+	}
+}
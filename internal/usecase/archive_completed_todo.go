@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ArchiveCompletedTodoUsecase struct {
+	repo    domain.IRepository
+	archive domain.IRepository
+	after   time.Duration
+}
+
+func NewArchiveCompletedTodoUsecase(repo domain.IRepository, archive domain.IRepository, after time.Duration) *ArchiveCompletedTodoUsecase {
+	return &ArchiveCompletedTodoUsecase{repo: repo, archive: archive, after: after}
+}
+
+// Execute は完了から after 以上経過した Todo をアーカイブし、件数を返す。
+// 元のリポジトリからの削除は1つのトランザクションで行い、失敗した場合は元に残っている Todo のアーカイブへの追加を取り消す。
+func (u *ArchiveCompletedTodoUsecase) Execute(ctx context.Context, now time.Time) (int, error) {
+	threshold := now.Add(-u.after)
+	var inserted []int
+	err := u.repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		todos, err := tx.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, todo := range todos {
+			if todo.IsDeleted() || !todo.IsCompleted() || todo.CompletedAt == nil || todo.CompletedAt.After(threshold) {
+				continue
+			}
+			if err := u.archive.Insert(ctx, todo); err != nil {
+				return err
+			}
+			inserted = append(inserted, todo.ID)
+			if err := tx.Delete(ctx, todo.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		errs := []error{err}
+		for _, id := range inserted {
+			errs = append(errs, undoMoveInsert(ctx, u.repo, u.archive, id))
+		}
+		return 0, errors.Join(errs...)
+	}
+	return len(inserted), nil
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestArchiveCompletedTodoUsecase_Execute(t *testing.T) {
+	// Given: 30日以上前に完了・30日ちょうど・最近完了・未完了・ゴミ箱の Todo
+	// When:  Execute を呼び出す
+	// Then:  30日以上前（境界を含む）に完了した Todo だけがアーカイブされる
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-40 * 24 * time.Hour)
+	boundary := now.Add(-30 * 24 * time.Hour)
+	recent := now.Add(-24 * time.Hour)
+	repo := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Old", Status: domain.StatusDone, CompletedAt: &old},
+			{ID: 2, Title: "Boundary", Status: domain.StatusDone, CompletedAt: &boundary},
+			{ID: 3, Title: "Recent", Status: domain.StatusDone, CompletedAt: &recent},
+			{ID: 4, Title: "Open", Status: domain.StatusTodo},
+			{ID: 5, Title: "Trashed", Status: domain.StatusDone, CompletedAt: &old, DeletedAt: &old},
+		},
+	}
+	archive := &MockRepository{}
+	usecase := NewArchiveCompletedTodoUsecase(repo, archive, 30*24*time.Hour)
+
+	archived, err := usecase.Execute(context.Background(), now)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if archived != 2 {
+		t.Errorf("Expected 2 archived, got %d", archived)
+	}
+	if len(archive.todoList) != 2 || archive.todoList[0].ID != 1 || archive.todoList[1].ID != 2 {
+		t.Errorf("Expected todos 1 and 2 in archive, got %v", archive.todoList)
+	}
+}
+
+func TestArchiveCompletedTodoUsecase_Execute_InsertError(t *testing.T) {
+	old := time.Now().Add(-48 * time.Hour)
+	repo := &MockRepository{
+		todoList: []*domain.Todo{{ID: 1, Title: "Old", Status: domain.StatusDone, CompletedAt: &old}},
+	}
+	archive := &MockRepository{insertErr: errors.New("storage failure")}
+	usecase := NewArchiveCompletedTodoUsecase(repo, archive, time.Hour)
+
+	archived, err := usecase.Execute(context.Background(), time.Now())
+
+	if err == nil {
+		t.Error("Expected error when archive.Insert fails")
+	}
+	if archived != 0 {
+		t.Errorf("Expected 0 archived, got %d", archived)
+	}
+}
+
+func TestArchiveCompletedTodoUsecase_Execute_DeleteErrorRollsBack(t *testing.T) {
+	// Given: 元のリポジトリからの削除が失敗するモック
+	// When:  Execute を呼び出す
+	// Then:  エラーが伝播し、アーカイブへの追加が取り消される
+	old := time.Now().Add(-48 * time.Hour)
+	repo := &MockRepository{
+		todoList:  []*domain.Todo{{ID: 1, Title: "Old", Status: domain.StatusDone, CompletedAt: &old}},
+		deleteErr: errors.New("storage failure"),
+	}
+	archive := &MockRepository{}
+	usecase := NewArchiveCompletedTodoUsecase(repo, archive, time.Hour)
+
+	archived, err := usecase.Execute(context.Background(), time.Now())
+
+	if err == nil {
+		t.Error("Expected error when repo.Delete fails")
+	}
+	if archived != 0 {
+		t.Errorf("Expected 0 archived, got %d", archived)
+	}
+	if !archive.deleteCalled || archive.deletedID != 1 {
+		t.Error("Expected archive insert to be rolled back")
+	}
+}
+
+func TestArchiveCompletedTodoUsecase_Execute_DeleteCommittedKeepsArchive(t *testing.T) {
+	// Given: 削除をコミットした後で失敗するデコレータ
+	// When:  Execute を呼び出す
+	// Then:  エラーは伝播するが、唯一の写しになったアーカイブの Todo は取り消さない
+	old := time.Now().Add(-48 * time.Hour)
+	repo := failAfterCommitRepository{&MockRepository{
+		todoList: []*domain.Todo{{ID: 1, Title: "Old", Status: domain.StatusDone, CompletedAt: &old}},
+	}}
+	archive := &MockRepository{}
+	usecase := NewArchiveCompletedTodoUsecase(repo, archive, time.Hour)
+
+	_, err := usecase.Execute(context.Background(), time.Now())
+
+	if !errors.Is(err, errAfterCommit) {
+		t.Errorf("Expected errAfterCommit, got %v", err)
+	}
+	if archive.deleteCalled {
+		t.Error("Expected archive insert not to be rolled back")
+	}
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ArchiveTodoUsecase struct {
+	repo    domain.IRepository
+	archive domain.IRepository
+}
+
+func NewArchiveTodoUsecase(repo domain.IRepository, archive domain.IRepository) *ArchiveTodoUsecase {
+	return &ArchiveTodoUsecase{repo: repo, archive: archive}
+}
+
+func (u *ArchiveTodoUsecase) Execute(ctx context.Context, id int) (*domain.Todo, error) {
+	todo, err := findActiveTodo(ctx, u.repo, id)
+	if err != nil {
+		return nil, err
+	}
+	if !todo.IsFinished() {
+		return nil, domain.ErrNotArchivable
+	}
+
+	if err := moveTodo(ctx, u.repo, u.archive, todo); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
+
+// moveTodo は ID を保ったまま todo を from から to へ移す。
+// from からの削除に失敗した場合は、todo が from に残っていれば to への追加を取り消す。
+// 取り消しは一方のリポジトリしか戻せず、戻すと Todo が重複したり失われたりするため、移動は取り消しの対象にしない。
+func moveTodo(ctx context.Context, from domain.IRepository, to domain.IRepository, todo *domain.Todo) error {
+	ctx = withoutUndo(ctx)
+	if err := to.Insert(ctx, todo); err != nil {
+		return err
+	}
+	if err := from.Delete(ctx, todo.ID); err != nil {
+		return errors.Join(err, undoMoveInsert(ctx, from, to, todo.ID))
+	}
+	return nil
+}
+
+// undoMoveInsert は from からの削除が失敗した後で、to に追加した id の Todo を取り消す。
+// 削除はコミットされたがデコレータが失敗した場合は from に残っておらず、to の Todo が唯一の写しになるため消さない。
+func undoMoveInsert(ctx context.Context, from domain.IRepository, to domain.IRepository, id int) error {
+	if _, err := from.FindByID(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrTodoNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to check todo %d before rolling back the move: %w", id, err)
+	}
+	if err := to.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to roll back the move of todo %d: %w", id, err)
+	}
+	return nil
+}
+
+// withoutUndo は ctx からセッションを外し、変更が取り消しの対象として記録されないようにする。
+func withoutUndo(ctx context.Context) context.Context {
+	return domain.WithSession(ctx, "")
+}
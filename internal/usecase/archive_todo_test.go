@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestArchiveTodoUsecase_Execute(t *testing.T) {
+	// Given: done 状態の Todo
+	// When:  Execute を呼び出す
+	// Then:  同じ ID でアーカイブに追加され、元のリポジトリから削除される
+	repo := &MockRepository{
+		todoList: []*domain.Todo{{ID: 7, Title: "Buy milk", Status: domain.StatusDone}},
+	}
+	archive := &MockRepository{}
+	usecase := NewArchiveTodoUsecase(repo, archive)
+
+	todo, err := usecase.Execute(context.Background(), 7)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if todo.ID != 7 {
+		t.Errorf("Expected ID 7, got %d", todo.ID)
+	}
+	if len(archive.todoList) != 1 || archive.todoList[0].ID != 7 {
+		t.Errorf("Expected todo 7 in archive, got %v", archive.todoList)
+	}
+	if !repo.deleteCalled || repo.deletedID != 7 {
+		t.Error("Expected todo 7 to be deleted from repository")
+	}
+}
+
+func TestArchiveTodoUsecase_Execute_NotFinished(t *testing.T) {
+	// Given: in_progress 状態の Todo
+	// When:  Execute を呼び出す
+	// Then:  ErrNotArchivable が返り、何も移動しない
+	repo := &MockRepository{
+		todoList: []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusInProgress}},
+	}
+	archive := &MockRepository{}
+	usecase := NewArchiveTodoUsecase(repo, archive)
+
+	_, err := usecase.Execute(context.Background(), 1)
+
+	if !errors.Is(err, domain.ErrNotArchivable) {
+		t.Errorf("Expected ErrNotArchivable, got %v", err)
+	}
+	if archive.insertCalled || repo.deleteCalled {
+		t.Error("Expected no repository mutation")
+	}
+}
+
+func TestArchiveTodoUsecase_Execute_Trashed(t *testing.T) {
+	deletedAt := time.Now()
+	repo := &MockRepository{
+		todoList: []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusDone, DeletedAt: &deletedAt}},
+	}
+	usecase := NewArchiveTodoUsecase(repo, &MockRepository{})
+
+	_, err := usecase.Execute(context.Background(), 1)
+
+	if !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Errorf("Expected ErrTodoNotFound, got %v", err)
+	}
+}
+
+func TestArchiveTodoUsecase_Execute_InsertError(t *testing.T) {
+	// Given: アーカイブへの追加が失敗するモック
+	// When:  Execute を呼び出す
+	// Then:  エラーが伝播し、元のリポジトリからは削除されない
+	repo := &MockRepository{
+		todoList: []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusDone}},
+	}
+	archive := &MockRepository{insertErr: errors.New("storage failure")}
+	usecase := NewArchiveTodoUsecase(repo, archive)
+
+	_, err := usecase.Execute(context.Background(), 1)
+
+	if err == nil {
+		t.Error("Expected error when archive.Insert fails")
+	}
+	if repo.deleteCalled {
+		t.Error("Expected Delete not to be called")
+	}
+}
+
+func TestArchiveTodoUsecase_Execute_DeleteErrorRollsBack(t *testing.T) {
+	// Given: 元のリポジトリからの削除が失敗するモック
+	// When:  Execute を呼び出す
+	// Then:  エラーが伝播し、アーカイブへの追加が取り消される
+	repo := &MockRepository{
+		todoList:  []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusDone}},
+		deleteErr: errors.New("storage failure"),
+	}
+	archive := &MockRepository{}
+	usecase := NewArchiveTodoUsecase(repo, archive)
+
+	_, err := usecase.Execute(context.Background(), 1)
+
+	if err == nil {
+		t.Error("Expected error when repo.Delete fails")
+	}
+	if !archive.deleteCalled || archive.deletedID != 1 {
+		t.Error("Expected archive insert to be rolled back")
+	}
+}
+
+var errAfterCommit = errors.New("audit failure")
+
+// failAfterCommitRepository は削除をコミットした後でエラーを返すデコレータを模す。
+type failAfterCommitRepository struct {
+	*MockRepository
+}
+
+func (r failAfterCommitRepository) Delete(ctx context.Context, id int) error {
+	if !r.inTx {
+		return r.WithinTx(ctx, func(tx domain.IRepository) error {
+			return tx.Delete(ctx, id)
+		})
+	}
+	for i, t := range r.todoList {
+		if t.ID == id {
+			r.todoList = append(r.todoList[:i], r.todoList[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrTodoNotFound
+}
+
+func (r failAfterCommitRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	err := r.MockRepository.WithinTx(ctx, func(domain.IRepository) error {
+		return fn(r)
+	})
+	if err != nil {
+		return err
+	}
+	return errAfterCommit
+}
+
+func TestArchiveTodoUsecase_Execute_DeleteCommittedKeepsArchive(t *testing.T) {
+	// Given: 削除をコミットした後で失敗するデコレータ
+	// When:  Execute を呼び出す
+	// Then:  エラーは伝播するが、唯一の写しになったアーカイブの Todo は取り消さない
+	repo := failAfterCommitRepository{&MockRepository{
+		todoList: []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusDone}},
+	}}
+	archive := &MockRepository{}
+	usecase := NewArchiveTodoUsecase(repo, archive)
+
+	_, err := usecase.Execute(context.Background(), 1)
+
+	if !errors.Is(err, errAfterCommit) {
+		t.Errorf("Expected errAfterCommit, got %v", err)
+	}
+	if archive.deleteCalled {
+		t.Error("Expected archive insert not to be rolled back")
+	}
+}
+
+func TestArchiveTodoUsecase_Execute_RollbackError(t *testing.T) {
+	// Given: 元のリポジトリからの削除とアーカイブからの取り消しの両方が失敗するモック
+	// When:  Execute を呼び出す
+	// Then:  取り消しの失敗もエラーに含まれる
+	repo := &MockRepository{
+		todoList:  []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusDone}},
+		deleteErr: errors.New("storage failure"),
+	}
+	rollbackErr := errors.New("archive failure")
+	archive := &MockRepository{deleteErr: rollbackErr}
+	usecase := NewArchiveTodoUsecase(repo, archive)
+
+	_, err := usecase.Execute(context.Background(), 1)
+
+	if !errors.Is(err, repo.deleteErr) || !errors.Is(err, rollbackErr) {
+		t.Errorf("Expected both errors, got %v", err)
+	}
+}
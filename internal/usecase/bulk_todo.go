@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type BulkTodoUsecase struct {
+	repo    domain.IRepository
+	machine *domain.StateMachine
+}
+
+func NewBulkTodoUsecase(repo domain.IRepository, machine *domain.StateMachine) *BulkTodoUsecase {
+	return &BulkTodoUsecase{repo: repo, machine: machine}
+}
+
+// Execute は operations を1つのトランザクションで順に実行し、操作ごとの結果を返す。
+// atomic では1件でも失敗すると何も保存せず ErrBulkRolledBack を返す。
+// best_effort では失敗した操作だけを飛ばして残りを保存する。
+func (u *BulkTodoUsecase) Execute(ctx context.Context, mode domain.BulkMode, operations []domain.BulkOperation) ([]domain.BulkResult, error) {
+	if mode != domain.BulkAtomic && mode != domain.BulkBestEffort {
+		return nil, domain.ErrInvalidBulkMode
+	}
+
+	resultList := make([]domain.BulkResult, len(operations))
+	failed := false
+	err := u.repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		for i, op := range operations {
+			resultList[i] = domain.BulkResult{Index: i, Op: op.Op}
+			if failed && mode == domain.BulkAtomic {
+				resultList[i].Status = domain.BulkSkipped
+				continue
+			}
+
+			todo, err := u.apply(ctx, tx, op)
+			if err != nil {
+				failed = true
+				resultList[i].Status = domain.BulkFailed
+				resultList[i].Error = err.Error()
+				continue
+			}
+			resultList[i].Status = domain.BulkOK
+			resultList[i].Todo = todo
+		}
+
+		if failed && mode == domain.BulkAtomic {
+			return domain.ErrBulkRolledBack
+		}
+		return nil
+	})
+
+	if errors.Is(err, domain.ErrBulkRolledBack) {
+		for i := range resultList {
+			if resultList[i].Status == domain.BulkOK {
+				resultList[i].Status = domain.BulkRolledBack
+				resultList[i].Todo = nil
+			}
+		}
+		return resultList, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resultList, nil
+}
+
+// apply は既存の usecase をバッチ内のリポジトリに対して実行し、単体の API と同じ検証を行う。
+func (u *BulkTodoUsecase) apply(ctx context.Context, repo domain.IRepository, op domain.BulkOperation) (*domain.Todo, error) {
+	switch op.Op {
+	case domain.BulkCreate:
+		return NewCreateTodoUsecase(repo, u.machine).Execute(ctx, op.Title)
+	case domain.BulkUpdate:
+		current, err := findActiveTodo(ctx, repo, op.ID)
+		if err != nil {
+			return nil, err
+		}
+		title, completed := current.Title, current.IsCompleted()
+		if op.Title != "" {
+			title = op.Title
+		}
+		if op.Completed != nil {
+			completed = *op.Completed
+		}
+		return NewUpdateTodoUsecase(repo, u.machine).Execute(ctx, op.ID, title, completed)
+	case domain.BulkDelete:
+		if err := NewDeleteTodoUsecase(repo).Execute(ctx, op.ID); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	case domain.BulkComplete:
+		return NewChangeStatusTodoUsecase(repo, u.machine).Execute(ctx, op.ID, domain.StatusDone)
+	default:
+		return nil, domain.ErrInvalidBulkOp
+	}
+}
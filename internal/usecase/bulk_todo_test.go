@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func newBulkMock() *MockRepository {
+	return &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo},
+			{ID: 2, Title: "Read book", Status: domain.StatusTodo},
+			{ID: 3, Title: "Old task", Status: domain.StatusCancelled},
+		},
+	}
+}
+
+func TestBulkTodoUsecase_Execute_Atomic(t *testing.T) {
+	// Given: すべて成功する create / update / complete / delete
+	// When:  atomic モードで Execute を呼び出す
+	// Then:  1回のトランザクションで全件が反映され、結果がすべて ok になる
+	mock := newBulkMock()
+	usecase := NewBulkTodoUsecase(mock, domain.DefaultStateMachine())
+	completed := true
+
+	resultList, err := usecase.Execute(context.Background(), domain.BulkAtomic, []domain.BulkOperation{
+		{Op: domain.BulkCreate, Title: "New todo"},
+		{Op: domain.BulkUpdate, ID: 1, Title: "Buy oat milk"},
+		{Op: domain.BulkUpdate, ID: 2, Completed: &completed},
+		{Op: domain.BulkComplete, ID: 1},
+		{Op: domain.BulkDelete, ID: 2},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if mock.txCalled != 1 {
+		t.Errorf("Expected WithinTx to be called once, got %d", mock.txCalled)
+	}
+	for _, result := range resultList {
+		if result.Status != domain.BulkOK {
+			t.Errorf("Expected ok at %d, got %s (%s)", result.Index, result.Status, result.Error)
+		}
+	}
+	if resultList[1].Todo.Title != "Buy oat milk" {
+		t.Errorf("Expected updated title, got %q", resultList[1].Todo.Title)
+	}
+	if mock.todoList[0].Status != domain.StatusDone {
+		t.Errorf("Expected todo 1 to be done, got %q", mock.todoList[0].Status)
+	}
+	if !mock.todoList[1].IsDeleted() {
+		t.Error("Expected todo 2 to be in the trash")
+	}
+}
+
+func TestBulkTodoUsecase_Execute_AtomicRollback(t *testing.T) {
+	// Given: 2件目が不正な遷移（cancelled -> done）になる操作列
+	// When:  atomic モードで Execute を呼び出す
+	// Then:  ErrBulkRolledBack が返り、1件目は rolled_back、2件目は failed、3件目は skipped で何も保存されない
+	mock := newBulkMock()
+	usecase := NewBulkTodoUsecase(mock, domain.DefaultStateMachine())
+
+	resultList, err := usecase.Execute(context.Background(), domain.BulkAtomic, []domain.BulkOperation{
+		{Op: domain.BulkComplete, ID: 1},
+		{Op: domain.BulkComplete, ID: 3},
+		{Op: domain.BulkDelete, ID: 2},
+	})
+
+	if !errors.Is(err, domain.ErrBulkRolledBack) {
+		t.Fatalf("Expected ErrBulkRolledBack, got %v", err)
+	}
+	wantStatus := []domain.BulkResultStatus{domain.BulkRolledBack, domain.BulkFailed, domain.BulkSkipped}
+	for i, want := range wantStatus {
+		if resultList[i].Status != want {
+			t.Errorf("Expected %s at %d, got %s", want, i, resultList[i].Status)
+		}
+	}
+	if resultList[1].Error != domain.ErrInvalidTransition.Error() {
+		t.Errorf("Expected transition error message, got %q", resultList[1].Error)
+	}
+	if mock.todoList[0].Status != domain.StatusTodo {
+		t.Errorf("Expected todo 1 to be rolled back, got %q", mock.todoList[0].Status)
+	}
+}
+
+func TestBulkTodoUsecase_Execute_BestEffort(t *testing.T) {
+	// Given: 途中に存在しない ID と不正な操作を含む操作列
+	// When:  best_effort モードで Execute を呼び出す
+	// Then:  失敗した操作だけ failed になり、残りは反映される
+	mock := newBulkMock()
+	usecase := NewBulkTodoUsecase(mock, domain.DefaultStateMachine())
+
+	resultList, err := usecase.Execute(context.Background(), domain.BulkBestEffort, []domain.BulkOperation{
+		{Op: domain.BulkComplete, ID: 1},
+		{Op: domain.BulkDelete, ID: 99},
+		{Op: domain.BulkOp("archive"), ID: 2},
+		{Op: domain.BulkCreate, Title: ""},
+		{Op: domain.BulkDelete, ID: 2},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	wantStatus := []domain.BulkResultStatus{domain.BulkOK, domain.BulkFailed, domain.BulkFailed, domain.BulkFailed, domain.BulkOK}
+	for i, want := range wantStatus {
+		if resultList[i].Status != want {
+			t.Errorf("Expected %s at %d, got %s", want, i, resultList[i].Status)
+		}
+	}
+	if resultList[2].Error != domain.ErrInvalidBulkOp.Error() {
+		t.Errorf("Expected invalid op message, got %q", resultList[2].Error)
+	}
+	if mock.todoList[0].Status != domain.StatusDone || !mock.todoList[1].IsDeleted() {
+		t.Error("Expected successful operations to be applied")
+	}
+}
+
+func TestBulkTodoUsecase_Execute_InvalidMode(t *testing.T) {
+	mock := newBulkMock()
+	usecase := NewBulkTodoUsecase(mock, domain.DefaultStateMachine())
+
+	_, err := usecase.Execute(context.Background(), domain.BulkMode("partial"), nil)
+
+	if !errors.Is(err, domain.ErrInvalidBulkMode) {
+		t.Errorf("Expected ErrInvalidBulkMode, got %v", err)
+	}
+	if mock.txCalled != 0 {
+		t.Error("Expected WithinTx not to be called")
+	}
+}
+
+func TestBulkTodoUsecase_Execute_StorageError(t *testing.T) {
+	// Given: 保存処理がエラーを返すモック
+	// When:  best_effort モードで Execute を呼び出す
+	// Then:  操作の失敗として記録される
+	mock := newBulkMock()
+	mock.updateErr = errors.New("storage failure")
+	usecase := NewBulkTodoUsecase(mock, domain.DefaultStateMachine())
+
+	resultList, err := usecase.Execute(context.Background(), domain.BulkBestEffort, []domain.BulkOperation{
+		{Op: domain.BulkComplete, ID: 1},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resultList[0].Status != domain.BulkFailed || resultList[0].Error != "storage failure" {
+		t.Errorf("Unexpected result: %+v", resultList[0])
+	}
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ChangeStatusTodoUsecase struct {
+	repo    domain.IRepository
+	machine *domain.StateMachine
+}
+
+func NewChangeStatusTodoUsecase(repo domain.IRepository, machine *domain.StateMachine) *ChangeStatusTodoUsecase {
+	return &ChangeStatusTodoUsecase{repo: repo, machine: machine}
+}
+
+func (u *ChangeStatusTodoUsecase) Execute(ctx context.Context, id int, status domain.Status) (*domain.Todo, error) {
+	if !u.machine.IsValid(status) {
+		return nil, domain.ErrInvalidStatus
+	}
+
+	todo, err := findActiveTodo(ctx, u.repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := u.machine.Transition(todo, status, now); err != nil {
+		return nil, err
+	}
+	todo.UpdatedAt = now
+
+	if err := u.repo.Update(ctx, todo); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestChangeStatusTodoUsecase_Execute(t *testing.T) {
+	// Given: todo 状態の Todo
+	// When:  in_progress への変更を実行する
+	// Then:  ステータスと遷移履歴が更新され保存される
+	now := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	usecase := NewChangeStatusTodoUsecase(mock, domain.DefaultStateMachine())
+
+	todo, err := usecase.Execute(context.Background(), 1, domain.StatusInProgress)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !mock.updateCalled {
+		t.Error("Expected Update to be called")
+	}
+	if todo.Status != domain.StatusInProgress {
+		t.Errorf("Expected status in_progress, got %q", todo.Status)
+	}
+	if len(todo.StatusHistory) != 1 {
+		t.Errorf("Expected 1 history entry, got %d", len(todo.StatusHistory))
+	}
+	if !todo.UpdatedAt.After(now) {
+		t.Error("Expected UpdatedAt to be updated")
+	}
+}
+
+func TestChangeStatusTodoUsecase_Execute_InvalidTransition(t *testing.T) {
+	// Given: cancelled 状態の Todo
+	// When:  done への変更を実行する
+	// Then:  ErrInvalidTransition が返り、保存されない
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusCancelled},
+		},
+	}
+	usecase := NewChangeStatusTodoUsecase(mock, domain.DefaultStateMachine())
+
+	_, err := usecase.Execute(context.Background(), 1, domain.StatusDone)
+
+	if !errors.Is(err, domain.ErrInvalidTransition) {
+		t.Errorf("Expected ErrInvalidTransition, got %v", err)
+	}
+	if mock.updateCalled {
+		t.Error("Expected Update not to be called")
+	}
+}
+
+func TestChangeStatusTodoUsecase_Execute_InvalidStatus(t *testing.T) {
+	// Given: ワークフローに存在しないステータス
+	// When:  Execute を呼び出す
+	// Then:  ErrInvalidStatus が返る
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo},
+		},
+	}
+	usecase := NewChangeStatusTodoUsecase(mock, domain.DefaultStateMachine())
+
+	_, err := usecase.Execute(context.Background(), 1, domain.Status("unknown"))
+
+	if !errors.Is(err, domain.ErrInvalidStatus) {
+		t.Errorf("Expected ErrInvalidStatus, got %v", err)
+	}
+}
+
+func TestChangeStatusTodoUsecase_Execute_NotFound(t *testing.T) {
+	mock := &MockRepository{todoList: []*domain.Todo{}}
+	usecase := NewChangeStatusTodoUsecase(mock, domain.DefaultStateMachine())
+
+	_, err := usecase.Execute(context.Background(), 999, domain.StatusDone)
+
+	if err == nil {
+		t.Error("Expected error for non-existent todo")
+	}
+}
+
+func TestChangeStatusTodoUsecase_Execute_RepoUpdateError(t *testing.T) {
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo},
+		},
+		updateErr: errors.New("storage failure"),
+	}
+	usecase := NewChangeStatusTodoUsecase(mock, domain.DefaultStateMachine())
+
+	_, err := usecase.Execute(context.Background(), 1, domain.StatusDone)
+
+	if err == nil {
+		t.Error("Expected error when repo.Update fails")
+	}
+}
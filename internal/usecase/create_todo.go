@@ -2,24 +2,26 @@ package usecase
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/k98a73/go-todo/internal/domain"
 )
 
 type CreateTodoUsecase struct {
-	repo domain.IRepository
+	repo    domain.IRepository
+	machine *domain.StateMachine
 }
 
-func NewCreateTodoUsecase(repo domain.IRepository) *CreateTodoUsecase {
-	return &CreateTodoUsecase{repo: repo}
+func NewCreateTodoUsecase(repo domain.IRepository, machine *domain.StateMachine) *CreateTodoUsecase {
+	return &CreateTodoUsecase{repo: repo, machine: machine}
 }
 
 func (u *CreateTodoUsecase) Execute(ctx context.Context, title string) (*domain.Todo, error) {
 	now := time.Now()
 	todo := &domain.Todo{
 		Title:     title,
-		Completed: false,
+		Status:    u.machine.Initial(),
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -28,9 +30,59 @@ func (u *CreateTodoUsecase) Execute(ctx context.Context, title string) (*domain.
 		return nil, err
 	}
 
-	if err := u.repo.Create(ctx, todo); err != nil {
+	// 並び順の決定と作成の間に他の作成が割り込まないよう、同じトランザクションで行う
+	err := u.repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		todos, err := tx.List(ctx)
+		if err != nil {
+			return err
+		}
+		position, err := nextPosition(todos)
+		if err != nil {
+			return err
+		}
+		if len(position) > domain.MaxRankLength {
+			// 末尾への追加が続いてキーが長くなりすぎたら、既存の Todo を振り直して空きを作る
+			if position, err = rebalanceForAppend(ctx, tx, todos); err != nil {
+				return err
+			}
+		}
+		todo.Position = position
+		return tx.Create(ctx, todo)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return todo, nil
 }
+
+// nextPosition は既存のどの Todo よりも後ろになる並び順のキーを返す。
+func nextPosition(todos []*domain.Todo) (string, error) {
+	last := ""
+	for _, t := range todos {
+		if t.Position > last {
+			last = t.Position
+		}
+	}
+	return domain.RankBetween(last, "")
+}
+
+// rebalanceForAppend は既存の Todo に短いキーを振り直し、その後ろに追加する Todo のキーを返す。
+// 削除済みの Todo も nextPosition の対象になるため、まとめて振り直す。
+func rebalanceForAppend(ctx context.Context, repo domain.IRepository, todos []*domain.Todo) (string, error) {
+	ordered := slices.Clone(todos)
+	if err := domain.SortTodos(ordered, domain.SortPosition); err != nil {
+		return "", err
+	}
+	rankList := domain.RebalanceRanks(len(ordered) + 1)
+	for i, t := range ordered {
+		if t.Position == rankList[i] {
+			continue
+		}
+		t.Position = rankList[i]
+		if err := repo.Update(ctx, t); err != nil {
+			return "", err
+		}
+	}
+	return rankList[len(ordered)], nil
+}
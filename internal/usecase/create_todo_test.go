@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/k98a73/go-todo/internal/domain"
@@ -11,6 +12,8 @@ import (
 type MockRepository struct {
 	createCalled bool
 	createdTodo  *domain.Todo
+	insertCalled bool
+	insertErr    error
 	todoList     []*domain.Todo
 	updateCalled bool
 	updatedTodo  *domain.Todo
@@ -19,6 +22,10 @@ type MockRepository struct {
 	createErr    error
 	deleteErr    error
 	updateErr    error
+	txCalled     int
+	inTx         bool
+	// nextID が 0 より大きければ、Create は連番の ID を付けて todoList に追加する
+	nextID int
 }
 
 func (m *MockRepository) Create(ctx context.Context, todo *domain.Todo) error {
@@ -27,10 +34,30 @@ func (m *MockRepository) Create(ctx context.Context, todo *domain.Todo) error {
 	if m.createErr != nil {
 		return m.createErr
 	}
+	if m.nextID > 0 {
+		todo.ID = m.nextID
+		m.nextID++
+		m.todoList = append(m.todoList, todo)
+		return nil
+	}
 	todo.ID = 1
 	return nil
 }
 
+func (m *MockRepository) Insert(ctx context.Context, todo *domain.Todo) error {
+	m.insertCalled = true
+	if m.insertErr != nil {
+		return m.insertErr
+	}
+	for _, t := range m.todoList {
+		if t.ID == todo.ID {
+			return domain.ErrTodoConflict
+		}
+	}
+	m.todoList = append(m.todoList, todo)
+	return nil
+}
+
 func (m *MockRepository) List(ctx context.Context) ([]*domain.Todo, error) {
 	return m.todoList, nil
 }
@@ -41,7 +68,7 @@ func (m *MockRepository) FindByID(ctx context.Context, id int) (*domain.Todo, er
 			return todo, nil
 		}
 	}
-	return nil, errors.New("todo not found")
+	return nil, domain.ErrTodoNotFound
 }
 
 func (m *MockRepository) Update(ctx context.Context, todo *domain.Todo) error {
@@ -68,10 +95,30 @@ func (m *MockRepository) Delete(ctx context.Context, id int) error {
 	return nil
 }
 
+// WithinTx は失敗時に todoList を呼び出し前の状態に戻す。入れ子の呼び出しは外側に参加する
+func (m *MockRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	if m.inTx {
+		return fn(m)
+	}
+	m.inTx = true
+	defer func() { m.inTx = false }()
+	m.txCalled++
+	snapshot := make([]*domain.Todo, len(m.todoList))
+	for i, t := range m.todoList {
+		c := *t
+		snapshot[i] = &c
+	}
+	if err := fn(m); err != nil {
+		m.todoList = snapshot
+		return err
+	}
+	return nil
+}
+
 // --- テスト ---
 func TestCreateTodoUsecase_Execute(t *testing.T) {
 	mock := &MockRepository{}
-	usecase := NewCreateTodoUsecase(mock)
+	usecase := NewCreateTodoUsecase(mock, domain.DefaultStateMachine())
 
 	todo, err := usecase.Execute(context.Background(), "Buy milk")
 
@@ -88,7 +135,7 @@ func TestCreateTodoUsecase_Execute(t *testing.T) {
 
 func TestCreateTodoUsecase_Execute_EmptyTitle(t *testing.T) {
 	mock := &MockRepository{}
-	usecase := NewCreateTodoUsecase(mock)
+	usecase := NewCreateTodoUsecase(mock, domain.DefaultStateMachine())
 
 	_, err := usecase.Execute(context.Background(), "")
 
@@ -102,7 +149,7 @@ func TestCreateTodoUsecase_Execute_RepoError(t *testing.T) {
 	// When:  Execute を呼び出す
 	// Then:  エラーが伝播する
 	mock := &MockRepository{createErr: errors.New("storage failure")}
-	usecase := NewCreateTodoUsecase(mock)
+	usecase := NewCreateTodoUsecase(mock, domain.DefaultStateMachine())
 
 	_, err := usecase.Execute(context.Background(), "Buy milk")
 
@@ -110,3 +157,103 @@ func TestCreateTodoUsecase_Execute_RepoError(t *testing.T) {
 		t.Error("Expected error when repo.Create fails")
 	}
 }
+
+func TestCreateTodoUsecase_Execute_InitialStatus(t *testing.T) {
+	// Given: 初期ステータスが in_progress のワークフロー
+	// When:  Execute を呼び出す
+	// Then:  作成された Todo はワークフローの初期ステータスを持つ
+	machine, err := domain.NewStateMachine(domain.StatusInProgress, map[domain.Status][]domain.Status{
+		domain.StatusInProgress: {domain.StatusDone},
+		domain.StatusDone:       {},
+	}, []domain.Status{domain.StatusDone})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock := &MockRepository{}
+	usecase := NewCreateTodoUsecase(mock, machine)
+
+	todo, err := usecase.Execute(context.Background(), "Buy milk")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if todo.Status != domain.StatusInProgress {
+		t.Errorf("Expected status in_progress, got %q", todo.Status)
+	}
+}
+
+func TestCreateTodoUsecase_Execute_AppendsPosition(t *testing.T) {
+	// Given: 並び順 "i" と "r" の Todo があるリポジトリ
+	// When:  Execute を呼び出す
+	// Then:  新しい Todo は既存のどれよりも後ろのキーを持つ
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "First", Position: "r"},
+			{ID: 2, Title: "Second", Position: "i"},
+		},
+	}
+	usecase := NewCreateTodoUsecase(mock, domain.DefaultStateMachine())
+
+	todo, err := usecase.Execute(context.Background(), "Buy milk")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if todo.Position <= "r" {
+		t.Errorf("Expected position after 'r', got %q", todo.Position)
+	}
+}
+
+func TestCreateTodoUsecase_Execute_RepeatedAppendStaysBounded(t *testing.T) {
+	// Given: 空のリポジトリ
+	// When:  Execute を1000回呼び出す
+	// Then:  並び順のキーは MaxRankLength 以内に収まり、作成順に並ぶ
+	mock := &MockRepository{nextID: 1}
+	usecase := NewCreateTodoUsecase(mock, domain.DefaultStateMachine())
+
+	for i := 0; i < 1000; i++ {
+		if _, err := usecase.Execute(context.Background(), "Todo"); err != nil {
+			t.Fatalf("Expected no error, got %v at %d", err, i)
+		}
+	}
+
+	for i, todo := range mock.todoList {
+		if len(todo.Position) > domain.MaxRankLength {
+			t.Fatalf("Expected position within %d characters, got %q", domain.MaxRankLength, todo.Position)
+		}
+		if i > 0 && todo.Position <= mock.todoList[i-1].Position {
+			t.Fatalf("Expected %q after %q", todo.Position, mock.todoList[i-1].Position)
+		}
+	}
+}
+
+func TestCreateTodoUsecase_Execute_RebalancesLongPosition(t *testing.T) {
+	// Given: 並び順のキーが MaxRankLength 文字の "z" の Todo があるリポジトリ
+	// When:  Execute を呼び出す
+	// Then:  既存の Todo のキーが振り直され、新しい Todo はその後ろに並ぶ
+	long := strings.Repeat("z", domain.MaxRankLength)
+	mock := &MockRepository{
+		nextID: 3,
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "First", Position: "i"},
+			{ID: 2, Title: "Second", Position: long},
+		},
+	}
+	usecase := NewCreateTodoUsecase(mock, domain.DefaultStateMachine())
+
+	todo, err := usecase.Execute(context.Background(), "Third")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second := mock.todoList[1]
+	if second.Position == long {
+		t.Errorf("Expected position of todo 2 to be rebalanced, got %q", second.Position)
+	}
+	if mock.todoList[0].Position >= second.Position || second.Position >= todo.Position {
+		t.Errorf("Expected order 1 < 2 < 3, got %q, %q, %q", mock.todoList[0].Position, second.Position, todo.Position)
+	}
+	if len(todo.Position) > domain.MaxRankLength {
+		t.Errorf("Expected position within %d characters, got %q", domain.MaxRankLength, todo.Position)
+	}
+}
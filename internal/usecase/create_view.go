@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/filter"
+)
+
+type CreateViewUsecase struct {
+	views   domain.IViewRepository
+	machine *domain.StateMachine
+}
+
+func NewCreateViewUsecase(views domain.IViewRepository, machine *domain.StateMachine) *CreateViewUsecase {
+	return &CreateViewUsecase{views: views, machine: machine}
+}
+
+func (u *CreateViewUsecase) Execute(ctx context.Context, name string, query domain.ListQuery) (*domain.View, error) {
+	now := time.Now()
+	view := &domain.View{
+		Name:      name,
+		Query:     query,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := validateView(view, u.machine); err != nil {
+		return nil, err
+	}
+
+	if err := u.views.Create(ctx, view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+// validateView は保存時に検索式の構文も検証し、実行時まで誤りが残らないようにする。
+func validateView(view *domain.View, machine *domain.StateMachine) error {
+	if err := domain.ValidateView(view); err != nil {
+		return err
+	}
+	_, err := filter.Parse(view.Query.Filter, machine)
+	return err
+}
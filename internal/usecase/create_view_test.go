@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type MockViewRepository struct {
+	viewList  []*domain.View
+	createErr error
+	updateErr error
+	deleteErr error
+	created   *domain.View
+	updated   *domain.View
+	deletedID string
+}
+
+func (m *MockViewRepository) Create(ctx context.Context, view *domain.View) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	view.ID = "1"
+	m.created = view
+	return nil
+}
+
+func (m *MockViewRepository) List(ctx context.Context) ([]*domain.View, error) {
+	return m.viewList, nil
+}
+
+func (m *MockViewRepository) FindByID(ctx context.Context, id string) (*domain.View, error) {
+	for _, v := range m.viewList {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+	return nil, domain.ErrViewNotFound
+}
+
+func (m *MockViewRepository) Update(ctx context.Context, view *domain.View) error {
+	if m.updateErr != nil {
+		return m.updateErr
+	}
+	m.updated = view
+	return nil
+}
+
+func (m *MockViewRepository) Delete(ctx context.Context, id string) error {
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	m.deletedID = id
+	return nil
+}
+
+func TestCreateViewUsecase_Execute(t *testing.T) {
+	// Given: 空のビューリポジトリ
+	// When:  名前と条件を指定して Execute を呼び出す
+	// Then:  ID と作成日時が設定されたビューが保存される
+	views := &MockViewRepository{}
+	usecase := NewCreateViewUsecase(views, domain.DefaultStateMachine())
+	query := domain.ListQuery{Filter: "status:open backend", Sort: domain.SortPosition, Limit: 20}
+
+	view, err := usecase.Execute(context.Background(), "Backend", query)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if view.ID != "1" || view.Name != "Backend" || view.Query != query {
+		t.Errorf("Unexpected view: %+v", view)
+	}
+	if view.CreatedAt.IsZero() || view.BuiltIn {
+		t.Errorf("Expected CreatedAt to be set and BuiltIn false, got %+v", view)
+	}
+	if views.created != view {
+		t.Error("Expected view to be saved")
+	}
+}
+
+func TestCreateViewUsecase_Execute_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		view    string
+		query   domain.ListQuery
+		wantErr error
+	}{
+		{name: "empty name", view: "", wantErr: domain.ErrInvalidView},
+		{name: "invalid sort", view: "Backend", query: domain.ListQuery{Sort: "title"}, wantErr: domain.ErrInvalidSort},
+		{name: "invalid filter", view: "Backend", query: domain.ListQuery{Filter: "tag:backend"}, wantErr: domain.ErrInvalidFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views := &MockViewRepository{}
+			usecase := NewCreateViewUsecase(views, domain.DefaultStateMachine())
+
+			_, err := usecase.Execute(context.Background(), tt.view, tt.query)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if views.created != nil {
+				t.Error("Expected view not to be saved")
+			}
+		})
+	}
+}
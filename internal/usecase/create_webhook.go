@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type CreateWebhookUsecase struct {
+	webhooks domain.IWebhookRepository
+}
+
+func NewCreateWebhookUsecase(webhooks domain.IWebhookRepository) *CreateWebhookUsecase {
+	return &CreateWebhookUsecase{webhooks: webhooks}
+}
+
+// Execute は Webhook を登録する。secret が空の場合は生成する。
+func (u *CreateWebhookUsecase) Execute(ctx context.Context, url string, events []domain.FeedEventType, secret string) (*domain.Webhook, error) {
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	now := time.Now()
+	webhook := &domain.Webhook{
+		URL:       url,
+		Events:    events,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := domain.ValidateWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	if err := u.webhooks.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func generateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type MockWebhookRepository struct {
+	webhookList []*domain.Webhook
+	createErr   error
+	updated     *domain.Webhook
+	deletedID   string
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	webhook.ID = strconv.Itoa(len(m.webhookList) + 1)
+	m.webhookList = append(m.webhookList, webhook)
+	return nil
+}
+
+func (m *MockWebhookRepository) List(ctx context.Context) ([]*domain.Webhook, error) {
+	return m.webhookList, nil
+}
+
+func (m *MockWebhookRepository) FindByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	for _, w := range m.webhookList {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return nil, domain.ErrWebhookNotFound
+}
+
+func (m *MockWebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	m.updated = webhook
+	return nil
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, id string) error {
+	if _, err := m.FindByID(ctx, id); err != nil {
+		return err
+	}
+	m.deletedID = id
+	return nil
+}
+
+type MockDeliveryRepository struct {
+	deliveryList     []*domain.WebhookDelivery
+	updated          []*domain.WebhookDelivery
+	deletedWebhookID string
+}
+
+func (m *MockDeliveryRepository) Create(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	for _, d := range deliveries {
+		d.ID = strconv.Itoa(len(m.deliveryList) + 1)
+		m.deliveryList = append(m.deliveryList, d)
+	}
+	return nil
+}
+
+func (m *MockDeliveryRepository) ListDue(ctx context.Context, now time.Time) ([]*domain.WebhookDelivery, error) {
+	var due []*domain.WebhookDelivery
+	for _, d := range m.deliveryList {
+		if d.Status == domain.DeliveryPending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	return due, nil
+}
+
+func (m *MockDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m.updated = append(m.updated, delivery)
+	return nil
+}
+
+func (m *MockDeliveryRepository) ListByWebhook(ctx context.Context, webhookID string, limit int) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*domain.WebhookDelivery
+	for i := len(m.deliveryList) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if m.deliveryList[i].WebhookID == webhookID {
+			deliveries = append(deliveries, m.deliveryList[i])
+		}
+	}
+	return deliveries, nil
+}
+
+func (m *MockDeliveryRepository) DeleteByWebhook(ctx context.Context, webhookID string) error {
+	m.deletedWebhookID = webhookID
+	return nil
+}
+
+func TestCreateWebhookUsecase_Execute(t *testing.T) {
+	// Given: 鍵を指定しない登録
+	repo := &MockWebhookRepository{}
+	usecase := NewCreateWebhookUsecase(repo)
+
+	// When:  実行する
+	webhook, err := usecase.Execute(context.Background(), "https://example.com/hook", []domain.FeedEventType{domain.FeedTodoCreated}, "")
+
+	// Then:  鍵が生成されて保存される
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if webhook.ID != "1" || len(webhook.Secret) != 64 || webhook.CreatedAt.IsZero() {
+		t.Errorf("Unexpected webhook: %+v", webhook)
+	}
+}
+
+func TestCreateWebhookUsecase_Execute_Secret(t *testing.T) {
+	usecase := NewCreateWebhookUsecase(&MockWebhookRepository{})
+
+	webhook, err := usecase.Execute(context.Background(), "https://example.com/hook", nil, "s3cret")
+
+	if err != nil || webhook.Secret != "s3cret" {
+		t.Errorf("Expected given secret, got %+v, %v", webhook, err)
+	}
+}
+
+func TestCreateWebhookUsecase_Execute_Invalid(t *testing.T) {
+	// Given: http(s) でない URL
+	repo := &MockWebhookRepository{}
+	usecase := NewCreateWebhookUsecase(repo)
+
+	// When:  実行する
+	_, err := usecase.Execute(context.Background(), "ftp://example.com", nil, "")
+
+	// Then:  ErrInvalidWebhook が返り、保存されない
+	if !errors.Is(err, domain.ErrInvalidWebhook) {
+		t.Errorf("Expected ErrInvalidWebhook, got %v", err)
+	}
+	if len(repo.webhookList) != 0 {
+		t.Errorf("Expected no webhook to be saved, got %d", len(repo.webhookList))
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/k98a73/go-todo/internal/domain"
 )
@@ -14,8 +15,17 @@ func NewDeleteTodoUsecase(repo domain.IRepository) *DeleteTodoUsecase {
 	return &DeleteTodoUsecase{repo: repo}
 }
 
+// Execute は Todo をゴミ箱に移動する。完全に削除するには PurgeTodoUsecase を使う。
 func (u *DeleteTodoUsecase) Execute(ctx context.Context, id int) error {
-	if err := u.repo.Delete(ctx, id); err != nil {
+	todo, err := findActiveTodo(ctx, u.repo, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	todo.DeletedAt = &now
+
+	if err := u.repo.Update(ctx, todo); err != nil {
 		return err
 	}
 
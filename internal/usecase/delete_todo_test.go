@@ -4,10 +4,21 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
 )
 
 func TestDeleteTodoUsecase_Execute(t *testing.T) {
-	mock := &MockRepository{}
+	// Given: 1件の Todo があるリポジトリ
+	// When:  Execute を呼び出す
+	// Then:  物理削除されず、DeletedAt が設定されて保存される
+	now := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, CreatedAt: now, UpdatedAt: now},
+		},
+	}
 	usecase := NewDeleteTodoUsecase(mock)
 
 	err := usecase.Execute(context.Background(), 1)
@@ -15,24 +26,63 @@ func TestDeleteTodoUsecase_Execute(t *testing.T) {
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
-	if !mock.deleteCalled {
-		t.Error("Expected Delete to be called")
+	if mock.deleteCalled {
+		t.Error("Expected Delete not to be called")
+	}
+	if !mock.updateCalled {
+		t.Fatal("Expected Update to be called")
+	}
+	if mock.updatedTodo.ID != 1 {
+		t.Errorf("Expected updated ID 1, got %d", mock.updatedTodo.ID)
+	}
+	if !mock.updatedTodo.IsDeleted() {
+		t.Error("Expected DeletedAt to be set")
+	}
+}
+
+func TestDeleteTodoUsecase_Execute_AlreadyDeleted(t *testing.T) {
+	// Given: ゴミ箱に入っている Todo
+	// When:  Execute を呼び出す
+	// Then:  "todo not found" エラーが返る
+	deletedAt := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, DeletedAt: &deletedAt},
+		},
+	}
+	usecase := NewDeleteTodoUsecase(mock)
+
+	err := usecase.Execute(context.Background(), 1)
+
+	if !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Errorf("Expected ErrTodoNotFound, got %v", err)
 	}
-	if mock.deletedID != 1 {
-		t.Errorf("Expected deleted ID 1, got %d", mock.deletedID)
+}
+
+func TestDeleteTodoUsecase_Execute_NotFound(t *testing.T) {
+	mock := &MockRepository{todoList: []*domain.Todo{}}
+	usecase := NewDeleteTodoUsecase(mock)
+
+	err := usecase.Execute(context.Background(), 999)
+
+	if err == nil {
+		t.Error("Expected error for non-existent todo")
 	}
 }
 
 func TestDeleteTodoUsecase_Execute_RepoError(t *testing.T) {
-	// Given: repo.Delete がエラーを返すモック
+	// Given: repo.Update がエラーを返すモック
 	// When:  Execute を呼び出す
 	// Then:  エラーが伝播する
-	mock := &MockRepository{deleteErr: errors.New("storage failure")}
+	mock := &MockRepository{
+		todoList:  []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusTodo}},
+		updateErr: errors.New("storage failure"),
+	}
 	usecase := NewDeleteTodoUsecase(mock)
 
 	err := usecase.Execute(context.Background(), 1)
 
 	if err == nil {
-		t.Error("Expected error when repo.Delete fails")
+		t.Error("Expected error when repo.Update fails")
 	}
 }
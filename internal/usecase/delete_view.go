@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type DeleteViewUsecase struct {
+	views domain.IViewRepository
+}
+
+func NewDeleteViewUsecase(views domain.IViewRepository) *DeleteViewUsecase {
+	return &DeleteViewUsecase{views: views}
+}
+
+func (u *DeleteViewUsecase) Execute(ctx context.Context, id string) error {
+	view, err := findView(ctx, u.views, id)
+	if err != nil {
+		return err
+	}
+	if view.BuiltIn {
+		return domain.ErrViewReadOnly
+	}
+
+	return u.views.Delete(ctx, id)
+}
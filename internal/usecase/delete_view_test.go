@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestDeleteViewUsecase_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		wantErr     error
+		wantDeleted string
+	}{
+		{name: "deleted", id: "1", wantDeleted: "1"},
+		{name: "built-in", id: "today", wantErr: domain.ErrViewReadOnly},
+		{name: "not found", id: "2", wantErr: domain.ErrViewNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views := &MockViewRepository{viewList: []*domain.View{{ID: "1", Name: "Backend"}}}
+			usecase := NewDeleteViewUsecase(views)
+
+			err := usecase.Execute(context.Background(), tt.id)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if views.deletedID != tt.wantDeleted {
+				t.Errorf("Expected deleted ID '%s', got '%s'", tt.wantDeleted, views.deletedID)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type DeleteWebhookUsecase struct {
+	webhooks   domain.IWebhookRepository
+	deliveries domain.IDeliveryRepository
+}
+
+func NewDeleteWebhookUsecase(webhooks domain.IWebhookRepository, deliveries domain.IDeliveryRepository) *DeleteWebhookUsecase {
+	return &DeleteWebhookUsecase{webhooks: webhooks, deliveries: deliveries}
+}
+
+// Execute は Webhook と、送信待ちのものを含むその通知をすべて削除する。
+func (u *DeleteWebhookUsecase) Execute(ctx context.Context, id string) error {
+	if err := u.webhooks.Delete(ctx, id); err != nil {
+		return err
+	}
+	return u.deliveries.DeleteByWebhook(ctx, id)
+}
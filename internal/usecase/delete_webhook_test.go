@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestDeleteWebhookUsecase_Execute(t *testing.T) {
+	// Given: 登録済みの Webhook
+	repo := &MockWebhookRepository{webhookList: []*domain.Webhook{{ID: "1"}}}
+	deliveries := &MockDeliveryRepository{}
+	usecase := NewDeleteWebhookUsecase(repo, deliveries)
+
+	// When:  削除する
+	err := usecase.Execute(context.Background(), "1")
+
+	// Then:  Webhook とその通知が削除される
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if repo.deletedID != "1" || deliveries.deletedWebhookID != "1" {
+		t.Errorf("Expected webhook and deliveries to be deleted, got %q, %q", repo.deletedID, deliveries.deletedWebhookID)
+	}
+}
+
+func TestDeleteWebhookUsecase_Execute_NotFound(t *testing.T) {
+	deliveries := &MockDeliveryRepository{}
+	usecase := NewDeleteWebhookUsecase(&MockWebhookRepository{}, deliveries)
+
+	err := usecase.Execute(context.Background(), "1")
+
+	if !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("Expected ErrWebhookNotFound, got %v", err)
+	}
+	if deliveries.deletedWebhookID != "" {
+		t.Error("Expected deliveries not to be deleted")
+	}
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type DeliverWebhookUsecase struct {
+	webhooks   domain.IWebhookRepository
+	deliveries domain.IDeliveryRepository
+	sender     domain.IWebhookSender
+}
+
+func NewDeliverWebhookUsecase(webhooks domain.IWebhookRepository, deliveries domain.IDeliveryRepository, sender domain.IWebhookSender) *DeliverWebhookUsecase {
+	return &DeliverWebhookUsecase{webhooks: webhooks, deliveries: deliveries, sender: sender}
+}
+
+// Execute は送信時刻になった通知を送り、成功した件数を返す。
+// 2xx 以外の応答や通信エラーは間隔を延ばしながら再送し、domain.MaxDeliveryAttempts 回失敗したらあきらめる。
+func (u *DeliverWebhookUsecase) Execute(ctx context.Context, now time.Time) (int, error) {
+	due, err := u.deliveries.ListDue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, d := range due {
+		webhook, err := u.webhooks.FindByID(ctx, d.WebhookID)
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			d.Status = domain.DeliveryFailed
+			d.LastError = "webhook has been deleted"
+			d.UpdatedAt = now
+			if err := u.deliveries.Update(ctx, d); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+		if err != nil {
+			return delivered, err
+		}
+
+		code, err := u.sender.Send(ctx, webhook, d)
+		d.Attempts++
+		d.LastStatusCode = code
+		d.UpdatedAt = time.Now()
+		switch {
+		case err == nil && code >= 200 && code < 300:
+			d.Status = domain.DeliverySucceeded
+			d.LastError = ""
+			delivered++
+		default:
+			if err != nil {
+				d.LastError = err.Error()
+			} else {
+				d.LastError = fmt.Sprintf("unexpected status %d", code)
+			}
+			if d.Attempts >= domain.MaxDeliveryAttempts {
+				d.Status = domain.DeliveryFailed
+			} else {
+				d.NextAttemptAt = domain.NextDeliveryAttempt(now, d.Attempts)
+			}
+		}
+		if err := u.deliveries.Update(ctx, d); err != nil {
+			return delivered, err
+		}
+	}
+	return delivered, nil
+}
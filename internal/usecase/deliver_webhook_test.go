@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// mockWebhookSender は Webhook の URL ごとに決めた結果を返す。
+type mockWebhookSender struct {
+	codes map[string]int
+	err   error
+	sent  []string
+}
+
+func (m *mockWebhookSender) Send(ctx context.Context, webhook *domain.Webhook, delivery *domain.WebhookDelivery) (int, error) {
+	m.sent = append(m.sent, delivery.ID)
+	if m.err != nil {
+		return 0, m.err
+	}
+	return m.codes[webhook.URL], nil
+}
+
+func TestDeliverWebhookUsecase_Execute(t *testing.T) {
+	// Given: 成功する Webhook・失敗する Webhook・削除済みの Webhook への通知と、まだ送信時刻でない通知
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &MockWebhookRepository{webhookList: []*domain.Webhook{
+		{ID: "1", URL: "https://ok.example.com"},
+		{ID: "2", URL: "https://down.example.com"},
+	}}
+	deliveries := &MockDeliveryRepository{deliveryList: []*domain.WebhookDelivery{
+		{ID: "1", WebhookID: "1", Status: domain.DeliveryPending, NextAttemptAt: now},
+		{ID: "2", WebhookID: "2", Status: domain.DeliveryPending, NextAttemptAt: now},
+		{ID: "3", WebhookID: "3", Status: domain.DeliveryPending, NextAttemptAt: now},
+		{ID: "4", WebhookID: "1", Status: domain.DeliveryPending, NextAttemptAt: now.Add(time.Minute)},
+	}}
+	sender := &mockWebhookSender{codes: map[string]int{"https://ok.example.com": 204, "https://down.example.com": 503}}
+	usecase := NewDeliverWebhookUsecase(repo, deliveries, sender)
+
+	// When:  実行する
+	delivered, err := usecase.Execute(context.Background(), now)
+
+	// Then:  送信時刻の来た通知だけが送られ、結果に応じて状態が更新される
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if delivered != 1 {
+		t.Errorf("Expected 1 delivered, got %d", delivered)
+	}
+	if len(sender.sent) != 2 {
+		t.Errorf("Expected 2 sends, got %v", sender.sent)
+	}
+	ok, down, deleted := deliveries.deliveryList[0], deliveries.deliveryList[1], deliveries.deliveryList[2]
+	if ok.Status != domain.DeliverySucceeded || ok.Attempts != 1 || ok.LastStatusCode != 204 {
+		t.Errorf("Unexpected succeeded delivery: %+v", ok)
+	}
+	if down.Status != domain.DeliveryPending || down.Attempts != 1 || down.LastStatusCode != 503 || !down.NextAttemptAt.Equal(now.Add(30*time.Second)) {
+		t.Errorf("Unexpected retried delivery: %+v", down)
+	}
+	if deleted.Status != domain.DeliveryFailed || deleted.Attempts != 0 {
+		t.Errorf("Unexpected delivery for deleted webhook: %+v", deleted)
+	}
+}
+
+func TestDeliverWebhookUsecase_Execute_GiveUp(t *testing.T) {
+	// Given: 上限の1回手前まで失敗している通知
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &MockWebhookRepository{webhookList: []*domain.Webhook{{ID: "1", URL: "https://down.example.com"}}}
+	deliveries := &MockDeliveryRepository{deliveryList: []*domain.WebhookDelivery{
+		{ID: "1", WebhookID: "1", Status: domain.DeliveryPending, Attempts: domain.MaxDeliveryAttempts - 1, NextAttemptAt: now},
+	}}
+	usecase := NewDeliverWebhookUsecase(repo, deliveries, &mockWebhookSender{err: errors.New("connection refused")})
+
+	// When:  もう一度失敗する
+	if _, err := usecase.Execute(context.Background(), now); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then:  失敗として記録され、再送されない
+	d := deliveries.deliveryList[0]
+	if d.Status != domain.DeliveryFailed || d.LastError != "connection refused" {
+		t.Errorf("Unexpected delivery: %+v", d)
+	}
+}
@@ -15,5 +15,17 @@ func NewFindByIDTodoUsecase(repo domain.IRepository) *FindByIDTodoUsecase {
 }
 
 func (u *FindByIDTodoUsecase) Execute(ctx context.Context, id int) (*domain.Todo, error) {
-	return u.repo.FindByID(ctx, id)
+	return findActiveTodo(ctx, u.repo, id)
+}
+
+// findActiveTodo はゴミ箱に入っている Todo を存在しないものとして扱う。
+func findActiveTodo(ctx context.Context, repo domain.IRepository, id int) (*domain.Todo, error) {
+	todo, err := repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if todo.IsDeleted() {
+		return nil, domain.ErrTodoNotFound
+	}
+	return todo, nil
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -12,8 +13,8 @@ func TestFindByIDTodoUsecase_Execute(t *testing.T) {
 	now := time.Now()
 	mock := &MockRepository{
 		todoList: []*domain.Todo{
-			{ID: 1, Title: "Buy milk", Completed: false, CreatedAt: now, UpdatedAt: now},
-			{ID: 2, Title: "Read book", Completed: true, CreatedAt: now, UpdatedAt: now},
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, CreatedAt: now, UpdatedAt: now},
+			{ID: 2, Title: "Read book", Status: domain.StatusDone, CreatedAt: now, UpdatedAt: now},
 		},
 	}
 	usecase := NewFindByIDTodoUsecase(mock)
@@ -43,3 +44,22 @@ func TestFindByIDTodoUsecase_Execute_NotFound(t *testing.T) {
 		t.Error("Expected error for non-existent todo")
 	}
 }
+
+func TestFindByIDTodoUsecase_Execute_Trashed(t *testing.T) {
+	// Given: ゴミ箱に入っている Todo
+	// When:  Execute を呼び出す
+	// Then:  ErrTodoNotFound が返る
+	deletedAt := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, DeletedAt: &deletedAt},
+		},
+	}
+	usecase := NewFindByIDTodoUsecase(mock)
+
+	_, err := usecase.Execute(context.Background(), 1)
+
+	if !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Errorf("Expected ErrTodoNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type FindViewUsecase struct {
+	views domain.IViewRepository
+}
+
+func NewFindViewUsecase(views domain.IViewRepository) *FindViewUsecase {
+	return &FindViewUsecase{views: views}
+}
+
+func (u *FindViewUsecase) Execute(ctx context.Context, id string) (*domain.View, error) {
+	return findView(ctx, u.views, id)
+}
+
+func findView(ctx context.Context, views domain.IViewRepository, id string) (*domain.View, error) {
+	for _, view := range domain.BuiltInViews() {
+		if view.ID == id {
+			return view, nil
+		}
+	}
+	return views.FindByID(ctx, id)
+}
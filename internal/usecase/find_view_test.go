@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestFindViewUsecase_Execute(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr error
+	}{
+		{name: "saved view", id: "1"},
+		{name: "built-in view", id: "today"},
+		{name: "not found", id: "2", wantErr: domain.ErrViewNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views := &MockViewRepository{viewList: []*domain.View{{ID: "1", Name: "Backend"}}}
+			usecase := NewFindViewUsecase(views)
+
+			view, err := usecase.Execute(context.Background(), tt.id)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if err == nil && view.ID != tt.id {
+				t.Errorf("Expected ID '%s', got '%s'", tt.id, view.ID)
+			}
+		})
+	}
+}
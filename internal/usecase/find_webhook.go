@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type FindWebhookUsecase struct {
+	webhooks domain.IWebhookRepository
+}
+
+func NewFindWebhookUsecase(webhooks domain.IWebhookRepository) *FindWebhookUsecase {
+	return &FindWebhookUsecase{webhooks: webhooks}
+}
+
+func (u *FindWebhookUsecase) Execute(ctx context.Context, id string) (*domain.Webhook, error) {
+	return u.webhooks.FindByID(ctx, id)
+}
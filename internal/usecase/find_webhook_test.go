@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestFindWebhookUsecase_Execute(t *testing.T) {
+	repo := &MockWebhookRepository{webhookList: []*domain.Webhook{{ID: "1", URL: "https://example.com/hook"}}}
+	usecase := NewFindWebhookUsecase(repo)
+
+	webhook, err := usecase.Execute(context.Background(), "1")
+	if err != nil || webhook.URL != "https://example.com/hook" {
+		t.Errorf("Unexpected result: %+v, %v", webhook, err)
+	}
+
+	if _, err := usecase.Execute(context.Background(), "2"); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("Expected ErrWebhookNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ImportTodoUsecase struct {
+	repo    domain.IRepository
+	machine *domain.StateMachine
+}
+
+func NewImportTodoUsecase(repo domain.IRepository, machine *domain.StateMachine) *ImportTodoUsecase {
+	return &ImportTodoUsecase{repo: repo, machine: machine}
+}
+
+// Execute は items を1回のトランザクションで作成する。1件でも失敗すれば何も作成しない。
+// ステータスは初期ステータスから状態遷移で変えるため、履歴にも取り込んだ時刻の遷移が残る。
+func (u *ImportTodoUsecase) Execute(ctx context.Context, items []domain.ImportTodo) (*domain.ImportResult, error) {
+	for _, item := range items {
+		if item.Status != "" && !u.machine.IsValid(item.Status) {
+			return nil, domain.ErrInvalidStatus
+		}
+	}
+
+	result := &domain.ImportResult{Created: []*domain.Todo{}}
+	err := u.repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		for _, item := range items {
+			if item.ExportedID > 0 {
+				_, err := tx.FindByID(ctx, item.ExportedID)
+				if err == nil {
+					result.Skipped++
+					continue
+				}
+				if !errors.Is(err, domain.ErrTodoNotFound) {
+					return err
+				}
+			}
+
+			todo, err := u.create(ctx, tx, item)
+			if err != nil {
+				return err
+			}
+			result.Created = append(result.Created, todo)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (u *ImportTodoUsecase) create(ctx context.Context, tx domain.IRepository, item domain.ImportTodo) (*domain.Todo, error) {
+	todo, err := NewCreateTodoUsecase(tx, u.machine).Execute(ctx, item.Title)
+	if err != nil || item.Status == "" || item.Status == todo.Status {
+		return todo, err
+	}
+
+	now := time.Now()
+	if err := u.machine.Transition(todo, item.Status, now); err != nil {
+		return nil, err
+	}
+	if item.Status == domain.StatusDone && item.CompletedAt != nil {
+		completedAt := *item.CompletedAt
+		todo.CompletedAt = &completedAt
+	}
+	todo.UpdatedAt = now
+	if err := tx.Update(ctx, todo); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestImportTodoUsecase_Execute(t *testing.T) {
+	// Given: 既存の Todo が1件あるリポジトリと、ステータスの異なる取り込み対象
+	mock := &MockRepository{
+		todoList: []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusTodo}},
+		nextID:   2,
+	}
+	usecase := NewImportTodoUsecase(mock, domain.DefaultStateMachine())
+	completedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// When:  取り込む
+	result, err := usecase.Execute(context.Background(), []domain.ImportTodo{
+		{Title: "Write report"},
+		{Title: "Call Bob", Status: domain.StatusDone, CompletedAt: &completedAt},
+		{Title: "Buy milk", ExportedID: 1},
+		{Title: "Old export", ExportedID: 99, Status: domain.StatusInProgress},
+	})
+
+	// Then:  残っている書き出し済みの Todo は飛ばし、他はステータスと完了日時を保って作成する
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Skipped != 1 || len(result.Created) != 3 || mock.txCalled != 1 {
+		t.Fatalf("Expected 3 created and 1 skipped in one transaction, got %d %d (tx %d)", len(result.Created), result.Skipped, mock.txCalled)
+	}
+	done := result.Created[1]
+	if done.Status != domain.StatusDone || done.CompletedAt == nil || !done.CompletedAt.Equal(completedAt) || len(done.StatusHistory) != 1 {
+		t.Errorf("Unexpected done todo: %+v", done)
+	}
+	if result.Created[0].Status != domain.StatusTodo || result.Created[2].Status != domain.StatusInProgress {
+		t.Errorf("Unexpected statuses: %s %s", result.Created[0].Status, result.Created[2].Status)
+	}
+	if len(mock.todoList) != 4 {
+		t.Errorf("Expected 4 todos, got %d", len(mock.todoList))
+	}
+}
+
+func TestImportTodoUsecase_Execute_RollsBack(t *testing.T) {
+	tests := []struct {
+		name    string
+		items   []domain.ImportTodo
+		wantErr error
+	}{
+		{name: "invalid status", items: []domain.ImportTodo{{Title: "ok"}, {Title: "bad", Status: "waiting"}}, wantErr: domain.ErrInvalidStatus},
+		{name: "empty title", items: []domain.ImportTodo{{Title: "ok"}, {Title: ""}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: 途中に不正な項目を含む取り込み対象
+			mock := &MockRepository{nextID: 1}
+			usecase := NewImportTodoUsecase(mock, domain.DefaultStateMachine())
+
+			// When:  取り込む
+			_, err := usecase.Execute(context.Background(), tt.items)
+
+			// Then:  エラーになり、何も作成されない
+			if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(mock.todoList) != 0 {
+				t.Errorf("Expected no todos, got %d", len(mock.todoList))
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ListArchiveTodoUsecase struct {
+	archive domain.IRepository
+}
+
+func NewListArchiveTodoUsecase(archive domain.IRepository) *ListArchiveTodoUsecase {
+	return &ListArchiveTodoUsecase{archive: archive}
+}
+
+// Execute は query をタイトルに含むアーカイブ済み Todo を返す。query が空なら全件。
+func (u *ListArchiveTodoUsecase) Execute(ctx context.Context, query string) ([]*domain.Todo, error) {
+	todos, err := u.archive.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	todoList := make([]*domain.Todo, 0, len(todos))
+	for _, todo := range todos {
+		if query == "" || strings.Contains(strings.ToLower(todo.Title), query) {
+			todoList = append(todoList, todo)
+		}
+	}
+	return todoList, nil
+}
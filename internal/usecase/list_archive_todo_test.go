@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestListArchiveTodoUsecase_Execute(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantIDs []int
+	}{
+		{name: "empty query returns all", query: "", wantIDs: []int{1, 2}},
+		{name: "case insensitive match", query: "MILK", wantIDs: []int{1}},
+		{name: "surrounding spaces are ignored", query: "  book ", wantIDs: []int{2}},
+		{name: "no match", query: "gym", wantIDs: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := &MockRepository{
+				todoList: []*domain.Todo{
+					{ID: 1, Title: "Buy milk", Status: domain.StatusDone},
+					{ID: 2, Title: "Read book", Status: domain.StatusCancelled},
+				},
+			}
+			usecase := NewListArchiveTodoUsecase(archive)
+
+			todoList, err := usecase.Execute(context.Background(), tt.query)
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(todoList) != len(tt.wantIDs) {
+				t.Fatalf("Expected %d todos, got %d", len(tt.wantIDs), len(todoList))
+			}
+			for i, id := range tt.wantIDs {
+				if todoList[i].ID != id {
+					t.Errorf("Expected ID %d at %d, got %d", id, i, todoList[i].ID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ListAuditUsecase struct {
+	log domain.IAuditLog
+}
+
+func NewListAuditUsecase(log domain.IAuditLog) *ListAuditUsecase {
+	return &ListAuditUsecase{log: log}
+}
+
+func (u *ListAuditUsecase) Execute(ctx context.Context, query domain.AuditQuery) ([]*domain.AuditEntry, error) {
+	if err := domain.ValidateAuditQuery(query); err != nil {
+		return nil, err
+	}
+	return u.log.Query(ctx, query)
+}
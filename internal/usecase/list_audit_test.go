@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockAuditLog struct {
+	entries     []*domain.AuditEntry
+	queryCalled bool
+}
+
+func (m *mockAuditLog) Append(ctx context.Context, entries []*domain.AuditEntry) error {
+	m.entries = append(m.entries, entries...)
+	return nil
+}
+
+func (m *mockAuditLog) Query(ctx context.Context, q domain.AuditQuery) ([]*domain.AuditEntry, error) {
+	m.queryCalled = true
+	var entries []*domain.AuditEntry
+	for _, e := range m.entries {
+		if q.Match(e) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func TestListAuditUsecase_Execute(t *testing.T) {
+	// Given: alice と bob の操作が記録された監査ログ
+	log := &mockAuditLog{entries: []*domain.AuditEntry{
+		{Seq: 1, Actor: "alice", Action: "todo_created"},
+		{Seq: 2, Actor: "bob", Action: "todo_created"},
+		{Seq: 3, Actor: "alice", Action: "title_changed"},
+	}}
+	usecase := NewListAuditUsecase(log)
+
+	// When:  alice の操作で絞り込む
+	entries, err := usecase.Execute(context.Background(), domain.AuditQuery{Actor: "alice"})
+
+	// Then:  alice の操作だけが返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 2 || entries[0].Seq != 1 || entries[1].Seq != 3 {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+}
+
+func TestListAuditUsecase_Execute_InvalidQuery(t *testing.T) {
+	// Given: 開始が終了より後の期間
+	log := &mockAuditLog{}
+	usecase := NewListAuditUsecase(log)
+	since := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// When:  実行する
+	_, err := usecase.Execute(context.Background(), domain.AuditQuery{Since: &since, Until: &until})
+
+	// Then:  ErrInvalidAuditQuery が返り、監査ログは参照しない
+	if !errors.Is(err, domain.ErrInvalidAuditQuery) {
+		t.Errorf("Expected ErrInvalidAuditQuery, got %v", err)
+	}
+	if log.queryCalled {
+		t.Error("Expected Query not to be called")
+	}
+}
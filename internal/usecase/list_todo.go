@@ -4,16 +4,44 @@ import (
 	"context"
 
 	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/filter"
 )
 
 type ListTodoUsecase struct {
-	repo domain.IRepository
+	repo    domain.IRepository
+	machine *domain.StateMachine
 }
 
-func NewListTodoUsecase(repo domain.IRepository) *ListTodoUsecase {
-	return &ListTodoUsecase{repo: repo}
+func NewListTodoUsecase(repo domain.IRepository, machine *domain.StateMachine) *ListTodoUsecase {
+	return &ListTodoUsecase{repo: repo, machine: machine}
 }
 
-func (u *ListTodoUsecase) Execute(ctx context.Context) ([]*domain.Todo, error) {
-	return u.repo.List(ctx)
+func (u *ListTodoUsecase) Execute(ctx context.Context, query domain.ListQuery) ([]*domain.Todo, error) {
+	if err := domain.ValidateListQuery(query); err != nil {
+		return nil, err
+	}
+	expr, err := filter.Parse(query.Filter, u.machine)
+	if err != nil {
+		return nil, err
+	}
+
+	todos, err := u.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todoList := make([]*domain.Todo, 0, len(todos))
+	for _, todo := range todos {
+		if !todo.IsDeleted() && expr.Match(todo) {
+			todoList = append(todoList, todo)
+		}
+	}
+
+	if err := domain.SortTodos(todoList, query.Sort); err != nil {
+		return nil, err
+	}
+	if query.Limit > 0 && len(todoList) > query.Limit {
+		todoList = todoList[:query.Limit]
+	}
+	return todoList, nil
 }
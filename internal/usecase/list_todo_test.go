@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -12,13 +13,13 @@ func TestListTodoUsecase_Execute(t *testing.T) {
 	now := time.Now()
 	mock := &MockRepository{
 		todoList: []*domain.Todo{
-			{ID: 1, Title: "Buy milk", Completed: false, CreatedAt: now, UpdatedAt: now},
-			{ID: 2, Title: "Read book", Completed: true, CreatedAt: now, UpdatedAt: now},
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, CreatedAt: now, UpdatedAt: now},
+			{ID: 2, Title: "Read book", Status: domain.StatusDone, CreatedAt: now, UpdatedAt: now},
 		},
 	}
-	usecase := NewListTodoUsecase(mock)
+	usecase := NewListTodoUsecase(mock, domain.DefaultStateMachine())
 
-	todoList, err := usecase.Execute(context.Background())
+	todoList, err := usecase.Execute(context.Background(), domain.ListQuery{})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -35,9 +36,9 @@ func TestListTodoUsecase_Execute_Empty(t *testing.T) {
 	mock := &MockRepository{
 		todoList: []*domain.Todo{},
 	}
-	usecase := NewListTodoUsecase(mock)
+	usecase := NewListTodoUsecase(mock, domain.DefaultStateMachine())
 
-	todoList, err := usecase.Execute(context.Background())
+	todoList, err := usecase.Execute(context.Background(), domain.ListQuery{})
 
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
@@ -46,3 +47,98 @@ func TestListTodoUsecase_Execute_Empty(t *testing.T) {
 		t.Errorf("Expected 0 todos, got %d", len(todoList))
 	}
 }
+
+func TestListTodoUsecase_Execute_ExcludesTrash(t *testing.T) {
+	// Given: ゴミ箱の Todo を含むリポジトリ
+	// When:  Execute を呼び出す
+	// Then:  ゴミ箱の Todo は含まれない
+	deletedAt := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo},
+			{ID: 2, Title: "Read book", Status: domain.StatusTodo, DeletedAt: &deletedAt},
+		},
+	}
+	usecase := NewListTodoUsecase(mock, domain.DefaultStateMachine())
+
+	todoList, err := usecase.Execute(context.Background(), domain.ListQuery{})
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(todoList) != 1 || todoList[0].ID != 1 {
+		t.Errorf("Expected only todo 1, got %v", todoList)
+	}
+}
+
+func TestListTodoUsecase_Execute_InvalidSort(t *testing.T) {
+	mock := &MockRepository{todoList: []*domain.Todo{}}
+	usecase := NewListTodoUsecase(mock, domain.DefaultStateMachine())
+
+	_, err := usecase.Execute(context.Background(), domain.ListQuery{Sort: "title"})
+
+	if !errors.Is(err, domain.ErrInvalidSort) {
+		t.Errorf("Expected ErrInvalidSort, got %v", err)
+	}
+}
+
+func TestListTodoUsecase_Execute_Filter(t *testing.T) {
+	// Given: ステータスの異なる Todo
+	// When:  検索式を指定して Execute を呼び出す
+	// Then:  一致する Todo だけが返る
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo},
+			{ID: 2, Title: "Buy eggs", Status: domain.StatusDone},
+			{ID: 3, Title: "Read book", Status: domain.StatusTodo},
+		},
+	}
+	usecase := NewListTodoUsecase(mock, domain.DefaultStateMachine())
+
+	todoList, err := usecase.Execute(context.Background(), domain.ListQuery{Filter: "status:open buy"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(todoList) != 1 || todoList[0].ID != 1 {
+		t.Errorf("Expected only ID 1, got %+v", todoList)
+	}
+}
+
+func TestListTodoUsecase_Execute_InvalidFilter(t *testing.T) {
+	// Given: 存在しないフィールドを含む検索式
+	// When:  Execute を呼び出す
+	// Then:  ErrInvalidFilter が返り、リポジトリは参照されない
+	mock := &MockRepository{}
+	usecase := NewListTodoUsecase(mock, domain.DefaultStateMachine())
+
+	_, err := usecase.Execute(context.Background(), domain.ListQuery{Filter: "tag:backend"})
+
+	if !errors.Is(err, domain.ErrInvalidFilter) {
+		t.Errorf("Expected ErrInvalidFilter, got %v", err)
+	}
+}
+
+func TestListTodoUsecase_Execute_Limit(t *testing.T) {
+	// Given: 3件の Todo
+	// When:  Limit 2 で Execute を呼び出す
+	// Then:  並べ替え後の先頭2件だけが返る
+	base := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, CreatedAt: base.Add(2 * time.Hour)},
+			{ID: 2, Title: "Buy eggs", Status: domain.StatusTodo, CreatedAt: base},
+			{ID: 3, Title: "Read book", Status: domain.StatusTodo, CreatedAt: base.Add(time.Hour)},
+		},
+	}
+	usecase := NewListTodoUsecase(mock, domain.DefaultStateMachine())
+
+	todoList, err := usecase.Execute(context.Background(), domain.ListQuery{Sort: domain.SortCreatedAt, Limit: 2})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(todoList) != 2 || todoList[0].ID != 2 || todoList[1].ID != 3 {
+		t.Errorf("Expected IDs [2 3], got %+v", todoList)
+	}
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ListTrashTodoUsecase struct {
+	repo domain.IRepository
+}
+
+func NewListTrashTodoUsecase(repo domain.IRepository) *ListTrashTodoUsecase {
+	return &ListTrashTodoUsecase{repo: repo}
+}
+
+func (u *ListTrashTodoUsecase) Execute(ctx context.Context) ([]*domain.Todo, error) {
+	todos, err := u.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	todoList := make([]*domain.Todo, 0)
+	for _, todo := range todos {
+		if todo.IsDeleted() {
+			todoList = append(todoList, todo)
+		}
+	}
+	return todoList, nil
+}
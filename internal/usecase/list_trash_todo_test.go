@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestListTrashTodoUsecase_Execute(t *testing.T) {
+	// Given: 通常の Todo とゴミ箱の Todo が混在するリポジトリ
+	// When:  Execute を呼び出す
+	// Then:  ゴミ箱の Todo だけが返る
+	deletedAt := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo},
+			{ID: 2, Title: "Read book", Status: domain.StatusTodo, DeletedAt: &deletedAt},
+		},
+	}
+	usecase := NewListTrashTodoUsecase(mock)
+
+	todoList, err := usecase.Execute(context.Background())
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(todoList) != 1 || todoList[0].ID != 2 {
+		t.Errorf("Expected only todo 2, got %v", todoList)
+	}
+}
+
+func TestListTrashTodoUsecase_Execute_Empty(t *testing.T) {
+	mock := &MockRepository{
+		todoList: []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusTodo}},
+	}
+	usecase := NewListTrashTodoUsecase(mock)
+
+	todoList, err := usecase.Execute(context.Background())
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if todoList == nil || len(todoList) != 0 {
+		t.Errorf("Expected empty non-nil slice, got %v", todoList)
+	}
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ListViewUsecase struct {
+	views domain.IViewRepository
+}
+
+func NewListViewUsecase(views domain.IViewRepository) *ListViewUsecase {
+	return &ListViewUsecase{views: views}
+}
+
+// Execute は組み込みビューに続けて保存済みのビューを返す。
+func (u *ListViewUsecase) Execute(ctx context.Context) ([]*domain.View, error) {
+	views, err := u.views.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(domain.BuiltInViews(), views...), nil
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestListViewUsecase_Execute(t *testing.T) {
+	// Given: 保存済みのビューが1件
+	// When:  Execute を呼び出す
+	// Then:  組み込みビューの後に保存済みのビューが並ぶ
+	views := &MockViewRepository{viewList: []*domain.View{{ID: "1", Name: "Backend"}}}
+	usecase := NewListViewUsecase(views)
+
+	viewList, err := usecase.Execute(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	builtIn := len(domain.BuiltInViews())
+	if len(viewList) != builtIn+1 {
+		t.Fatalf("Expected %d views, got %d", builtIn+1, len(viewList))
+	}
+	if !viewList[0].BuiltIn || viewList[builtIn].ID != "1" {
+		t.Errorf("Expected built-in views first, got %+v", viewList)
+	}
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ListViewTodoUsecase struct {
+	views   domain.IViewRepository
+	repo    domain.IRepository
+	machine *domain.StateMachine
+}
+
+func NewListViewTodoUsecase(views domain.IViewRepository, repo domain.IRepository, machine *domain.StateMachine) *ListViewTodoUsecase {
+	return &ListViewTodoUsecase{views: views, repo: repo, machine: machine}
+}
+
+// Execute はビューに保存された条件で Todo 一覧を返す。
+func (u *ListViewTodoUsecase) Execute(ctx context.Context, id string) ([]*domain.Todo, error) {
+	view, err := findView(ctx, u.views, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewListTodoUsecase(u.repo, u.machine).Execute(ctx, view.Query)
+}
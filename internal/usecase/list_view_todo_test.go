@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestListViewTodoUsecase_Execute(t *testing.T) {
+	// Given: 未完了の backend を絞り込むビュー
+	// When:  Execute を呼び出す
+	// Then:  ビューの条件・並び順・件数で絞り込まれた Todo が返る
+	base := time.Now()
+	views := &MockViewRepository{viewList: []*domain.View{
+		{ID: "1", Name: "Backend", Query: domain.ListQuery{Filter: "status:open backend", Sort: domain.SortCreatedAt, Limit: 2}},
+	}}
+	repo := &MockRepository{todoList: []*domain.Todo{
+		{ID: 1, Title: "backend: fix login", Status: domain.StatusTodo, CreatedAt: base.Add(3 * time.Hour)},
+		{ID: 2, Title: "backend: add index", Status: domain.StatusDone, CreatedAt: base},
+		{ID: 3, Title: "frontend: new form", Status: domain.StatusTodo, CreatedAt: base},
+		{ID: 4, Title: "backend: retry jobs", Status: domain.StatusInProgress, CreatedAt: base.Add(time.Hour)},
+		{ID: 5, Title: "backend: metrics", Status: domain.StatusTodo, CreatedAt: base.Add(2 * time.Hour)},
+	}}
+	usecase := NewListViewTodoUsecase(views, repo, domain.DefaultStateMachine())
+
+	todoList, err := usecase.Execute(context.Background(), "1")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(todoList) != 2 || todoList[0].ID != 4 || todoList[1].ID != 5 {
+		t.Errorf("Expected IDs [4 5], got %+v", todoList)
+	}
+}
+
+func TestListViewTodoUsecase_Execute_BuiltIn(t *testing.T) {
+	// Given: 今日更新された Todo と数日前に更新された Todo
+	// When:  組み込みビュー today を実行する
+	// Then:  今日更新された未完了の Todo だけが返る
+	now := time.Now()
+	repo := &MockRepository{todoList: []*domain.Todo{
+		{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, UpdatedAt: now},
+		{ID: 2, Title: "Read book", Status: domain.StatusTodo, UpdatedAt: now.AddDate(0, 0, -3)},
+		{ID: 3, Title: "Buy eggs", Status: domain.StatusDone, UpdatedAt: now},
+	}}
+	usecase := NewListViewTodoUsecase(&MockViewRepository{}, repo, domain.DefaultStateMachine())
+
+	todoList, err := usecase.Execute(context.Background(), "today")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(todoList) != 1 || todoList[0].ID != 1 {
+		t.Errorf("Expected only ID 1, got %+v", todoList)
+	}
+}
+
+func TestListViewTodoUsecase_Execute_NotFound(t *testing.T) {
+	usecase := NewListViewTodoUsecase(&MockViewRepository{}, &MockRepository{}, domain.DefaultStateMachine())
+
+	_, err := usecase.Execute(context.Background(), "1")
+
+	if !errors.Is(err, domain.ErrViewNotFound) {
+		t.Errorf("Expected ErrViewNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type ListWebhookUsecase struct {
+	webhooks domain.IWebhookRepository
+}
+
+func NewListWebhookUsecase(webhooks domain.IWebhookRepository) *ListWebhookUsecase {
+	return &ListWebhookUsecase{webhooks: webhooks}
+}
+
+func (u *ListWebhookUsecase) Execute(ctx context.Context) ([]*domain.Webhook, error) {
+	return u.webhooks.List(ctx)
+}
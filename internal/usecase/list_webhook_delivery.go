@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// DefaultDeliveryLimit は送信履歴で件数を指定しなかった場合に返す件数。
+const DefaultDeliveryLimit = 50
+
+type ListWebhookDeliveryUsecase struct {
+	webhooks   domain.IWebhookRepository
+	deliveries domain.IDeliveryRepository
+}
+
+func NewListWebhookDeliveryUsecase(webhooks domain.IWebhookRepository, deliveries domain.IDeliveryRepository) *ListWebhookDeliveryUsecase {
+	return &ListWebhookDeliveryUsecase{webhooks: webhooks, deliveries: deliveries}
+}
+
+// Execute は Webhook の送信履歴を新しい順に返す。limit が 0 の場合は DefaultDeliveryLimit 件。
+func (u *ListWebhookDeliveryUsecase) Execute(ctx context.Context, webhookID string, limit int) ([]*domain.WebhookDelivery, error) {
+	if limit < 0 {
+		return nil, domain.ErrInvalidLimit
+	}
+	if limit == 0 {
+		limit = DefaultDeliveryLimit
+	}
+	if _, err := u.webhooks.FindByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	return u.deliveries.ListByWebhook(ctx, webhookID, limit)
+}
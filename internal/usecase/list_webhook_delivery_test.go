@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestListWebhookDeliveryUsecase_Execute(t *testing.T) {
+	// Given: 2つの Webhook の通知
+	repo := &MockWebhookRepository{webhookList: []*domain.Webhook{{ID: "1"}, {ID: "2"}}}
+	deliveries := &MockDeliveryRepository{deliveryList: []*domain.WebhookDelivery{
+		{ID: "1", WebhookID: "1"},
+		{ID: "2", WebhookID: "2"},
+		{ID: "3", WebhookID: "1"},
+	}}
+	usecase := NewListWebhookDeliveryUsecase(repo, deliveries)
+
+	// When:  Webhook 1 の送信履歴を取得する
+	list, err := usecase.Execute(context.Background(), "1", 0)
+
+	// Then:  Webhook 1 の通知が新しい順に返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(list) != 2 || list[0].ID != "3" || list[1].ID != "1" {
+		t.Errorf("Unexpected deliveries: %+v", list)
+	}
+}
+
+func TestListWebhookDeliveryUsecase_Execute_Error(t *testing.T) {
+	usecase := NewListWebhookDeliveryUsecase(&MockWebhookRepository{webhookList: []*domain.Webhook{{ID: "1"}}}, &MockDeliveryRepository{})
+
+	if _, err := usecase.Execute(context.Background(), "2", 0); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("Expected ErrWebhookNotFound, got %v", err)
+	}
+	if _, err := usecase.Execute(context.Background(), "1", -1); !errors.Is(err, domain.ErrInvalidLimit) {
+		t.Errorf("Expected ErrInvalidLimit, got %v", err)
+	}
+}
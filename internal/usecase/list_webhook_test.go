@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestListWebhookUsecase_Execute(t *testing.T) {
+	repo := &MockWebhookRepository{webhookList: []*domain.Webhook{{ID: "1"}, {ID: "2"}}}
+	usecase := NewListWebhookUsecase(repo)
+
+	webhooks, err := usecase.Execute(context.Background())
+
+	if err != nil || len(webhooks) != 2 {
+		t.Errorf("Expected 2 webhooks, got %d, %v", len(webhooks), err)
+	}
+}
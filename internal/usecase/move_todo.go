@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type MoveTodoUsecase struct {
+	repo domain.IRepository
+}
+
+func NewMoveTodoUsecase(repo domain.IRepository) *MoveTodoUsecase {
+	return &MoveTodoUsecase{repo: repo}
+}
+
+// Execute は id の Todo を afterID の直後、または beforeID の直前に移動する。
+// 片方だけ指定された場合、もう片方は現在の並び順から決める。0 は指定なしを表す。
+func (u *MoveTodoUsecase) Execute(ctx context.Context, id int, afterID int, beforeID int) (*domain.Todo, error) {
+	if (afterID == 0 && beforeID == 0) || afterID == id || beforeID == id {
+		return nil, domain.ErrInvalidMove
+	}
+
+	var moved *domain.Todo
+	err := u.repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		var err error
+		moved, err = move(ctx, tx, id, afterID, beforeID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return moved, nil
+}
+
+func move(ctx context.Context, repo domain.IRepository, id int, afterID int, beforeID int) (*domain.Todo, error) {
+	todos, err := repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *domain.Todo
+	ordered := make([]*domain.Todo, 0, len(todos))
+	for _, t := range todos {
+		if t.IsDeleted() {
+			continue
+		}
+		if t.ID == id {
+			target = t
+			continue
+		}
+		ordered = append(ordered, t)
+	}
+	if target == nil {
+		return nil, domain.ErrTodoNotFound
+	}
+	if err := domain.SortTodos(ordered, domain.SortPosition); err != nil {
+		return nil, err
+	}
+
+	index, err := insertIndex(ordered, afterID, beforeID)
+	if err != nil {
+		return nil, err
+	}
+	ordered = append(ordered[:index], append([]*domain.Todo{target}, ordered[index:]...)...)
+
+	now := time.Now()
+	position, err := positionAt(ordered, index)
+	if err != nil || len(position) > domain.MaxRankLength {
+		// キーを付けられない（旧データで未設定など）か長くなりすぎた場合は全体を振り直す
+		if err := rebalance(ctx, repo, ordered, target, now); err != nil {
+			return nil, err
+		}
+		return target, nil
+	}
+
+	target.Position = position
+	target.UpdatedAt = now
+	if err := repo.Update(ctx, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+func insertIndex(ordered []*domain.Todo, afterID int, beforeID int) (int, error) {
+	afterIndex, beforeIndex := -1, len(ordered)
+	for i, t := range ordered {
+		if t.ID == afterID {
+			afterIndex = i
+		}
+		if t.ID == beforeID {
+			beforeIndex = i
+		}
+	}
+
+	if afterID != 0 && afterIndex == -1 {
+		return 0, domain.ErrTodoNotFound
+	}
+	if beforeID != 0 && beforeIndex == len(ordered) {
+		return 0, domain.ErrTodoNotFound
+	}
+	if afterID != 0 && beforeID != 0 && afterIndex+1 != beforeIndex {
+		return 0, domain.ErrInvalidMove
+	}
+	if afterID != 0 {
+		return afterIndex + 1, nil
+	}
+	return beforeIndex, nil
+}
+
+func positionAt(ordered []*domain.Todo, index int) (string, error) {
+	prev, next := "", ""
+	if index > 0 {
+		prev = ordered[index-1].Position
+		if prev == "" {
+			return "", domain.ErrInvalidRank
+		}
+	}
+	if index < len(ordered)-1 {
+		next = ordered[index+1].Position
+		if next == "" {
+			return "", domain.ErrInvalidRank
+		}
+	}
+	return domain.RankBetween(prev, next)
+}
+
+// rebalance は複数の Todo を更新するため、呼び出し側のトランザクション内で実行する。
+func rebalance(ctx context.Context, repo domain.IRepository, ordered []*domain.Todo, target *domain.Todo, now time.Time) error {
+	rankList := domain.RebalanceRanks(len(ordered))
+	for i, t := range ordered {
+		if t.Position == rankList[i] && t != target {
+			continue
+		}
+		t.Position = rankList[i]
+		if t == target {
+			t.UpdatedAt = now
+		}
+		if err := repo.Update(ctx, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
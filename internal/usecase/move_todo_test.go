@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func newRankedMock() *MockRepository {
+	return &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "First", Status: domain.StatusTodo, Position: "a"},
+			{ID: 2, Title: "Second", Status: domain.StatusTodo, Position: "i"},
+			{ID: 3, Title: "Third", Status: domain.StatusTodo, Position: "r"},
+		},
+	}
+}
+
+func sortedIDs(t *testing.T, mock *MockRepository) []int {
+	t.Helper()
+	todoList, err := NewListTodoUsecase(mock, domain.DefaultStateMachine()).Execute(context.Background(), domain.ListQuery{Sort: domain.SortPosition})
+	if err != nil {
+		t.Fatal(err)
+	}
+	idList := make([]int, len(todoList))
+	for i, todo := range todoList {
+		idList[i] = todo.ID
+	}
+	return idList
+}
+
+func TestMoveTodoUsecase_Execute(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       int
+		afterID  int
+		beforeID int
+		wantIDs  []int
+	}{
+		{name: "after only", id: 3, afterID: 1, wantIDs: []int{1, 3, 2}},
+		{name: "before only", id: 3, beforeID: 1, wantIDs: []int{3, 1, 2}},
+		{name: "both neighbors", id: 1, afterID: 2, beforeID: 3, wantIDs: []int{2, 1, 3}},
+		{name: "to the end", id: 1, afterID: 3, wantIDs: []int{2, 3, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newRankedMock()
+			usecase := NewMoveTodoUsecase(mock)
+
+			todo, err := usecase.Execute(context.Background(), tt.id, tt.afterID, tt.beforeID)
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if todo.ID != tt.id {
+				t.Errorf("Expected ID %d, got %d", tt.id, todo.ID)
+			}
+			got := sortedIDs(t, mock)
+			for i, id := range tt.wantIDs {
+				if got[i] != id {
+					t.Fatalf("Expected order %v, got %v", tt.wantIDs, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMoveTodoUsecase_Execute_Rebalance(t *testing.T) {
+	// Given: 隣接するキーの間が詰まっていて、間のキーが MaxRankLength を超える
+	// When:  その間に移動する
+	// Then:  全体のキーが振り直され、短いキーで順序が保たれる
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "First", Status: domain.StatusTodo, Position: "a"},
+			{ID: 2, Title: "Second", Status: domain.StatusTodo, Position: "a" + strings.Repeat("0", domain.MaxRankLength) + "1"},
+			{ID: 3, Title: "Third", Status: domain.StatusTodo, Position: "r"},
+		},
+	}
+	usecase := NewMoveTodoUsecase(mock)
+
+	_, err := usecase.Execute(context.Background(), 3, 1, 2)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got := sortedIDs(t, mock)
+	if got[0] != 1 || got[1] != 3 || got[2] != 2 {
+		t.Errorf("Expected order [1 3 2], got %v", got)
+	}
+	for _, todo := range mock.todoList {
+		if len(todo.Position) > 1 {
+			t.Errorf("Expected rebalanced short key, got %q", todo.Position)
+		}
+	}
+}
+
+func TestMoveTodoUsecase_Execute_LegacyWithoutPosition(t *testing.T) {
+	// Given: 並び順を持たない旧データ
+	// When:  移動する
+	// Then:  全体にキーが振られ、指定した順になる
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "First", Status: domain.StatusTodo},
+			{ID: 2, Title: "Second", Status: domain.StatusTodo},
+		},
+	}
+	usecase := NewMoveTodoUsecase(mock)
+
+	_, err := usecase.Execute(context.Background(), 2, 0, 1)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	got := sortedIDs(t, mock)
+	if got[0] != 2 || got[1] != 1 {
+		t.Errorf("Expected order [2 1], got %v", got)
+	}
+}
+
+func TestMoveTodoUsecase_Execute_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       int
+		afterID  int
+		beforeID int
+		wantErr  error
+	}{
+		{name: "no neighbor", id: 1, wantErr: domain.ErrInvalidMove},
+		{name: "relative to itself", id: 1, afterID: 1, wantErr: domain.ErrInvalidMove},
+		{name: "neighbors not adjacent", id: 2, afterID: 1, beforeID: 1, wantErr: domain.ErrInvalidMove},
+		{name: "target not found", id: 99, afterID: 1, wantErr: domain.ErrTodoNotFound},
+		{name: "neighbor not found", id: 1, afterID: 99, wantErr: domain.ErrTodoNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newRankedMock()
+			usecase := NewMoveTodoUsecase(mock)
+
+			_, err := usecase.Execute(context.Background(), tt.id, tt.afterID, tt.beforeID)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if mock.updateCalled {
+				t.Error("Expected Update not to be called")
+			}
+		})
+	}
+}
+
+func TestMoveTodoUsecase_Execute_RepoUpdateError(t *testing.T) {
+	mock := newRankedMock()
+	mock.updateErr = errors.New("storage failure")
+	usecase := NewMoveTodoUsecase(mock)
+
+	_, err := usecase.Execute(context.Background(), 3, 1, 0)
+
+	if err == nil {
+		t.Error("Expected error when repo.Update fails")
+	}
+}
+
+func TestMoveTodoUsecase_Execute_RebalanceRunsInTransaction(t *testing.T) {
+	// Given: 振り直しが必要な状態で、保存が失敗するモック
+	// When:  移動する
+	// Then:  1つのトランザクション内で実行され、どの Todo の並び順も変わらない
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "First", Status: domain.StatusTodo},
+			{ID: 2, Title: "Second", Status: domain.StatusTodo},
+		},
+		updateErr: errors.New("storage failure"),
+	}
+	usecase := NewMoveTodoUsecase(mock)
+
+	_, err := usecase.Execute(context.Background(), 2, 0, 1)
+
+	if err == nil {
+		t.Fatal("Expected error when repo.Update fails")
+	}
+	if mock.txCalled != 1 {
+		t.Errorf("Expected 1 transaction, got %d", mock.txCalled)
+	}
+	for _, todo := range mock.todoList {
+		if todo.Position != "" {
+			t.Errorf("Expected no partial write, got position %q for %d", todo.Position, todo.ID)
+		}
+	}
+}
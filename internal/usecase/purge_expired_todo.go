@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type PurgeExpiredTodoUsecase struct {
+	repo      domain.IRepository
+	retention time.Duration
+}
+
+func NewPurgeExpiredTodoUsecase(repo domain.IRepository, retention time.Duration) *PurgeExpiredTodoUsecase {
+	return &PurgeExpiredTodoUsecase{repo: repo, retention: retention}
+}
+
+// Execute は保持期間を過ぎたゴミ箱内の Todo を完全に削除し、削除件数を返す。
+// 削除は1つのトランザクションで行い、途中で失敗した場合は1件も削除しない。
+func (u *PurgeExpiredTodoUsecase) Execute(ctx context.Context, now time.Time) (int, error) {
+	threshold := now.Add(-u.retention)
+	purged := 0
+	err := u.repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		todos, err := tx.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, todo := range todos {
+			if !todo.IsDeleted() || todo.DeletedAt.After(threshold) {
+				continue
+			}
+			if err := tx.Delete(ctx, todo.ID); err != nil {
+				return err
+			}
+			purged++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
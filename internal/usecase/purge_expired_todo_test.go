@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestPurgeExpiredTodoUsecase_Execute(t *testing.T) {
+	// Given: 保持期間を過ぎたもの・境界ちょうどのもの・期間内のもの・通常の Todo
+	// When:  Execute を呼び出す
+	// Then:  保持期間を過ぎたもの（境界を含む）だけが削除される
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	expired := now.Add(-31 * 24 * time.Hour)
+	boundary := now.Add(-30 * 24 * time.Hour)
+	recent := now.Add(-time.Hour)
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Active", Status: domain.StatusTodo},
+			{ID: 2, Title: "Recent", Status: domain.StatusTodo, DeletedAt: &recent},
+			{ID: 3, Title: "Boundary", Status: domain.StatusTodo, DeletedAt: &boundary},
+			{ID: 4, Title: "Expired", Status: domain.StatusTodo, DeletedAt: &expired},
+		},
+	}
+	usecase := NewPurgeExpiredTodoUsecase(mock, 30*24*time.Hour)
+
+	purged, err := usecase.Execute(context.Background(), now)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if purged != 2 {
+		t.Errorf("Expected 2 purged, got %d", purged)
+	}
+	if mock.deletedID != 4 {
+		t.Errorf("Expected last deleted ID 4, got %d", mock.deletedID)
+	}
+}
+
+func TestPurgeExpiredTodoUsecase_Execute_RepoError(t *testing.T) {
+	// Given: repo.Delete がエラーを返すモック
+	// When:  Execute を呼び出す
+	// Then:  エラーが伝播する
+	expired := time.Now().Add(-48 * time.Hour)
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Expired", Status: domain.StatusTodo, DeletedAt: &expired},
+		},
+		deleteErr: errors.New("storage failure"),
+	}
+	usecase := NewPurgeExpiredTodoUsecase(mock, time.Hour)
+
+	purged, err := usecase.Execute(context.Background(), time.Now())
+
+	if err == nil {
+		t.Error("Expected error when repo.Delete fails")
+	}
+	if purged != 0 {
+		t.Errorf("Expected 0 purged, got %d", purged)
+	}
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type PurgeTodoUsecase struct {
+	repo domain.IRepository
+}
+
+func NewPurgeTodoUsecase(repo domain.IRepository) *PurgeTodoUsecase {
+	return &PurgeTodoUsecase{repo: repo}
+}
+
+// Execute はゴミ箱内の Todo を完全に削除する。ゴミ箱にない Todo は対象外。
+func (u *PurgeTodoUsecase) Execute(ctx context.Context, id int) error {
+	if _, err := findTrashedTodo(ctx, u.repo, id); err != nil {
+		return err
+	}
+
+	return u.repo.Delete(ctx, id)
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestPurgeTodoUsecase_Execute(t *testing.T) {
+	// Given: ゴミ箱に入っている Todo
+	// When:  Execute を呼び出す
+	// Then:  repo.Delete で物理削除される
+	deletedAt := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, DeletedAt: &deletedAt},
+		},
+	}
+	usecase := NewPurgeTodoUsecase(mock)
+
+	err := usecase.Execute(context.Background(), 1)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if !mock.deleteCalled || mock.deletedID != 1 {
+		t.Errorf("Expected Delete(1) to be called, got called=%v id=%d", mock.deleteCalled, mock.deletedID)
+	}
+}
+
+func TestPurgeTodoUsecase_Execute_NotTrashed(t *testing.T) {
+	// Given: ゴミ箱に入っていない Todo
+	// When:  Execute を呼び出す
+	// Then:  ErrTodoNotFound が返り、削除されない
+	mock := &MockRepository{
+		todoList: []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusTodo}},
+	}
+	usecase := NewPurgeTodoUsecase(mock)
+
+	err := usecase.Execute(context.Background(), 1)
+
+	if !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Errorf("Expected ErrTodoNotFound, got %v", err)
+	}
+	if mock.deleteCalled {
+		t.Error("Expected Delete not to be called")
+	}
+}
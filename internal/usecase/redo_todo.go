@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type RedoTodoUsecase struct {
+	undoer domain.IUndoer
+}
+
+func NewRedoTodoUsecase(undoer domain.IUndoer) *RedoTodoUsecase {
+	return &RedoTodoUsecase{undoer: undoer}
+}
+
+func (u *RedoTodoUsecase) Execute(ctx context.Context) ([]domain.Change, error) {
+	return u.undoer.Redo(ctx)
+}
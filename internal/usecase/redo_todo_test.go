@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestRedoTodoUsecase_Execute(t *testing.T) {
+	// Given: alice のセッションにやり直せる変更がある
+	undoer := &mockUndoer{redo: map[string][]domain.Change{
+		"alice": {{ID: 1, Before: nil, After: &domain.Todo{ID: 1, Title: "Buy milk"}}},
+	}}
+	usecase := NewRedoTodoUsecase(undoer)
+
+	// When:  alice のセッションで実行する
+	changes, err := usecase.Execute(domain.WithSession(context.Background(), "alice"))
+
+	// Then:  やり直した変更が返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(changes) != 1 || changes[0].Before != nil || changes[0].After.Title != "Buy milk" {
+		t.Errorf("Unexpected changes: %+v", changes)
+	}
+}
+
+func TestRedoTodoUsecase_Execute_NothingToRedo(t *testing.T) {
+	usecase := NewRedoTodoUsecase(&mockUndoer{})
+
+	_, err := usecase.Execute(domain.WithSession(context.Background(), "bob"))
+
+	if !errors.Is(err, domain.ErrNothingToRedo) {
+		t.Errorf("Expected ErrNothingToRedo, got %v", err)
+	}
+}
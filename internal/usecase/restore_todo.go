@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type RestoreTodoUsecase struct {
+	repo domain.IRepository
+}
+
+func NewRestoreTodoUsecase(repo domain.IRepository) *RestoreTodoUsecase {
+	return &RestoreTodoUsecase{repo: repo}
+}
+
+func (u *RestoreTodoUsecase) Execute(ctx context.Context, id int) (*domain.Todo, error) {
+	todo, err := findTrashedTodo(ctx, u.repo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	todo.DeletedAt = nil
+
+	if err := u.repo.Update(ctx, todo); err != nil {
+		return nil, err
+	}
+
+	return todo, nil
+}
+
+func findTrashedTodo(ctx context.Context, repo domain.IRepository, id int) (*domain.Todo, error) {
+	todo, err := repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !todo.IsDeleted() {
+		return nil, domain.ErrTodoNotFound
+	}
+	return todo, nil
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestRestoreTodoUsecase_Execute(t *testing.T) {
+	// Given: ゴミ箱に入っている Todo
+	// When:  Execute を呼び出す
+	// Then:  DeletedAt がクリアされて保存される
+	deletedAt := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, DeletedAt: &deletedAt},
+		},
+	}
+	usecase := NewRestoreTodoUsecase(mock)
+
+	todo, err := usecase.Execute(context.Background(), 1)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if todo.IsDeleted() {
+		t.Error("Expected DeletedAt to be cleared")
+	}
+	if !mock.updateCalled {
+		t.Error("Expected Update to be called")
+	}
+}
+
+func TestRestoreTodoUsecase_Execute_NotTrashed(t *testing.T) {
+	// Given: ゴミ箱に入っていない Todo
+	// When:  Execute を呼び出す
+	// Then:  ErrTodoNotFound が返る
+	mock := &MockRepository{
+		todoList: []*domain.Todo{{ID: 1, Title: "Buy milk", Status: domain.StatusTodo}},
+	}
+	usecase := NewRestoreTodoUsecase(mock)
+
+	_, err := usecase.Execute(context.Background(), 1)
+
+	if !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Errorf("Expected ErrTodoNotFound, got %v", err)
+	}
+}
+
+func TestRestoreTodoUsecase_Execute_RepoError(t *testing.T) {
+	deletedAt := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, DeletedAt: &deletedAt},
+		},
+		updateErr: errors.New("storage failure"),
+	}
+	usecase := NewRestoreTodoUsecase(mock)
+
+	_, err := usecase.Execute(context.Background(), 1)
+
+	if err == nil {
+		t.Error("Expected error when repo.Update fails")
+	}
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type SearchTodoUsecase struct {
+	searcher domain.ISearcher
+}
+
+func NewSearchTodoUsecase(searcher domain.ISearcher) *SearchTodoUsecase {
+	return &SearchTodoUsecase{searcher: searcher}
+}
+
+func (u *SearchTodoUsecase) Execute(ctx context.Context, query string, limit int) ([]domain.SearchHit, error) {
+	return u.searcher.Search(ctx, query, limit)
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockSearcher struct {
+	query string
+	limit int
+	err   error
+}
+
+func (m *mockSearcher) Search(ctx context.Context, query string, limit int) ([]domain.SearchHit, error) {
+	m.query = query
+	m.limit = limit
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []domain.SearchHit{{Todo: &domain.Todo{ID: 1, Title: "Buy milk"}, Score: 1, Snippet: "Buy <mark>milk</mark>"}}, nil
+}
+
+func TestSearchTodoUsecase_Execute(t *testing.T) {
+	// Given: 検索結果を返す searcher
+	searcher := &mockSearcher{}
+	usecase := NewSearchTodoUsecase(searcher)
+
+	// When:  Execute を呼び出す
+	hitList, err := usecase.Execute(context.Background(), "milk", 5)
+
+	// Then:  query と limit がそのまま渡され、結果が返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if searcher.query != "milk" || searcher.limit != 5 {
+		t.Errorf("Expected query milk and limit 5, got %q and %d", searcher.query, searcher.limit)
+	}
+	if len(hitList) != 1 || hitList[0].Todo.ID != 1 {
+		t.Errorf("Expected 1 hit for ID 1, got %+v", hitList)
+	}
+}
+
+func TestSearchTodoUsecase_Execute_InvalidQuery(t *testing.T) {
+	// Given: 不正なクエリでエラーを返す searcher
+	usecase := NewSearchTodoUsecase(&mockSearcher{err: domain.ErrInvalidQuery})
+
+	// When:  Execute を呼び出す
+	_, err := usecase.Execute(context.Background(), `"milk`, 0)
+
+	// Then:  ErrInvalidQuery が返る
+	if !errors.Is(err, domain.ErrInvalidQuery) {
+		t.Errorf("Expected ErrInvalidQuery, got %v", err)
+	}
+}
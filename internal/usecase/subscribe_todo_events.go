@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/filter"
+)
+
+type SubscribeTodoEventsUsecase struct {
+	feed    domain.IFeed
+	views   domain.IViewRepository
+	machine *domain.StateMachine
+}
+
+func NewSubscribeTodoEventsUsecase(feed domain.IFeed, views domain.IViewRepository, machine *domain.StateMachine) *SubscribeTodoEventsUsecase {
+	return &SubscribeTodoEventsUsecase{feed: feed, views: views, machine: machine}
+}
+
+// Execute は query に一致する Todo の変更だけを流すチャネルを返す。
+// 変更前か変更後のどちらかが一致すれば流すため、条件から外れた Todo の変更も受け取れる。
+func (u *SubscribeTodoEventsUsecase) Execute(ctx context.Context, lastEventID int64, query domain.FeedQuery) (<-chan domain.FeedEvent, error) {
+	exprs := make([]filter.Expr, 0, 2)
+	if query.ViewID != "" {
+		view, err := findView(ctx, u.views, query.ViewID)
+		if err != nil {
+			return nil, err
+		}
+		expr, err := filter.Parse(view.Query.Filter, u.machine)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	expr, err := filter.Parse(query.Filter, u.machine)
+	if err != nil {
+		return nil, err
+	}
+	exprs = append(exprs, expr)
+
+	match := func(todo *domain.Todo) bool {
+		if todo == nil || todo.IsDeleted() {
+			return false
+		}
+		for _, expr := range exprs {
+			if !expr.Match(todo) {
+				return false
+			}
+		}
+		return true
+	}
+
+	events := u.feed.Subscribe(ctx, lastEventID)
+	out := make(chan domain.FeedEvent)
+	go func() {
+		defer close(out)
+		for e := range events {
+			if e.Type != domain.FeedReset && !match(e.Todo) && !match(e.Previous) {
+				continue
+			}
+			select {
+			case out <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// mockFeed は Subscribe 時点で events をすべて流して閉じる。
+type mockFeed struct {
+	events      []domain.FeedEvent
+	lastEventID int64
+}
+
+func (m *mockFeed) Subscribe(ctx context.Context, lastEventID int64) <-chan domain.FeedEvent {
+	m.lastEventID = lastEventID
+	ch := make(chan domain.FeedEvent, len(m.events))
+	for _, e := range m.events {
+		ch <- e
+	}
+	close(ch)
+	return ch
+}
+
+func collectFeed(ch <-chan domain.FeedEvent) []int64 {
+	var ids []int64
+	for e := range ch {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func TestSubscribeTodoEventsUsecase_Execute(t *testing.T) {
+	open := &domain.Todo{ID: 1, Title: "Buy milk", Status: domain.StatusTodo}
+	done := &domain.Todo{ID: 1, Title: "Buy milk", Status: domain.StatusDone}
+	other := &domain.Todo{ID: 2, Title: "Read book", Status: domain.StatusDone}
+	feed := &mockFeed{events: []domain.FeedEvent{
+		{ID: 1, Type: domain.FeedTodoCreated, Todo: open},
+		{ID: 2, Type: domain.FeedTodoCreated, Todo: other},
+		{ID: 3, Type: domain.FeedTodoUpdated, Todo: done, Previous: open},
+		{ID: 4, Type: domain.FeedTodoDeleted, Todo: other, Previous: other},
+		{ID: 5, Type: domain.FeedReset},
+	}}
+	views := &MockViewRepository{viewList: []*domain.View{{ID: "1", Name: "Milk", Query: domain.ListQuery{Filter: "milk"}}}}
+
+	tests := []struct {
+		name    string
+		query   domain.FeedQuery
+		wantIDs []int64
+	}{
+		{name: "all", query: domain.FeedQuery{}, wantIDs: []int64{1, 2, 3, 4, 5}},
+		{name: "filter", query: domain.FeedQuery{Filter: "status:open"}, wantIDs: []int64{1, 3, 5}},
+		{name: "view", query: domain.FeedQuery{ViewID: "1"}, wantIDs: []int64{1, 3, 5}},
+		{name: "view and filter", query: domain.FeedQuery{ViewID: "1", Filter: "status:done"}, wantIDs: []int64{3, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: 2件の Todo の変更
+			usecase := NewSubscribeTodoEventsUsecase(feed, views, domain.DefaultStateMachine())
+
+			// When:  条件を指定して購読する
+			ch, err := usecase.Execute(context.Background(), 0, tt.query)
+
+			// Then:  変更前後のどちらかが条件に一致する変更と、reset が流れる
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			ids := collectFeed(ch)
+			if len(ids) != len(tt.wantIDs) {
+				t.Fatalf("Expected %v, got %v", tt.wantIDs, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantIDs[i] {
+					t.Errorf("Expected %v, got %v", tt.wantIDs, ids)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestSubscribeTodoEventsUsecase_Execute_Resume(t *testing.T) {
+	feed := &mockFeed{}
+	usecase := NewSubscribeTodoEventsUsecase(feed, &MockViewRepository{}, domain.DefaultStateMachine())
+
+	_, err := usecase.Execute(context.Background(), 42, domain.FeedQuery{})
+
+	if err != nil || feed.lastEventID != 42 {
+		t.Errorf("Expected to resume from 42, got %d, %v", feed.lastEventID, err)
+	}
+}
+
+func TestSubscribeTodoEventsUsecase_Execute_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   domain.FeedQuery
+		wantErr error
+	}{
+		{name: "invalid filter", query: domain.FeedQuery{Filter: "status:unknown"}, wantErr: domain.ErrInvalidFilter},
+		{name: "unknown view", query: domain.FeedQuery{ViewID: "99"}, wantErr: domain.ErrViewNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewSubscribeTodoEventsUsecase(&mockFeed{}, &MockViewRepository{}, domain.DefaultStateMachine())
+
+			_, err := usecase.Execute(context.Background(), 0, tt.query)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type SyncTodoUsecase struct {
+	repo    domain.IRepository
+	index   domain.IChangeIndex
+	machine *domain.StateMachine
+}
+
+func NewSyncTodoUsecase(repo domain.IRepository, index domain.IChangeIndex, machine *domain.StateMachine) *SyncTodoUsecase {
+	return &SyncTodoUsecase{repo: repo, index: index, machine: machine}
+}
+
+// Execute は pushes をクライアントでの変更として1件ずつ適用し、token の後に変わった Todo を返す。
+// token が空の場合はゴミ箱にないすべての Todo を返す。返す変更には pushes で適用したものも含む。
+func (u *SyncTodoUsecase) Execute(ctx context.Context, token string, pushes []domain.SyncPush) (*domain.SyncResponse, error) {
+	since, err := domain.DecodeSyncToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		head, err := u.index.Head(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if since.Epoch != head.Epoch || since.Seq > head.Seq {
+			return nil, domain.ErrSyncTokenExpired
+		}
+	}
+
+	var results []domain.SyncResult
+	for i, push := range pushes {
+		result, err := u.push(ctx, push)
+		if err != nil {
+			return nil, err
+		}
+		result.Index = i
+		results = append(results, result)
+	}
+
+	response := &domain.SyncResponse{Results: results}
+	err = u.repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		head, err := u.index.Head(ctx)
+		if err != nil {
+			return err
+		}
+		response.Token = domain.EncodeSyncToken(head)
+
+		if token == "" {
+			response.Changes, err = u.snapshot(ctx, tx)
+		} else {
+			response.Changes, err = u.changesSince(ctx, tx, since.Seq)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// push は1件の変更を確認と適用をまとめたトランザクションで実行する。
+// 変更自体の失敗は結果に記録し、保存に失敗した場合だけエラーを返す。
+func (u *SyncTodoUsecase) push(ctx context.Context, push domain.SyncPush) (domain.SyncResult, error) {
+	result := domain.SyncResult{ClientID: push.ClientID, ID: push.ID}
+	var opErr error
+	err := u.repo.WithinTx(ctx, func(tx domain.IRepository) error {
+		if push.Op != domain.BulkCreate {
+			current, err := u.index.Version(ctx, push.ID)
+			if err != nil {
+				return err
+			}
+			if current != push.BaseSeq {
+				result.Status = domain.SyncConflict
+				result.Seq = current
+				result.Todo, err = findActiveTodo(ctx, tx, push.ID)
+				if errors.Is(err, domain.ErrTodoNotFound) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		todo, err := NewBulkTodoUsecase(tx, u.machine).apply(ctx, tx, push.BulkOperation)
+		if err != nil {
+			opErr = err
+			return err
+		}
+		result.Status = domain.SyncApplied
+		result.Todo = todo
+		if todo != nil {
+			result.ID = todo.ID
+		}
+		return nil
+	})
+	if opErr != nil {
+		return domain.SyncResult{ClientID: push.ClientID, ID: push.ID, Status: domain.SyncFailed, Error: opErr.Error()}, nil
+	}
+	if err != nil {
+		return domain.SyncResult{}, err
+	}
+
+	if result.Status == domain.SyncApplied {
+		result.Seq, err = u.index.Version(ctx, result.ID)
+		if err != nil {
+			return domain.SyncResult{}, err
+		}
+	}
+	return result, nil
+}
+
+func (u *SyncTodoUsecase) snapshot(ctx context.Context, tx domain.IRepository) ([]domain.SyncChange, error) {
+	versions, err := u.index.Since(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	seqs := make(map[int]int64, len(versions))
+	for _, v := range versions {
+		seqs[v.ID] = v.Seq
+	}
+
+	todos, err := tx.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changes := []domain.SyncChange{}
+	for _, todo := range todos {
+		if !todo.IsDeleted() {
+			changes = append(changes, domain.SyncChange{Seq: seqs[todo.ID], ID: todo.ID, Todo: todo})
+		}
+	}
+	return changes, nil
+}
+
+// changesSince は変更番号の順に、各 Todo の現在の状態を返す。存在しないかゴミ箱にある Todo は削除として返す。
+func (u *SyncTodoUsecase) changesSince(ctx context.Context, tx domain.IRepository, seq int64) ([]domain.SyncChange, error) {
+	versions, err := u.index.Since(ctx, seq)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]domain.SyncChange, 0, len(versions))
+	for _, v := range versions {
+		todo, err := findActiveTodo(ctx, tx, v.ID)
+		if errors.Is(err, domain.ErrTodoNotFound) {
+			changes = append(changes, domain.SyncChange{Seq: v.Seq, ID: v.ID, Deleted: true})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, domain.SyncChange{Seq: v.Seq, ID: v.ID, Todo: todo})
+	}
+	return changes, nil
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockChangeIndex struct {
+	head     domain.SyncHead
+	versions map[int]int64
+}
+
+func newMockChangeIndex(versions map[int]int64) *mockChangeIndex {
+	m := &mockChangeIndex{head: domain.SyncHead{Epoch: "e1"}, versions: versions}
+	for _, seq := range versions {
+		if seq > m.head.Seq {
+			m.head.Seq = seq
+		}
+	}
+	return m
+}
+
+func (m *mockChangeIndex) Head(ctx context.Context) (domain.SyncHead, error) {
+	return m.head, nil
+}
+
+func (m *mockChangeIndex) Bump(ctx context.Context, ids []int) error {
+	for _, id := range ids {
+		m.head.Seq++
+		m.versions[id] = m.head.Seq
+	}
+	return nil
+}
+
+func (m *mockChangeIndex) Version(ctx context.Context, id int) (int64, error) {
+	return m.versions[id], nil
+}
+
+func (m *mockChangeIndex) Since(ctx context.Context, seq int64) ([]domain.SyncVersion, error) {
+	var versions []domain.SyncVersion
+	for s := seq + 1; s <= m.head.Seq; s++ {
+		for id, v := range m.versions {
+			if v == s {
+				versions = append(versions, domain.SyncVersion{ID: id, Seq: v})
+			}
+		}
+	}
+	return versions, nil
+}
+
+func newSyncMock() *MockRepository {
+	deletedAt := time.Now()
+	return &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo},
+			{ID: 2, Title: "Read book", Status: domain.StatusTodo},
+			{ID: 3, Title: "Old task", Status: domain.StatusTodo, DeletedAt: &deletedAt},
+		},
+	}
+}
+
+func TestSyncTodoUsecase_Execute_Snapshot(t *testing.T) {
+	// Given: ゴミ箱の Todo を含むリポジトリ
+	// When:  トークンなしで Execute を呼び出す
+	// Then:  ゴミ箱にない Todo が変更番号付きで返り、現在位置のトークンが返る
+	index := newMockChangeIndex(map[int]int64{2: 4, 3: 5})
+	usecase := NewSyncTodoUsecase(newSyncMock(), index, domain.DefaultStateMachine())
+
+	response, err := usecase.Execute(context.Background(), "", nil)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(response.Changes) != 2 || response.Changes[0].Seq != 0 || response.Changes[1].Seq != 4 {
+		t.Errorf("Unexpected changes: %+v", response.Changes)
+	}
+	if response.Token != domain.EncodeSyncToken(domain.SyncHead{Epoch: "e1", Seq: 5}) {
+		t.Errorf("Unexpected token: %s", response.Token)
+	}
+}
+
+func TestSyncTodoUsecase_Execute_Delta(t *testing.T) {
+	// Given: トークンの後に更新された Todo とゴミ箱に移動した Todo
+	// When:  トークンを指定して Execute を呼び出す
+	// Then:  変更番号の順に更新と削除が返る
+	index := newMockChangeIndex(map[int]int64{1: 2, 2: 4, 3: 3})
+	usecase := NewSyncTodoUsecase(newSyncMock(), index, domain.DefaultStateMachine())
+
+	response, err := usecase.Execute(context.Background(), domain.EncodeSyncToken(domain.SyncHead{Epoch: "e1", Seq: 2}), nil)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(response.Changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %+v", response.Changes)
+	}
+	if c := response.Changes[0]; c.ID != 3 || !c.Deleted || c.Todo != nil {
+		t.Errorf("Expected tombstone for 3, got %+v", c)
+	}
+	if c := response.Changes[1]; c.ID != 2 || c.Deleted || c.Todo.Title != "Read book" {
+		t.Errorf("Expected todo 2, got %+v", c)
+	}
+}
+
+func TestSyncTodoUsecase_Execute_Token(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "malformed", token: "!!", wantErr: domain.ErrInvalidSyncToken},
+		{name: "other epoch", token: domain.EncodeSyncToken(domain.SyncHead{Epoch: "e0", Seq: 1}), wantErr: domain.ErrSyncTokenExpired},
+		{name: "future", token: domain.EncodeSyncToken(domain.SyncHead{Epoch: "e1", Seq: 9}), wantErr: domain.ErrSyncTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newSyncMock()
+			usecase := NewSyncTodoUsecase(mock, newMockChangeIndex(map[int]int64{1: 1}), domain.DefaultStateMachine())
+
+			_, err := usecase.Execute(context.Background(), tt.token, []domain.SyncPush{{BulkOperation: domain.BulkOperation{Op: domain.BulkCreate, Title: "New todo"}}})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if mock.createCalled {
+				t.Error("Expected pushes not to be applied")
+			}
+		})
+	}
+}
+
+func TestSyncTodoUsecase_Execute_Push(t *testing.T) {
+	// Given: 作成・最新の番号を元にした更新・古い番号を元にした更新・ゴミ箱の Todo の更新・不正な操作
+	// When:  Execute を呼び出す
+	// Then:  1件ずつ適用され、古い番号とゴミ箱の Todo は衝突、不正な操作は失敗になる
+	mock := newSyncMock()
+	index := newMockChangeIndex(map[int]int64{1: 3, 2: 4, 3: 5})
+	usecase := NewSyncTodoUsecase(mock, index, domain.DefaultStateMachine())
+
+	response, err := usecase.Execute(context.Background(), domain.EncodeSyncToken(domain.SyncHead{Epoch: "e1", Seq: 5}), []domain.SyncPush{
+		{BulkOperation: domain.BulkOperation{Op: domain.BulkCreate, Title: "New todo"}, ClientID: "tmp-1"},
+		{BulkOperation: domain.BulkOperation{Op: domain.BulkUpdate, ID: 1, Title: "Buy oat milk"}, BaseSeq: 3},
+		{BulkOperation: domain.BulkOperation{Op: domain.BulkUpdate, ID: 2, Title: "Read paper"}, BaseSeq: 2},
+		{BulkOperation: domain.BulkOperation{Op: domain.BulkUpdate, ID: 3, Title: "Revived"}, BaseSeq: 1},
+		{BulkOperation: domain.BulkOperation{Op: "archive", ID: 1}, BaseSeq: 3},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	results := response.Results
+	if len(results) != 5 {
+		t.Fatalf("Expected 5 results, got %+v", results)
+	}
+	if r := results[0]; r.Status != domain.SyncApplied || r.ClientID != "tmp-1" || r.ID != 1 || r.Todo.Title != "New todo" {
+		t.Errorf("Unexpected create result: %+v", r)
+	}
+	if r := results[1]; r.Status != domain.SyncApplied || r.Todo.Title != "Buy oat milk" {
+		t.Errorf("Unexpected update result: %+v", r)
+	}
+	if r := results[2]; r.Status != domain.SyncConflict || r.Seq != 4 || r.Todo.Title != "Read book" {
+		t.Errorf("Unexpected conflict result: %+v", r)
+	}
+	if r := results[3]; r.Status != domain.SyncConflict || r.Seq != 5 || r.Todo != nil {
+		t.Errorf("Unexpected conflict result for deleted todo: %+v", r)
+	}
+	if r := results[4]; r.Index != 4 || r.Status != domain.SyncFailed || r.Error == "" {
+		t.Errorf("Unexpected failed result: %+v", r)
+	}
+	if mock.todoList[1].Title != "Read book" {
+		t.Errorf("Expected conflicting update not to be applied, got %q", mock.todoList[1].Title)
+	}
+}
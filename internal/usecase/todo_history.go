@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type TodoHistoryUsecase struct {
+	history domain.IHistoryRepository
+}
+
+func NewTodoHistoryUsecase(history domain.IHistoryRepository) *TodoHistoryUsecase {
+	return &TodoHistoryUsecase{history: history}
+}
+
+func (u *TodoHistoryUsecase) Execute(ctx context.Context, id int) ([]*domain.Event, error) {
+	return u.history.History(ctx, id)
+}
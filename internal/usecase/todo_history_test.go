@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type mockHistoryRepository struct {
+	events []*domain.Event
+}
+
+func (m *mockHistoryRepository) History(ctx context.Context, todoID int) ([]*domain.Event, error) {
+	var events []*domain.Event
+	for _, e := range m.events {
+		if e.TodoID == todoID {
+			events = append(events, e)
+		}
+	}
+	if len(events) == 0 {
+		return nil, domain.ErrTodoNotFound
+	}
+	return events, nil
+}
+
+func TestTodoHistoryUsecase_Execute(t *testing.T) {
+	// Given: 2件の Todo のイベント
+	history := &mockHistoryRepository{events: []*domain.Event{
+		{Seq: 1, Type: domain.EventTodoCreated, TodoID: 1},
+		{Seq: 2, Type: domain.EventTodoCreated, TodoID: 2},
+		{Seq: 3, Type: domain.EventTitleChanged, TodoID: 1},
+	}}
+	usecase := NewTodoHistoryUsecase(history)
+
+	// When:  ID 1 の履歴を取得する
+	events, err := usecase.Execute(context.Background(), 1)
+
+	// Then:  ID 1 のイベントだけが返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(events) != 2 || events[0].Seq != 1 || events[1].Seq != 3 {
+		t.Errorf("Unexpected events: %+v", events)
+	}
+}
+
+func TestTodoHistoryUsecase_Execute_NotFound(t *testing.T) {
+	usecase := NewTodoHistoryUsecase(&mockHistoryRepository{})
+
+	_, err := usecase.Execute(context.Background(), 1)
+
+	if !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Errorf("Expected ErrTodoNotFound, got %v", err)
+	}
+}
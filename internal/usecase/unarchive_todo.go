@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type UnarchiveTodoUsecase struct {
+	repo    domain.IRepository
+	archive domain.IRepository
+}
+
+func NewUnarchiveTodoUsecase(repo domain.IRepository, archive domain.IRepository) *UnarchiveTodoUsecase {
+	return &UnarchiveTodoUsecase{repo: repo, archive: archive}
+}
+
+// Execute はアーカイブ済みの Todo を同じ ID のまま戻す。
+// 同じ ID が既に使われている場合は ErrTodoConflict を返す。
+func (u *UnarchiveTodoUsecase) Execute(ctx context.Context, id int) (*domain.Todo, error) {
+	todo, err := u.archive.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := moveTodo(ctx, u.archive, u.repo, todo); err != nil {
+		return nil, err
+	}
+	return todo, nil
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestUnarchiveTodoUsecase_Execute(t *testing.T) {
+	// Given: アーカイブに ID 7 の Todo
+	// When:  Execute を呼び出す
+	// Then:  同じ ID でリポジトリに戻り、アーカイブから削除される
+	repo := &MockRepository{}
+	archive := &MockRepository{
+		todoList: []*domain.Todo{{ID: 7, Title: "Buy milk", Status: domain.StatusDone}},
+	}
+	usecase := NewUnarchiveTodoUsecase(repo, archive)
+
+	todo, err := usecase.Execute(context.Background(), 7)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if todo.ID != 7 {
+		t.Errorf("Expected ID 7, got %d", todo.ID)
+	}
+	if len(repo.todoList) != 1 || repo.todoList[0].ID != 7 {
+		t.Errorf("Expected todo 7 in repository, got %v", repo.todoList)
+	}
+	if !archive.deleteCalled || archive.deletedID != 7 {
+		t.Error("Expected todo 7 to be deleted from archive")
+	}
+}
+
+func TestUnarchiveTodoUsecase_Execute_Conflict(t *testing.T) {
+	// Given: 同じ ID の Todo が既にリポジトリにある
+	// When:  Execute を呼び出す
+	// Then:  ErrTodoConflict が返り、アーカイブからは削除されない
+	repo := &MockRepository{
+		todoList: []*domain.Todo{{ID: 7, Title: "New todo", Status: domain.StatusTodo}},
+	}
+	archive := &MockRepository{
+		todoList: []*domain.Todo{{ID: 7, Title: "Buy milk", Status: domain.StatusDone}},
+	}
+	usecase := NewUnarchiveTodoUsecase(repo, archive)
+
+	_, err := usecase.Execute(context.Background(), 7)
+
+	if !errors.Is(err, domain.ErrTodoConflict) {
+		t.Errorf("Expected ErrTodoConflict, got %v", err)
+	}
+	if archive.deleteCalled {
+		t.Error("Expected archive.Delete not to be called")
+	}
+}
+
+func TestUnarchiveTodoUsecase_Execute_NotFound(t *testing.T) {
+	usecase := NewUnarchiveTodoUsecase(&MockRepository{}, &MockRepository{})
+
+	_, err := usecase.Execute(context.Background(), 999)
+
+	if err == nil {
+		t.Error("Expected error for non-archived todo")
+	}
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type UndoTodoUsecase struct {
+	undoer domain.IUndoer
+}
+
+func NewUndoTodoUsecase(undoer domain.IUndoer) *UndoTodoUsecase {
+	return &UndoTodoUsecase{undoer: undoer}
+}
+
+func (u *UndoTodoUsecase) Execute(ctx context.Context) ([]domain.Change, error) {
+	return u.undoer.Undo(ctx)
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// mockUndoer はセッションごとに取り消し・やり直しできる変更を持つ。
+type mockUndoer struct {
+	undo map[string][]domain.Change
+	redo map[string][]domain.Change
+}
+
+func (m *mockUndoer) Undo(ctx context.Context) ([]domain.Change, error) {
+	changes, ok := m.undo[domain.SessionFromContext(ctx)]
+	if !ok {
+		return nil, domain.ErrNothingToUndo
+	}
+	return changes, nil
+}
+
+func (m *mockUndoer) Redo(ctx context.Context) ([]domain.Change, error) {
+	changes, ok := m.redo[domain.SessionFromContext(ctx)]
+	if !ok {
+		return nil, domain.ErrNothingToRedo
+	}
+	return changes, nil
+}
+
+func TestUndoTodoUsecase_Execute(t *testing.T) {
+	// Given: alice のセッションに取り消せる変更がある
+	undoer := &mockUndoer{undo: map[string][]domain.Change{
+		"alice": {{ID: 1, Before: &domain.Todo{ID: 1, Title: "Buy eggs"}, After: &domain.Todo{ID: 1, Title: "Buy milk"}}},
+	}}
+	usecase := NewUndoTodoUsecase(undoer)
+
+	// When:  alice のセッションで実行する
+	changes, err := usecase.Execute(domain.WithSession(context.Background(), "alice"))
+
+	// Then:  取り消した変更が返る
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(changes) != 1 || changes[0].After.Title != "Buy milk" {
+		t.Errorf("Unexpected changes: %+v", changes)
+	}
+}
+
+func TestUndoTodoUsecase_Execute_NothingToUndo(t *testing.T) {
+	usecase := NewUndoTodoUsecase(&mockUndoer{})
+
+	_, err := usecase.Execute(domain.WithSession(context.Background(), "bob"))
+
+	if !errors.Is(err, domain.ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo, got %v", err)
+	}
+}
@@ -8,22 +8,33 @@ import (
 )
 
 type UpdateTodoUsecase struct {
-	repo domain.IRepository
+	repo    domain.IRepository
+	machine *domain.StateMachine
 }
 
-func NewUpdateTodoUsecase(repo domain.IRepository) *UpdateTodoUsecase {
-	return &UpdateTodoUsecase{repo: repo}
+func NewUpdateTodoUsecase(repo domain.IRepository, machine *domain.StateMachine) *UpdateTodoUsecase {
+	return &UpdateTodoUsecase{repo: repo, machine: machine}
 }
 
 func (u *UpdateTodoUsecase) Execute(ctx context.Context, id int, title string, completed bool) (*domain.Todo, error) {
-	todo, err := u.repo.FindByID(ctx, id)
+	todo, err := findActiveTodo(ctx, u.repo, id)
 	if err != nil {
 		return nil, err
 	}
 
+	now := time.Now()
 	todo.Title = title
-	todo.Completed = completed
-	todo.UpdatedAt = time.Now()
+	// completed は status から導出されるため、値が変わった場合のみ done / 初期状態へ遷移させる
+	if completed != todo.IsCompleted() {
+		to := u.machine.Initial()
+		if completed {
+			to = domain.StatusDone
+		}
+		if err := u.machine.Transition(todo, to, now); err != nil {
+			return nil, err
+		}
+	}
+	todo.UpdatedAt = now
 
 	if err := domain.ValidateTodo(todo); err != nil {
 		return nil, err
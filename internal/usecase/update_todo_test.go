@@ -13,10 +13,10 @@ func TestUpdateTodoUsecase_Execute(t *testing.T) {
 	now := time.Now()
 	mock := &MockRepository{
 		todoList: []*domain.Todo{
-			{ID: 1, Title: "Buy milk", Completed: false, CreatedAt: now, UpdatedAt: now},
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, CreatedAt: now, UpdatedAt: now},
 		},
 	}
-	usecase := NewUpdateTodoUsecase(mock)
+	usecase := NewUpdateTodoUsecase(mock, domain.DefaultStateMachine())
 
 	todo, err := usecase.Execute(context.Background(), 1, "Buy milk and eggs", true)
 
@@ -29,7 +29,7 @@ func TestUpdateTodoUsecase_Execute(t *testing.T) {
 	if todo.Title != "Buy milk and eggs" {
 		t.Errorf("Expected title 'Buy milk and eggs', got '%s'", todo.Title)
 	}
-	if !todo.Completed {
+	if !todo.IsCompleted() {
 		t.Error("Expected completed to be true")
 	}
 	if todo.CreatedAt != now {
@@ -44,10 +44,10 @@ func TestUpdateTodoUsecase_Execute_EmptyTitle(t *testing.T) {
 	now := time.Now()
 	mock := &MockRepository{
 		todoList: []*domain.Todo{
-			{ID: 1, Title: "Buy milk", Completed: false, CreatedAt: now, UpdatedAt: now},
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, CreatedAt: now, UpdatedAt: now},
 		},
 	}
-	usecase := NewUpdateTodoUsecase(mock)
+	usecase := NewUpdateTodoUsecase(mock, domain.DefaultStateMachine())
 
 	_, err := usecase.Execute(context.Background(), 1, "", false)
 
@@ -60,7 +60,7 @@ func TestUpdateTodoUsecase_Execute_NotFound(t *testing.T) {
 	mock := &MockRepository{
 		todoList: []*domain.Todo{},
 	}
-	usecase := NewUpdateTodoUsecase(mock)
+	usecase := NewUpdateTodoUsecase(mock, domain.DefaultStateMachine())
 
 	_, err := usecase.Execute(context.Background(), 999, "Updated", false)
 
@@ -76,11 +76,11 @@ func TestUpdateTodoUsecase_Execute_RepoUpdateError(t *testing.T) {
 	now := time.Now()
 	mock := &MockRepository{
 		todoList: []*domain.Todo{
-			{ID: 1, Title: "Buy milk", Completed: false, CreatedAt: now, UpdatedAt: now},
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, CreatedAt: now, UpdatedAt: now},
 		},
 		updateErr: errors.New("storage failure"),
 	}
-	usecase := NewUpdateTodoUsecase(mock)
+	usecase := NewUpdateTodoUsecase(mock, domain.DefaultStateMachine())
 
 	_, err := usecase.Execute(context.Background(), 1, "Updated", true)
 
@@ -88,3 +88,95 @@ func TestUpdateTodoUsecase_Execute_RepoUpdateError(t *testing.T) {
 		t.Error("Expected error when repo.Update fails")
 	}
 }
+
+func TestUpdateTodoUsecase_Execute_KeepsInProgress(t *testing.T) {
+	// Given: in_progress 状態の Todo
+	// When:  completed=false のままタイトルだけ更新する
+	// Then:  ステータスは in_progress のまま維持される
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusInProgress},
+		},
+	}
+	usecase := NewUpdateTodoUsecase(mock, domain.DefaultStateMachine())
+
+	todo, err := usecase.Execute(context.Background(), 1, "Buy oat milk", false)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if todo.Status != domain.StatusInProgress {
+		t.Errorf("Expected status in_progress, got %q", todo.Status)
+	}
+	if len(todo.StatusHistory) != 0 {
+		t.Errorf("Expected no transition, got %d", len(todo.StatusHistory))
+	}
+}
+
+func TestUpdateTodoUsecase_Execute_Reopen(t *testing.T) {
+	// Given: done 状態の Todo
+	// When:  completed=false で更新する
+	// Then:  初期ステータスに戻り CompletedAt がクリアされる
+	completedAt := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusDone, CompletedAt: &completedAt},
+		},
+	}
+	usecase := NewUpdateTodoUsecase(mock, domain.DefaultStateMachine())
+
+	todo, err := usecase.Execute(context.Background(), 1, "Buy milk", false)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if todo.Status != domain.StatusTodo {
+		t.Errorf("Expected status todo, got %q", todo.Status)
+	}
+	if todo.CompletedAt != nil {
+		t.Error("Expected CompletedAt to be cleared")
+	}
+}
+
+func TestUpdateTodoUsecase_Execute_InvalidTransition(t *testing.T) {
+	// Given: cancelled 状態の Todo
+	// When:  completed=true で更新する
+	// Then:  ErrInvalidTransition が返り、保存されない
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusCancelled},
+		},
+	}
+	usecase := NewUpdateTodoUsecase(mock, domain.DefaultStateMachine())
+
+	_, err := usecase.Execute(context.Background(), 1, "Buy milk", true)
+
+	if !errors.Is(err, domain.ErrInvalidTransition) {
+		t.Errorf("Expected ErrInvalidTransition, got %v", err)
+	}
+	if mock.updateCalled {
+		t.Error("Expected Update not to be called")
+	}
+}
+
+func TestUpdateTodoUsecase_Execute_Trashed(t *testing.T) {
+	// Given: ゴミ箱に入っている Todo
+	// When:  Execute を呼び出す
+	// Then:  ErrTodoNotFound が返り、保存されない
+	deletedAt := time.Now()
+	mock := &MockRepository{
+		todoList: []*domain.Todo{
+			{ID: 1, Title: "Buy milk", Status: domain.StatusTodo, DeletedAt: &deletedAt},
+		},
+	}
+	usecase := NewUpdateTodoUsecase(mock, domain.DefaultStateMachine())
+
+	_, err := usecase.Execute(context.Background(), 1, "Updated", false)
+
+	if !errors.Is(err, domain.ErrTodoNotFound) {
+		t.Errorf("Expected ErrTodoNotFound, got %v", err)
+	}
+	if mock.updateCalled {
+		t.Error("Expected Update not to be called")
+	}
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type UpdateViewUsecase struct {
+	views   domain.IViewRepository
+	machine *domain.StateMachine
+}
+
+func NewUpdateViewUsecase(views domain.IViewRepository, machine *domain.StateMachine) *UpdateViewUsecase {
+	return &UpdateViewUsecase{views: views, machine: machine}
+}
+
+func (u *UpdateViewUsecase) Execute(ctx context.Context, id string, name string, query domain.ListQuery) (*domain.View, error) {
+	view, err := findView(ctx, u.views, id)
+	if err != nil {
+		return nil, err
+	}
+	if view.BuiltIn {
+		return nil, domain.ErrViewReadOnly
+	}
+
+	view.Name = name
+	view.Query = query
+	view.UpdatedAt = time.Now()
+
+	if err := validateView(view, u.machine); err != nil {
+		return nil, err
+	}
+
+	if err := u.views.Update(ctx, view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestUpdateViewUsecase_Execute(t *testing.T) {
+	// Given: 保存済みのビュー
+	// When:  名前と条件を変えて Execute を呼び出す
+	// Then:  変更が保存される
+	views := &MockViewRepository{viewList: []*domain.View{{ID: "1", Name: "Backend"}}}
+	usecase := NewUpdateViewUsecase(views, domain.DefaultStateMachine())
+
+	view, err := usecase.Execute(context.Background(), "1", "API", domain.ListQuery{Filter: "api"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if view.Name != "API" || view.Query.Filter != "api" || view.UpdatedAt.IsZero() {
+		t.Errorf("Unexpected view: %+v", view)
+	}
+	if views.updated != view {
+		t.Error("Expected view to be saved")
+	}
+}
+
+func TestUpdateViewUsecase_Execute_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		view    string
+		query   domain.ListQuery
+		wantErr error
+	}{
+		{name: "not found", id: "2", view: "API", wantErr: domain.ErrViewNotFound},
+		{name: "built-in", id: "today", view: "API", wantErr: domain.ErrViewReadOnly},
+		{name: "empty name", id: "1", view: "", wantErr: domain.ErrInvalidView},
+		{name: "invalid filter", id: "1", view: "API", query: domain.ListQuery{Filter: `"api`}, wantErr: domain.ErrInvalidFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views := &MockViewRepository{viewList: []*domain.View{{ID: "1", Name: "Backend"}}}
+			usecase := NewUpdateViewUsecase(views, domain.DefaultStateMachine())
+
+			_, err := usecase.Execute(context.Background(), tt.id, tt.view, tt.query)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if views.updated != nil {
+				t.Error("Expected view not to be saved")
+			}
+		})
+	}
+}
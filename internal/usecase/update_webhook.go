@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+type UpdateWebhookUsecase struct {
+	webhooks domain.IWebhookRepository
+}
+
+func NewUpdateWebhookUsecase(webhooks domain.IWebhookRepository) *UpdateWebhookUsecase {
+	return &UpdateWebhookUsecase{webhooks: webhooks}
+}
+
+// Execute は URL と通知する種類を更新する。secret が空でなければ署名の鍵も置き換える。
+func (u *UpdateWebhookUsecase) Execute(ctx context.Context, id string, url string, events []domain.FeedEventType, secret string) (*domain.Webhook, error) {
+	webhook, err := u.webhooks.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.URL = url
+	webhook.Events = events
+	if secret != "" {
+		webhook.Secret = secret
+	}
+	webhook.UpdatedAt = time.Now()
+
+	if err := domain.ValidateWebhook(webhook); err != nil {
+		return nil, err
+	}
+
+	if err := u.webhooks.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
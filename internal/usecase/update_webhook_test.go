@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+func TestUpdateWebhookUsecase_Execute(t *testing.T) {
+	tests := []struct {
+		name       string
+		secret     string
+		wantSecret string
+	}{
+		{name: "keep secret", secret: "", wantSecret: "old"},
+		{name: "rotate secret", secret: "new", wantSecret: "new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: 登録済みの Webhook
+			repo := &MockWebhookRepository{webhookList: []*domain.Webhook{{ID: "1", URL: "https://example.com/a", Secret: "old"}}}
+			usecase := NewUpdateWebhookUsecase(repo)
+
+			// When:  URL と種類を更新する
+			webhook, err := usecase.Execute(context.Background(), "1", "https://example.com/b", []domain.FeedEventType{domain.FeedTodoDeleted}, tt.secret)
+
+			// Then:  更新が保存され、鍵は指定した場合だけ置き換わる
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if repo.updated != webhook || webhook.URL != "https://example.com/b" || webhook.Secret != tt.wantSecret || len(webhook.Events) != 1 {
+				t.Errorf("Unexpected webhook: %+v", webhook)
+			}
+		})
+	}
+}
+
+func TestUpdateWebhookUsecase_Execute_Error(t *testing.T) {
+	repo := &MockWebhookRepository{webhookList: []*domain.Webhook{{ID: "1", URL: "https://example.com/a", Secret: "old"}}}
+	usecase := NewUpdateWebhookUsecase(repo)
+
+	if _, err := usecase.Execute(context.Background(), "2", "https://example.com/b", nil, ""); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("Expected ErrWebhookNotFound, got %v", err)
+	}
+	if _, err := usecase.Execute(context.Background(), "1", "https://example.com/b", []domain.FeedEventType{"moved"}, ""); !errors.Is(err, domain.ErrInvalidWebhook) {
+		t.Errorf("Expected ErrInvalidWebhook, got %v", err)
+	}
+	if repo.updated != nil {
+		t.Error("Expected Update not to be called")
+	}
+}
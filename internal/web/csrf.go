@@ -0,0 +1,49 @@
+package web
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+)
+
+const (
+	csrfCookie = "todo_csrf"
+	csrfField  = "csrf_token"
+)
+
+// csrfToken はクッキーのトークンを返す。なければ新しく発行してクッキーに設定する。
+// フォームにも同じトークンを埋め込み、送信時に両者が一致するかを確かめる（double submit cookie）。
+// 他のサイトはクッキーを読めないため、一致するトークンを送れない。
+func csrfToken(w http.ResponseWriter, r *http.Request) string {
+	if cookie, err := r.Cookie(csrfCookie); err == nil && validToken(cookie.Value) {
+		return cookie.Value
+	}
+
+	b := make([]byte, 32)
+	rand.Read(b)
+	token := hex.EncodeToString(b)
+	http.SetCookie(w, &http.Cookie{
+		Name:     csrfCookie,
+		Value:    token,
+		Path:     basePath,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+		Secure:   r.TLS != nil,
+	})
+	return token
+}
+
+// verifyCSRF はフォームのトークンがクッキーのトークンと一致するかを返す。
+func verifyCSRF(r *http.Request) bool {
+	cookie, err := r.Cookie(csrfCookie)
+	if err != nil || !validToken(cookie.Value) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(r.PostFormValue(csrfField))) == 1
+}
+
+func validToken(token string) bool {
+	b, err := hex.DecodeString(token)
+	return err == nil && len(b) == 32
+}
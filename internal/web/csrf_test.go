@@ -0,0 +1,57 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCSRFToken_ReusesCookie(t *testing.T) {
+	// Given: 発行済みのトークン
+	w := httptest.NewRecorder()
+	token := csrfToken(w, httptest.NewRequest("GET", "/ui", nil))
+
+	// When:  そのクッキーを送って再び表示する
+	req := httptest.NewRequest("GET", "/ui", nil)
+	req.AddCookie(&http.Cookie{Name: csrfCookie, Value: token})
+	w = httptest.NewRecorder()
+	got := csrfToken(w, req)
+
+	// Then:  同じトークンを使い、クッキーを設定し直さない
+	if got != token {
+		t.Errorf("Expected %s, got %s", token, got)
+	}
+	if len(w.Result().Cookies()) != 0 {
+		t.Errorf("Expected no new cookie")
+	}
+}
+
+func TestVerifyCSRF(t *testing.T) {
+	token := strings.Repeat("ab", 32)
+	tests := []struct {
+		name   string
+		cookie string
+		form   string
+		want   bool
+	}{
+		{"match", token, token, true},
+		{"mismatch", token, strings.Repeat("cd", 32), false},
+		{"no cookie", "", token, false},
+		{"malformed cookie", "x", "x", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/ui/todos", strings.NewReader(url.Values{csrfField: {tt.form}}.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: csrfCookie, Value: tt.cookie})
+			}
+
+			if got := verifyCSRF(req); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
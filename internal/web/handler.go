@@ -0,0 +1,287 @@
+// Package web はブラウザ向けの画面。html/template でサーバー側で描画し、JavaScript がなくてもフォームで操作できる。
+// JavaScript が使える場合は static/app.js が JSON の API を呼び出し、一覧だけを描画し直す。
+package web
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// basePath は画面の URL の接頭辞。JSON の API とは別の URL にする。
+const basePath = "/ui"
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.html"))
+
+type CreateTodoUsecase interface {
+	Execute(ctx context.Context, title string) (*domain.Todo, error)
+}
+
+type ListTodoUsecase interface {
+	Execute(ctx context.Context, query domain.ListQuery) ([]*domain.Todo, error)
+}
+
+type FindByIDTodoUsecase interface {
+	Execute(ctx context.Context, id int) (*domain.Todo, error)
+}
+
+type UpdateTodoUsecase interface {
+	Execute(ctx context.Context, id int, title string, completed bool) (*domain.Todo, error)
+}
+
+type DeleteTodoUsecase interface {
+	Execute(ctx context.Context, id int) error
+}
+
+type ChangeStatusTodoUsecase interface {
+	Execute(ctx context.Context, id int, status domain.Status) (*domain.Todo, error)
+}
+
+type Handler struct {
+	createUsecase       CreateTodoUsecase
+	listUsecase         ListTodoUsecase
+	findByIDUsecase     FindByIDTodoUsecase
+	updateUsecase       UpdateTodoUsecase
+	deleteUsecase       DeleteTodoUsecase
+	changeStatusUsecase ChangeStatusTodoUsecase
+}
+
+func NewHandler(create CreateTodoUsecase, list ListTodoUsecase, findByID FindByIDTodoUsecase, update UpdateTodoUsecase, del DeleteTodoUsecase, changeStatus ChangeStatusTodoUsecase) *Handler {
+	return &Handler{
+		createUsecase:       create,
+		listUsecase:         list,
+		findByIDUsecase:     findByID,
+		updateUsecase:       update,
+		deleteUsecase:       del,
+		changeStatusUsecase: changeStatus,
+	}
+}
+
+// page はテンプレートに渡す値。
+type page struct {
+	Title     string
+	Error     string
+	CSRFToken string
+	Query     string
+	Todos     []*domain.Todo
+	Todo      *domain.Todo
+}
+
+// Static は static ディレクトリのファイルを返す。GET /ui/static/ に登録する。
+func (h *Handler) Static() http.Handler {
+	sub, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		panic(err)
+	}
+	return http.StripPrefix(basePath+"/static/", http.FileServerFS(sub))
+}
+
+// Index は一覧と作成・絞り込みのフォームを表示する。
+func (h *Handler) Index(w http.ResponseWriter, r *http.Request) {
+	p := page{Title: "Todos", CSRFToken: csrfToken(w, r), Query: r.URL.Query().Get("q")}
+	todos, status, err := h.list(r.Context(), p.Query)
+	p.Todos = todos
+	if err != nil {
+		p.Error = err.Error()
+	}
+	render(w, status, "index.html", p)
+}
+
+// List は一覧の部分だけを返す。app.js が変更の後や絞り込みの入力中に呼び出す。
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	p := page{CSRFToken: csrfToken(w, r), Query: r.URL.Query().Get("q")}
+	todos, status, err := h.list(r.Context(), p.Query)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	p.Todos = todos
+	render(w, http.StatusOK, "list", p)
+}
+
+func (h *Handler) list(ctx context.Context, query string) ([]*domain.Todo, int, error) {
+	todos, err := h.listUsecase.Execute(ctx, domain.ListQuery{Sort: domain.SortPosition, Filter: query})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidFilter) {
+			return nil, http.StatusBadRequest, err
+		}
+		return nil, http.StatusInternalServerError, errors.New("failed to load todos")
+	}
+	return todos, http.StatusOK, nil
+}
+
+// EditPage は Todo のタイトルを変えるフォームを表示する。
+func (h *Handler) EditPage(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(r)
+	if !ok {
+		h.fail(w, r, http.StatusNotFound, "todo not found")
+		return
+	}
+
+	todo, err := h.findByIDUsecase.Execute(r.Context(), id)
+	if err == nil && todo == nil {
+		err = domain.ErrTodoNotFound
+	}
+	if err != nil {
+		status, message := errorStatus(err)
+		h.fail(w, r, status, message)
+		return
+	}
+	render(w, http.StatusOK, "edit.html", page{Title: "Edit todo", CSRFToken: csrfToken(w, r), Todo: todo})
+}
+
+// CreateTodo・UpdateTodo・ToggleTodo・DeleteTodo はフォームの送信を受け付ける。
+// 成功したら一覧に 303 でリダイレクトし、再読み込みで二重に送信されないようにする。
+func (h *Handler) CreateTodo(w http.ResponseWriter, r *http.Request) {
+	if !h.verify(w, r) {
+		return
+	}
+	title := r.PostFormValue("title")
+	if title == "" {
+		h.fail(w, r, http.StatusBadRequest, "title cannot be empty")
+		return
+	}
+
+	if _, err := h.createUsecase.Execute(r.Context(), title); err != nil {
+		status, message := errorStatus(err)
+		h.fail(w, r, status, message)
+		return
+	}
+	redirect(w, r)
+}
+
+func (h *Handler) UpdateTodo(w http.ResponseWriter, r *http.Request) {
+	if !h.verify(w, r) {
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		h.fail(w, r, http.StatusNotFound, "todo not found")
+		return
+	}
+
+	completed := r.PostFormValue("completed") == "true"
+	if _, err := h.updateUsecase.Execute(r.Context(), id, r.PostFormValue("title"), completed); err != nil {
+		status, message := errorStatus(err)
+		h.fail(w, r, status, message)
+		return
+	}
+	redirect(w, r)
+}
+
+// ToggleTodo はフォームの status（done または todo）に変える。完了済みの Todo は todo に戻す。
+func (h *Handler) ToggleTodo(w http.ResponseWriter, r *http.Request) {
+	if !h.verify(w, r) {
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		h.fail(w, r, http.StatusNotFound, "todo not found")
+		return
+	}
+
+	if _, err := h.changeStatusUsecase.Execute(r.Context(), id, domain.Status(r.PostFormValue("status"))); err != nil {
+		status, message := errorStatus(err)
+		h.fail(w, r, status, message)
+		return
+	}
+	redirect(w, r)
+}
+
+func (h *Handler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
+	if !h.verify(w, r) {
+		return
+	}
+	id, ok := pathID(r)
+	if !ok {
+		h.fail(w, r, http.StatusNotFound, "todo not found")
+		return
+	}
+
+	if err := h.deleteUsecase.Execute(r.Context(), id); err != nil {
+		status, message := errorStatus(err)
+		h.fail(w, r, status, message)
+		return
+	}
+	redirect(w, r)
+}
+
+// verify は CSRF のトークンを確かめ、一致しなければ 403 を返す。
+func (h *Handler) verify(w http.ResponseWriter, r *http.Request) bool {
+	if verifyCSRF(r) {
+		return true
+	}
+	h.fail(w, r, http.StatusForbidden, "invalid or missing CSRF token; reload the page and try again")
+	return false
+}
+
+// fail はエラーを一覧の上に表示する。フォームの送信に失敗しても一覧に戻れるようにする。
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, status int, message string) {
+	p := page{Title: "Todos", CSRFToken: csrfToken(w, r), Error: message}
+	if todos, _, err := h.list(r.Context(), ""); err == nil {
+		p.Todos = todos
+	}
+	render(w, status, "index.html", p)
+}
+
+// errorStatus は usecase のエラーを JSON の API と同じステータスコードにし、利用者に見せるメッセージを返す。
+func errorStatus(err error) (int, string) {
+	switch {
+	case err == nil:
+		return http.StatusOK, ""
+	case err.Error() == "todo not found":
+		return http.StatusNotFound, "todo not found"
+	case err.Error() == "title cannot be empty" || err.Error() == "title too long":
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrInvalidStatus):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrInvalidTransition):
+		return http.StatusConflict, err.Error()
+	default:
+		return http.StatusInternalServerError, "something went wrong"
+	}
+}
+
+func pathID(r *http.Request) (int, bool) {
+	var id int
+	if _, err := fmt.Sscanf(r.PathValue("id"), "%d", &id); err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// redirect は一覧に戻す。絞り込みの条件は Referer から引き継ぐ。
+func redirect(w http.ResponseWriter, r *http.Request) {
+	target := basePath
+	if ref, err := url.Parse(r.Referer()); err == nil && ref.Path == basePath && ref.Host == r.Host {
+		if q := ref.Query().Get("q"); q != "" {
+			target += "?" + url.Values{"q": {q}}.Encode()
+		}
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+func render(w http.ResponseWriter, status int, name string, p page) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	// スクリプトとスタイルは static の自分のファイルだけを許可し、他のサイトの frame に埋め込ませない
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; frame-ancestors 'none'; form-action 'self'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	if err := templates.ExecuteTemplate(w, name, p); err != nil {
+		log.Printf("Failed to render %s: %v", name, err)
+	}
+}
@@ -0,0 +1,299 @@
+package web
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+	"github.com/k98a73/go-todo/internal/usecase"
+)
+
+type testEnv struct {
+	repo   domain.IRepository
+	mux    *http.ServeMux
+	cookie *http.Cookie
+}
+
+// newTestEnv は cmd/main.go と同じ URL に画面を登録し、CSRF のクッキーを受け取っておく。
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	repo := storage.NewFileRepository(filepath.Join(t.TempDir(), "todos.json"))
+	machine := domain.DefaultStateMachine()
+	h := NewHandler(
+		usecase.NewCreateTodoUsecase(repo, machine),
+		usecase.NewListTodoUsecase(repo, machine),
+		usecase.NewFindByIDTodoUsecase(repo),
+		usecase.NewUpdateTodoUsecase(repo, machine),
+		usecase.NewDeleteTodoUsecase(repo),
+		usecase.NewChangeStatusTodoUsecase(repo, machine),
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /ui", h.Index)
+	mux.HandleFunc("GET /ui/list", h.List)
+	mux.HandleFunc("POST /ui/todos", h.CreateTodo)
+	mux.HandleFunc("GET /ui/todos/{id}/edit", h.EditPage)
+	mux.HandleFunc("POST /ui/todos/{id}", h.UpdateTodo)
+	mux.HandleFunc("POST /ui/todos/{id}/toggle", h.ToggleTodo)
+	mux.HandleFunc("POST /ui/todos/{id}/delete", h.DeleteTodo)
+	mux.Handle("GET /ui/static/", h.Static())
+
+	env := &testEnv{repo: repo, mux: mux}
+	w := env.get("/ui")
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == csrfCookie {
+			env.cookie = cookie
+		}
+	}
+	if env.cookie == nil {
+		t.Fatal("Expected CSRF cookie")
+	}
+	return env
+}
+
+func (e *testEnv) get(target string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", target, nil)
+	if e.cookie != nil {
+		req.AddCookie(e.cookie)
+	}
+	w := httptest.NewRecorder()
+	e.mux.ServeHTTP(w, req)
+	return w
+}
+
+// post はフォームを送信する。csrf_token を指定しなければクッキーのトークンを送る。
+func (e *testEnv) post(target string, form url.Values) *httptest.ResponseRecorder {
+	if _, ok := form[csrfField]; !ok {
+		form.Set(csrfField, e.cookie.Value)
+	}
+	req := httptest.NewRequest("POST", target, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(e.cookie)
+	w := httptest.NewRecorder()
+	e.mux.ServeHTTP(w, req)
+	return w
+}
+
+func (e *testEnv) create(t *testing.T, title string) *domain.Todo {
+	t.Helper()
+	todo := &domain.Todo{Title: title, Status: domain.StatusTodo}
+	if err := e.repo.Create(context.Background(), todo); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	return todo
+}
+
+func TestIndex(t *testing.T) {
+	// Given: タグを含むタイトルの Todo
+	env := newTestEnv(t)
+	env.create(t, "<b>Buy milk</b>")
+
+	// When:  一覧を表示する
+	w := env.get("/ui")
+	body := w.Body.String()
+
+	// Then:  タイトルはエスケープされ、フォームに CSRF のトークンが埋め込まれる
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if !strings.Contains(body, "&lt;b&gt;Buy milk&lt;/b&gt;") || strings.Contains(body, "<b>Buy milk") {
+		t.Errorf("Expected escaped title, got %s", body)
+	}
+	if !strings.Contains(body, `name="csrf_token" value="`+env.cookie.Value+`"`) {
+		t.Errorf("Expected CSRF token in form")
+	}
+	if w.Header().Get("Content-Security-Policy") == "" {
+		t.Errorf("Expected Content-Security-Policy header")
+	}
+}
+
+func TestCreateTodo(t *testing.T) {
+	// Given: 空の一覧
+	env := newTestEnv(t)
+
+	// When:  作成のフォームを送信する
+	w := env.post("/ui/todos", url.Values{"title": {"Buy milk"}})
+
+	// Then:  一覧にリダイレクトし、Todo が作成される
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/ui" {
+		t.Fatalf("Expected redirect to /ui, got %d %q", w.Code, w.Header().Get("Location"))
+	}
+	todos, _ := env.repo.List(context.Background())
+	if len(todos) != 1 || todos[0].Title != "Buy milk" {
+		t.Errorf("Expected created todo, got %+v", todos)
+	}
+}
+
+func TestCreateTodo_CSRF(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"missing token", ""},
+		{"wrong token", strings.Repeat("0", 64)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Given: クッキーと一致しないトークン
+			env := newTestEnv(t)
+
+			// When:  作成のフォームを送信する
+			w := env.post("/ui/todos", url.Values{"title": {"Buy milk"}, csrfField: {tt.token}})
+
+			// Then:  403 を返し、何も作成しない
+			if w.Code != http.StatusForbidden {
+				t.Errorf("Expected status 403, got %d", w.Code)
+			}
+			if todos, _ := env.repo.List(context.Background()); len(todos) != 0 {
+				t.Errorf("Expected no todos, got %d", len(todos))
+			}
+		})
+	}
+}
+
+func TestCreateTodo_EmptyTitle(t *testing.T) {
+	env := newTestEnv(t)
+
+	w := env.post("/ui/todos", url.Values{"title": {""}})
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "title cannot be empty") {
+		t.Errorf("Expected 400 with error message, got %d", w.Code)
+	}
+}
+
+func TestEditAndUpdateTodo(t *testing.T) {
+	// Given: Todo が1件
+	env := newTestEnv(t)
+	todo := env.create(t, "Buy milk")
+
+	// When:  編集のフォームを表示してから送信する
+	w := env.get("/ui/todos/1/edit")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `value="Buy milk"`) {
+		t.Fatalf("Expected edit form, got %d", w.Code)
+	}
+	w = env.post("/ui/todos/1", url.Values{"title": {"Buy oat milk"}, "completed": {"false"}})
+
+	// Then:  タイトルが変わる
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("Expected status 303, got %d", w.Code)
+	}
+	got, _ := env.repo.FindByID(context.Background(), todo.ID)
+	if got.Title != "Buy oat milk" {
+		t.Errorf("Expected title Buy oat milk, got %s", got.Title)
+	}
+}
+
+func TestEditPage_NotFound(t *testing.T) {
+	env := newTestEnv(t)
+
+	w := env.get("/ui/todos/99/edit")
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+}
+
+func TestToggleTodo(t *testing.T) {
+	// Given: 未着手の Todo
+	env := newTestEnv(t)
+	todo := env.create(t, "Buy milk")
+
+	// When:  完了にしてから戻す
+	w := env.post("/ui/todos/1/toggle", url.Values{"status": {"done"}})
+	got, _ := env.repo.FindByID(context.Background(), todo.ID)
+
+	// Then:  完了になり、一覧には戻すボタンが表示される
+	if w.Code != http.StatusSeeOther || got.Status != domain.StatusDone {
+		t.Fatalf("Expected done, got %d %s", w.Code, got.Status)
+	}
+	if !strings.Contains(env.get("/ui").Body.String(), "Reopen Buy milk") {
+		t.Errorf("Expected reopen button")
+	}
+	env.post("/ui/todos/1/toggle", url.Values{"status": {"todo"}})
+	got, _ = env.repo.FindByID(context.Background(), todo.ID)
+	if got.Status != domain.StatusTodo {
+		t.Errorf("Expected todo, got %s", got.Status)
+	}
+}
+
+func TestToggleTodo_InvalidTransition(t *testing.T) {
+	// Given: 未着手の Todo
+	env := newTestEnv(t)
+	env.create(t, "Buy milk")
+
+	// When:  未着手に変える
+	w := env.post("/ui/todos/1/toggle", url.Values{"status": {"todo"}})
+
+	// Then:  409 とエラーを表示する
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), domain.ErrInvalidTransition.Error()) {
+		t.Errorf("Expected 409 with error message, got %d", w.Code)
+	}
+}
+
+func TestDeleteTodo(t *testing.T) {
+	// Given: 「milk」で絞り込んだ一覧から
+	env := newTestEnv(t)
+	env.create(t, "Buy milk")
+
+	// When:  削除のフォームを送信する
+	req := httptest.NewRequest("POST", "/ui/todos/1/delete", strings.NewReader(url.Values{csrfField: {env.cookie.Value}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Referer", "http://example.com/ui?q=milk")
+	req.AddCookie(env.cookie)
+	w := httptest.NewRecorder()
+	env.mux.ServeHTTP(w, req)
+
+	// Then:  ゴミ箱に移り、絞り込みを保ったまま一覧に戻る
+	if w.Code != http.StatusSeeOther || w.Header().Get("Location") != "/ui?q=milk" {
+		t.Fatalf("Expected redirect to /ui?q=milk, got %d %q", w.Code, w.Header().Get("Location"))
+	}
+	if body := env.get("/ui").Body.String(); strings.Contains(body, "Buy milk") {
+		t.Errorf("Expected deleted todo to be hidden")
+	}
+}
+
+func TestList_Filter(t *testing.T) {
+	// Given: 2件の Todo
+	env := newTestEnv(t)
+	env.create(t, "Buy milk")
+	env.create(t, "Read book")
+
+	// When:  一覧の部分を絞り込んで取得する
+	w := env.get("/ui/list?q=milk")
+	body := w.Body.String()
+
+	// Then:  一致する Todo だけの一覧を返す
+	if w.Code != http.StatusOK || !strings.HasPrefix(body, `<ul id="todos"`) {
+		t.Fatalf("Expected list fragment, got %d %s", w.Code, body)
+	}
+	if !strings.Contains(body, "Buy milk") || strings.Contains(body, "Read book") {
+		t.Errorf("Unexpected list: %s", body)
+	}
+}
+
+func TestList_InvalidFilter(t *testing.T) {
+	env := newTestEnv(t)
+
+	w := env.get("/ui/list?q=" + url.QueryEscape(`"unterminated`))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+}
+
+func TestStatic(t *testing.T) {
+	env := newTestEnv(t)
+
+	w := env.get("/ui/static/app.js")
+	body, _ := io.ReadAll(w.Body)
+
+	if w.Code != http.StatusOK || !strings.Contains(string(body), "refreshList") {
+		t.Errorf("Expected app.js, got %d", w.Code)
+	}
+}
@@ -0,0 +1,125 @@
+// Progressive enhancement for the todo UI.
+// Forms with data-url are sent to the JSON API with fetch instead of a full page reload,
+// and the list is re-rendered from the server. Without JavaScript the forms post to /ui as usual.
+(function () {
+  "use strict";
+
+  if (!window.fetch) {
+    return;
+  }
+
+  function showError(message) {
+    var error = document.getElementById("error");
+    if (!error) {
+      return;
+    }
+    error.textContent = message;
+    error.hidden = !message;
+  }
+
+  function listURL() {
+    var filter = document.querySelector("form.filter input[name=q]");
+    var url = "/ui/list";
+    if (filter && filter.value) {
+      url += "?q=" + encodeURIComponent(filter.value);
+    }
+    return url;
+  }
+
+  // refreshList replaces the list with the server-rendered fragment.
+  async function refreshList() {
+    var list = document.getElementById("todos");
+    if (!list) {
+      return;
+    }
+    var response = await fetch(listURL(), { headers: { Accept: "text/html" } });
+    if (!response.ok) {
+      var message = await response.text();
+      showError(message || "Failed to load todos (" + response.status + ")");
+      return;
+    }
+    list.outerHTML = await response.text();
+    showError("");
+  }
+
+  function jsonBody(form) {
+    var body = {};
+    form.querySelectorAll("[data-json]").forEach(function (input) {
+      body[input.name] = input.dataset.json === "bool" ? input.value === "true" : input.value;
+    });
+    return body;
+  }
+
+  async function errorMessage(response) {
+    try {
+      var body = await response.json();
+      if (body && body.error) {
+        var fields = (body.fields || []).map(function (f) {
+          return f.field + " " + f.message;
+        });
+        return fields.length ? body.error + ": " + fields.join("; ") : body.error;
+      }
+    } catch (e) {
+      // The API returns no body for most errors.
+    }
+    return "Request failed: " + response.status + " " + response.statusText;
+  }
+
+  document.addEventListener("submit", async function (event) {
+    var form = event.target;
+    if (!form.dataset || !form.dataset.url) {
+      return;
+    }
+    event.preventDefault();
+
+    var method = form.dataset.method || "POST";
+    var init = { method: method, headers: { "Content-Type": "application/json" } };
+    if (method !== "DELETE") {
+      init.body = JSON.stringify(jsonBody(form));
+    }
+
+    form.classList.add("busy");
+    var response;
+    try {
+      response = await fetch(form.dataset.url, init);
+    } catch (e) {
+      // Fall back to the plain form post if the API cannot be reached.
+      form.submit();
+      return;
+    } finally {
+      form.classList.remove("busy");
+    }
+
+    if (!response.ok) {
+      showError(await errorMessage(response));
+      return;
+    }
+    if (form.dataset.redirect) {
+      window.location.href = form.dataset.redirect;
+      return;
+    }
+    if (form.classList.contains("create")) {
+      form.reset();
+    }
+    await refreshList();
+  });
+
+  // Filter as you type.
+  var filter = document.querySelector("form.filter input[name=q]");
+  if (filter) {
+    var timer;
+    filter.addEventListener("input", function () {
+      clearTimeout(timer);
+      timer = setTimeout(function () {
+        var url = new URL(window.location.href);
+        if (filter.value) {
+          url.searchParams.set("q", filter.value);
+        } else {
+          url.searchParams.delete("q");
+        }
+        window.history.replaceState(null, "", url);
+        refreshList();
+      }, 200);
+    });
+  }
+})();
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// NotifyingRepository は repo へのコミットされた変更を、登録された Webhook ごとの通知として送信キューに積むデコレータ。
+// 送信は DeliverWebhookUsecase が非同期に行う。
+type NotifyingRepository struct {
+	domain.IRepository
+	webhooks   domain.IWebhookRepository
+	deliveries domain.IDeliveryRepository
+}
+
+func NewNotifyingRepository(repo domain.IRepository, webhooks domain.IWebhookRepository, deliveries domain.IDeliveryRepository) *NotifyingRepository {
+	return &NotifyingRepository{IRepository: repo, webhooks: webhooks, deliveries: deliveries}
+}
+
+func (r *NotifyingRepository) Create(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Create(ctx, todo)
+	})
+}
+
+func (r *NotifyingRepository) Insert(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Insert(ctx, todo)
+	})
+}
+
+func (r *NotifyingRepository) Update(ctx context.Context, todo *domain.Todo) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Update(ctx, todo)
+	})
+}
+
+func (r *NotifyingRepository) Delete(ctx context.Context, id int) error {
+	return r.WithinTx(ctx, func(tx domain.IRepository) error {
+		return tx.Delete(ctx, id)
+	})
+}
+
+// WithinTx はコミットに成功した後で通知を積む。
+// 積めなかった場合も変更は保存済みなので、エラーは返さずにログに残す。
+func (r *NotifyingRepository) WithinTx(ctx context.Context, fn func(tx domain.IRepository) error) error {
+	var rec *domain.ChangeRecorder
+	err := r.IRepository.WithinTx(ctx, func(tx domain.IRepository) error {
+		rec = domain.NewChangeRecorder(tx)
+		return fn(rec)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := r.enqueue(ctx, rec.Changes()); err != nil {
+		log.Printf("Failed to enqueue webhook deliveries: %v", err)
+	}
+	return nil
+}
+
+func (r *NotifyingRepository) enqueue(ctx context.Context, changes []domain.Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	webhooks, err := r.webhooks.List(ctx)
+	if err != nil || len(webhooks) == 0 {
+		return err
+	}
+
+	now := time.Now()
+	var deliveries []*domain.WebhookDelivery
+	for _, c := range changes {
+		e := domain.FeedEventFromChange(c, now)
+		payload, err := json.Marshal(domain.WebhookPayload{Type: e.Type, Todo: e.Todo, At: e.At})
+		if err != nil {
+			return err
+		}
+		for _, w := range webhooks {
+			if !w.Subscribes(e.Type) {
+				continue
+			}
+			deliveries = append(deliveries, &domain.WebhookDelivery{
+				WebhookID:     w.ID,
+				Event:         e.Type,
+				Payload:       payload,
+				Status:        domain.DeliveryPending,
+				NextAttemptAt: now,
+				CreatedAt:     now,
+				UpdatedAt:     now,
+			})
+		}
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.deliveries.Create(ctx, deliveries)
+}
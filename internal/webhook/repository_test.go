@@ -0,0 +1,161 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+	"github.com/k98a73/go-todo/internal/usecase"
+)
+
+type testEnv struct {
+	repo       *NotifyingRepository
+	webhooks   *storage.FileWebhookRepository
+	deliveries *storage.FileDeliveryRepository
+	deliver    *usecase.DeliverWebhookUsecase
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	dir := t.TempDir()
+	webhooks := storage.NewFileWebhookRepository(filepath.Join(dir, "webhooks.json"))
+	deliveries := storage.NewFileDeliveryRepository(filepath.Join(dir, "webhook_deliveries.json"))
+	return &testEnv{
+		repo:       NewNotifyingRepository(storage.NewFileRepository(filepath.Join(dir, "todos.json")), webhooks, deliveries),
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		deliver:    usecase.NewDeliverWebhookUsecase(webhooks, deliveries, NewHTTPSender(DefaultTimeout)),
+	}
+}
+
+func (e *testEnv) register(t *testing.T, url string, events ...domain.FeedEventType) *domain.Webhook {
+	t.Helper()
+	webhook := &domain.Webhook{URL: url, Events: events, Secret: "s3cret"}
+	if err := e.webhooks.Create(context.Background(), webhook); err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	return webhook
+}
+
+func TestNotifyingRepository_Deliver(t *testing.T) {
+	// Given: 作成と削除だけを受け取る受信側
+	server, received := newReceiver(t, http.StatusOK)
+	env := newTestEnv(t)
+	webhook := env.register(t, server.URL, domain.FeedTodoCreated, domain.FeedTodoDeleted)
+	ctx := context.Background()
+
+	// When:  Todo を作成・更新・削除し、送信する
+	todo := &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}
+	if err := env.repo.Create(ctx, todo); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+	todo.Title = "Buy oat milk"
+	if err := env.repo.Update(ctx, todo); err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	if err := env.repo.Delete(ctx, todo.ID); err != nil {
+		t.Fatalf("Failed to delete: %v", err)
+	}
+	delivered, err := env.deliver.Execute(ctx, time.Now())
+
+	// Then:  作成と削除の通知が署名付きで順に届き、送信履歴に残る
+	if err != nil || delivered != 2 {
+		t.Fatalf("Expected 2 delivered, got %d, %v", delivered, err)
+	}
+	for _, wantType := range []domain.FeedEventType{domain.FeedTodoCreated, domain.FeedTodoDeleted} {
+		req := <-received
+		var payload domain.WebhookPayload
+		if err := json.Unmarshal(req.body, &payload); err != nil {
+			t.Fatalf("Failed to decode payload: %v", err)
+		}
+		if payload.Type != wantType || payload.Todo.ID != todo.ID {
+			t.Errorf("Unexpected payload: %s", req.body)
+		}
+	}
+	history, _ := env.deliveries.ListByWebhook(ctx, webhook.ID, 10)
+	if len(history) != 2 || history[0].Status != domain.DeliverySucceeded || history[1].Status != domain.DeliverySucceeded {
+		t.Errorf("Unexpected history: %+v", history)
+	}
+}
+
+func TestNotifyingRepository_Retry(t *testing.T) {
+	// Given: 失敗を返す受信側
+	server, received := newReceiver(t, http.StatusInternalServerError)
+	env := newTestEnv(t)
+	webhook := env.register(t, server.URL)
+	ctx := context.Background()
+	if err := env.repo.Create(ctx, &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+
+	// When:  送信し、再送時刻の前と後にもう一度送信する
+	now := time.Now()
+	if _, err := env.deliver.Execute(ctx, now); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := env.deliver.Execute(ctx, now.Add(10*time.Second)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := env.deliver.Execute(ctx, now.Add(31*time.Second)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Then:  再送時刻が来てから再送され、失敗の内容が記録される
+	if len(received) != 2 {
+		t.Errorf("Expected 2 attempts, got %d", len(received))
+	}
+	history, _ := env.deliveries.ListByWebhook(ctx, webhook.ID, 10)
+	if len(history) != 1 || history[0].Attempts != 2 || history[0].LastStatusCode != 500 || history[0].Status != domain.DeliveryPending {
+		t.Errorf("Unexpected history: %+v", history)
+	}
+}
+
+func TestNotifyingRepository_NoWebhook(t *testing.T) {
+	// Given: Webhook が登録されていない
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	// When:  Todo を作成する
+	if err := env.repo.Create(ctx, &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo}); err != nil {
+		t.Fatalf("Failed to create: %v", err)
+	}
+
+	// Then:  通知は積まれない
+	if due, _ := env.deliveries.ListDue(ctx, time.Now()); len(due) != 0 {
+		t.Errorf("Expected no deliveries, got %+v", due)
+	}
+}
+
+type failingDeliveries struct {
+	domain.IDeliveryRepository
+}
+
+func (failingDeliveries) Create(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	return errors.New("disk full")
+}
+
+func TestNotifyingRepository_EnqueueFailure(t *testing.T) {
+	// Given: 通知を積めない送信キュー
+	env := newTestEnv(t)
+	env.register(t, "http://example.com/hook")
+	todos := storage.NewFileRepository(filepath.Join(t.TempDir(), "todos.json"))
+	repo := NewNotifyingRepository(todos, env.webhooks, failingDeliveries{env.deliveries})
+	ctx := context.Background()
+
+	// When:  Todo を作成する
+	err := repo.Create(ctx, &domain.Todo{Title: "Buy milk", Status: domain.StatusTodo})
+
+	// Then:  変更はコミット済みなのでエラーにならず、Todo は保存されている
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved, _ := todos.List(ctx); len(saved) != 1 {
+		t.Errorf("Expected 1 todo, got %d", len(saved))
+	}
+}
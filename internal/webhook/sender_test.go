@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/k98a73/go-todo/internal/domain"
+)
+
+// receivedRequest は受信側に届いたリクエストの内容。
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newReceiver は届いたリクエストを記録し、status を返す受信側を起動する。
+func newReceiver(t *testing.T, status int) (*httptest.Server, chan receivedRequest) {
+	t.Helper()
+	received := make(chan receivedRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedRequest{header: r.Header.Clone(), body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func TestHTTPSender_Send(t *testing.T) {
+	// Given: 受信側と、その URL を登録した Webhook
+	server, received := newReceiver(t, http.StatusNoContent)
+	sender := NewHTTPSender(DefaultTimeout)
+	sender.now = func() time.Time { return time.Unix(1700000000, 0) }
+	webhook := &domain.Webhook{ID: "1", URL: server.URL, Secret: "s3cret"}
+	delivery := &domain.WebhookDelivery{ID: "7", WebhookID: "1", Event: domain.FeedTodoCreated, Payload: []byte(`{"type":"created"}`)}
+
+	// When:  送信する
+	code, err := sender.Send(context.Background(), webhook, delivery)
+
+	// Then:  本文と、受信側で検証できる署名が届く
+	if err != nil || code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d, %v", code, err)
+	}
+	req := <-received
+	if string(req.body) != `{"type":"created"}` {
+		t.Errorf("Unexpected body: %s", req.body)
+	}
+	timestamp, _ := strconv.ParseInt(req.header.Get(TimestampHeader), 10, 64)
+	if timestamp != 1700000000 {
+		t.Errorf("Unexpected timestamp: %s", req.header.Get(TimestampHeader))
+	}
+	if got := req.header.Get(SignatureHeader); got != domain.SignWebhook("s3cret", timestamp, req.body) {
+		t.Errorf("Unexpected signature: %s", got)
+	}
+	if req.header.Get(EventHeader) != "created" || req.header.Get(DeliveryHeader) != "7" || req.header.Get("Content-Type") != "application/json" {
+		t.Errorf("Unexpected headers: %v", req.header)
+	}
+}
+
+func TestHTTPSender_Send_Error(t *testing.T) {
+	// Given: 停止した受信側
+	server, _ := newReceiver(t, http.StatusOK)
+	server.Close()
+	sender := NewHTTPSender(DefaultTimeout)
+
+	// When:  送信する
+	_, err := sender.Send(context.Background(), &domain.Webhook{URL: server.URL, Secret: "s"}, &domain.WebhookDelivery{})
+
+	// Then:  通信エラーが返る
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
@@ -0,0 +1,183 @@
+// Package client は Todo サーバーの HTTP API を呼び出すクライアント。
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client の Token は Authorization ヘッダーで、Actor は X-Actor ヘッダーで送る。どちらも空なら送らない。
+// Retry のゼロ値は再試行しない。
+type Client struct {
+	BaseURL    string
+	Token      string
+	Actor      string
+	HTTPClient *http.Client
+	Retry      RetryPolicy
+}
+
+func New(baseURL string) *Client {
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		Retry:      DefaultRetryPolicy,
+	}
+}
+
+type StatusTransition struct {
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+type Todo struct {
+	ID            int                `json:"id"`
+	Title         string             `json:"title"`
+	Position      string             `json:"position,omitempty"`
+	Status        string             `json:"status"`
+	Completed     bool               `json:"completed"`
+	StatusHistory []StatusTransition `json:"status_history,omitempty"`
+	CompletedAt   *time.Time         `json:"completed_at,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+	DeletedAt     *time.Time         `json:"deleted_at,omitempty"`
+}
+
+// ListOptions の Query はサーバーの検索式。ゼロ値の項目は指定しない。
+type ListOptions struct {
+	Query string
+	Sort  string
+	Limit int
+}
+
+func (c *Client) CreateTodo(ctx context.Context, title string) (*Todo, error) {
+	var todo Todo
+	if err := c.do(ctx, http.MethodPost, "/v1/todos", map[string]string{"title": title}, &todo); err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+func (c *Client) ListTodos(ctx context.Context, opts ListOptions) ([]*Todo, error) {
+	query := url.Values{}
+	if opts.Query != "" {
+		query.Set("q", opts.Query)
+	}
+	if opts.Sort != "" {
+		query.Set("sort", opts.Sort)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	path := "/v1/todos"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var list struct {
+		Items []*Todo `json:"items"`
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (c *Client) GetTodo(ctx context.Context, id int) (*Todo, error) {
+	var todo Todo
+	if err := c.do(ctx, http.MethodGet, "/v1/todos/"+strconv.Itoa(id), nil, &todo); err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+func (c *Client) UpdateTodo(ctx context.Context, id int, title string, completed bool) (*Todo, error) {
+	body := map[string]any{"title": title, "completed": completed}
+	var todo Todo
+	if err := c.do(ctx, http.MethodPut, "/v1/todos/"+strconv.Itoa(id), body, &todo); err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+// DeleteTodo は Todo をゴミ箱に移動する。
+// 再試行した場合、最初の要求が届いていれば2回目は ErrNotFound になる。
+func (c *Client) DeleteTodo(ctx context.Context, id int) error {
+	return c.do(ctx, http.MethodDelete, "/v1/todos/"+strconv.Itoa(id), nil, nil)
+}
+
+func (c *Client) ChangeStatus(ctx context.Context, id int, status string) (*Todo, error) {
+	var todo Todo
+	if err := c.do(ctx, http.MethodPost, "/todo/"+strconv.Itoa(id)+"/status", map[string]string{"status": status}, &todo); err != nil {
+		return nil, err
+	}
+	return &todo, nil
+}
+
+// do は body を JSON で送り、成功したレスポンスを out に読み込む。out が nil なら本文は読まない。
+// 冪等なメソッドは Retry に従って再試行する。
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, path, data)
+		retry, wait := c.Retry.next(ctx, method, attempt, resp, err)
+		if !retry {
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+				return newAPIError(resp)
+			}
+			if out == nil {
+				return nil
+			}
+			return json.NewDecoder(resp.Body).Decode(out)
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// send はリクエストを1回送る。
+func (c *Client) send(ctx context.Context, method, path string, data []byte) (*http.Response, error) {
+	var reader io.Reader
+	if data != nil {
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if c.Actor != "" {
+		req.Header.Set("X-Actor", c.Actor)
+	}
+	return c.HTTPClient.Do(req)
+}
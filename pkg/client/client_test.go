@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/k98a73/go-todo/internal/domain"
+	http_infra "github.com/k98a73/go-todo/internal/infra/http"
+	"github.com/k98a73/go-todo/internal/infra/storage"
+	"github.com/k98a73/go-todo/internal/usecase"
+)
+
+// newTestServer は実際のハンドラーを cmd/main.go と同じ URL とミドルウェアで起動する。
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	repo := storage.NewFileRepository(filepath.Join(t.TempDir(), "todos.json"))
+	machine := domain.DefaultStateMachine()
+	todoHandler := http_infra.NewTodoHandler(
+		usecase.NewCreateTodoUsecase(repo, machine),
+		usecase.NewListTodoUsecase(repo, machine),
+		usecase.NewFindByIDTodoUsecase(repo),
+		usecase.NewUpdateTodoUsecase(repo, machine),
+		usecase.NewDeleteTodoUsecase(repo),
+	)
+	statusHandler := http_infra.NewStatusHandler(usecase.NewChangeStatusTodoUsecase(repo, machine))
+
+	v1 := todoHandler.V1()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/todos", v1.CreateTodo)
+	mux.HandleFunc("GET /v1/todos", v1.ListTodo)
+	mux.HandleFunc("GET /v1/todos/{id}", v1.FindByIDTodo)
+	mux.HandleFunc("PUT /v1/todos/{id}", v1.UpdateTodo)
+	mux.HandleFunc("DELETE /v1/todos/{id}", v1.DeleteTodo)
+	mux.HandleFunc("POST /todo/{id}/status", statusHandler.ChangeStatus)
+	server := httptest.NewServer(http_infra.RequestIDMiddleware(http_infra.ActorMiddleware(mux)))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_Workflow(t *testing.T) {
+	// Given: 実際のハンドラーで動くサーバー
+	c := New(newTestServer(t).URL)
+	ctx := context.Background()
+
+	// When:  作成・一覧・取得・更新・ステータス変更・削除を順に行う
+	created, err := c.CreateTodo(ctx, "Buy milk")
+	if err != nil {
+		t.Fatalf("CreateTodo: %v", err)
+	}
+	if _, err := c.CreateTodo(ctx, "Read book"); err != nil {
+		t.Fatalf("CreateTodo: %v", err)
+	}
+	todos, err := c.ListTodos(ctx, ListOptions{Query: "milk"})
+	if err != nil || len(todos) != 1 || todos[0].ID != created.ID {
+		t.Fatalf("ListTodos: expected only todo %d, got %v, %v", created.ID, todos, err)
+	}
+	updated, err := c.UpdateTodo(ctx, created.ID, "Buy oat milk", false)
+	if err != nil || updated.Title != "Buy oat milk" {
+		t.Fatalf("UpdateTodo: %v, %v", updated, err)
+	}
+	done, err := c.ChangeStatus(ctx, created.ID, "done")
+	if err != nil || !done.Completed || done.Status != "done" {
+		t.Fatalf("ChangeStatus: %v, %v", done, err)
+	}
+	got, err := c.GetTodo(ctx, created.ID)
+	if err != nil || got.Title != "Buy oat milk" || got.CompletedAt == nil {
+		t.Fatalf("GetTodo: %v, %v", got, err)
+	}
+	if err := c.DeleteTodo(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteTodo: %v", err)
+	}
+
+	// Then:  削除した Todo は見つからない
+	if _, err := c.GetTodo(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestClient_TypedErrors(t *testing.T) {
+	// Given: 実際のハンドラーで動くサーバーと、完了していない Todo
+	c := New(newTestServer(t).URL)
+	ctx := context.Background()
+	todo, _ := c.CreateTodo(ctx, "Buy milk")
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"invalid filter", func() error { _, err := c.ListTodos(ctx, ListOptions{Query: `"unterminated`}); return err }, ErrBadRequest},
+		{"empty title", func() error { _, err := c.CreateTodo(ctx, ""); return err }, ErrBadRequest},
+		{"missing todo", func() error { _, err := c.UpdateTodo(ctx, 99, "x", false); return err }, ErrNotFound},
+		{"invalid transition", func() error { _, err := c.ChangeStatus(ctx, todo.ID, "todo"); return err }, ErrConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// When:  失敗する操作を行う
+			err := tt.call()
+
+			// Then:  ステータスコードに対応するエラーになり、リクエスト ID が付く
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, err)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.RequestID == "" {
+				t.Errorf("Expected request ID, got %+v", apiErr)
+			}
+			if tt.name == "empty title" && (len(apiErr.Fields) != 1 || apiErr.Fields[0].Field != "title") {
+				t.Errorf("Expected title field error, got %+v", apiErr.Fields)
+			}
+		})
+	}
+}
+
+func TestClient_Request(t *testing.T) {
+	// Given: リクエストを記録するサーバー
+	var got *http.Request
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Todo{ID: 3, Title: "Buy milk", Status: "done", Completed: true})
+	}))
+	defer server.Close()
+	c := New(server.URL + "/")
+	c.Token = "t0ken"
+	c.Actor = "alice"
+
+	// When:  ステータスを変更する
+	todo, err := c.ChangeStatus(context.Background(), 3, "done")
+
+	// Then:  パス・本文・ヘッダーが送られ、レスポンスが読み込まれる
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Method != "POST" || got.URL.Path != "/todo/3/status" || gotBody["status"] != "done" {
+		t.Errorf("Unexpected request: %s %s %v", got.Method, got.URL.Path, gotBody)
+	}
+	if got.Header.Get("Authorization") != "Bearer t0ken" || got.Header.Get("X-Actor") != "alice" {
+		t.Errorf("Unexpected headers: %v", got.Header)
+	}
+	if todo.ID != 3 || !todo.Completed {
+		t.Errorf("Unexpected todo: %+v", todo)
+	}
+}
+
+func TestClient_ListTodos_Query(t *testing.T) {
+	// Given: クエリを記録するサーバー
+	var path, rawQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, rawQuery = r.URL.Path, r.URL.RawQuery
+		w.Write([]byte(`{"items":[],"count":0}`))
+	}))
+	defer server.Close()
+
+	// When:  条件を指定して一覧を取得する
+	_, err := New(server.URL).ListTodos(context.Background(), ListOptions{Query: "status:open", Sort: "position", Limit: 5})
+
+	// Then:  条件がクエリパラメータで送られる
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if path != "/v1/todos" || rawQuery != "limit=5&q=status%3Aopen&sort=position" {
+		t.Errorf("Unexpected request: %s?%s", path, rawQuery)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+	}{
+		{name: "without body", status: http.StatusNotFound, wantMessage: "Not Found"},
+		{name: "with error body", status: http.StatusBadRequest, body: `{"error":"unexpected token at 3"}`, wantMessage: "unexpected token at 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := New(server.URL).GetTodo(context.Background(), 1)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected APIError, got %v", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Message != tt.wantMessage {
+				t.Errorf("Unexpected error: %+v", apiErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ステータスコードの種類ごとのエラー。errors.Is(err, client.ErrNotFound) のように APIError と比較できる。
+var (
+	ErrBadRequest = errors.New("bad request")
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrGone       = errors.New("gone")
+	ErrServer     = errors.New("server error")
+)
+
+// FieldError は本文の検証に失敗したフィールド。
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// APIError はサーバーが 2xx 以外を返したことを表す。Message はレスポンスの error、なければステータスの説明。
+// Fields は本文の検証に失敗したフィールド。RequestID はサーバーのログと突き合わせるための X-Request-ID。
+type APIError struct {
+	StatusCode int
+	Message    string
+	Fields     []FieldError
+	RequestID  string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+	if len(e.Fields) > 0 {
+		fields := make([]string, len(e.Fields))
+		for i, f := range e.Fields {
+			fields[i] = f.Field + " " + f.Message
+		}
+		msg += ": " + strings.Join(fields, "; ")
+	}
+	return msg
+}
+
+// Is はステータスコードに対応する Err* と一致するかを返す。
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrGone:
+		return e.StatusCode == http.StatusGone
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    http.StatusText(resp.StatusCode),
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+	var body struct {
+		Error  string       `json:"error"`
+		Fields []FieldError `json:"fields"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err == nil && body.Error != "" {
+		apiErr.Message = body.Error
+		apiErr.Fields = body.Fields
+	}
+	return apiErr
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{400, ErrBadRequest},
+		{404, ErrNotFound},
+		{409, ErrConflict},
+		{410, ErrGone},
+		{500, ErrServer},
+		{503, ErrServer},
+	}
+	all := []error{ErrBadRequest, ErrNotFound, ErrConflict, ErrGone, ErrServer}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.status), func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: tt.status})
+			for _, target := range all {
+				if got := errors.Is(err, target); got != (target == tt.want) {
+					t.Errorf("errors.Is(%d, %v): expected %v, got %v", tt.status, target, target == tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIError_Fields(t *testing.T) {
+	err := &APIError{StatusCode: 400, Message: "invalid request body", Fields: []FieldError{{Field: "title", Message: "is required"}, {Field: "color", Message: "unknown field"}}}
+
+	if got := err.Error(); got != "400 invalid request body: title is required; color unknown field" {
+		t.Errorf("Unexpected message: %s", got)
+	}
+}
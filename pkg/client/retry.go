@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy は冪等なリクエスト（GET・PUT・DELETE）を再試行する条件。
+// 接続の失敗と、429・502・503・504 を再試行する。POST は二重に作成しないよう再試行しない。
+// MaxAttempts は最初の1回を含む回数で、1以下なら再試行しない。
+type RetryPolicy struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  100 * time.Millisecond,
+	MaxBackoff:  2 * time.Second,
+}
+
+// next は attempt 回目の結果を再試行するか、するならいつまで待つかを返す。
+func (p RetryPolicy) next(ctx context.Context, method string, attempt int, resp *http.Response, err error) (bool, time.Duration) {
+	if attempt >= p.MaxAttempts || !idempotent(method) || ctx.Err() != nil {
+		return false, 0
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded), p.backoff(attempt)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+	default:
+		return false, 0
+	}
+	// Retry-After（秒）があれば従う。ただし MaxBackoff より長くは待たない
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return true, min(time.Duration(seconds)*time.Second, p.MaxBackoff)
+	}
+	return true, p.backoff(attempt)
+}
+
+// backoff は MinBackoff から倍々に増やし MaxBackoff で止めた時間に、同時に再試行が集中しないようゆらぎを加える。
+// 結果は上限の半分から上限までの間になる。
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, p.MaxBackoff)
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetry はテストが待たないように短い間隔で再試行する。
+var fastRetry = RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+// newFlakyServer は最初の failures 回だけ status を返し、その後は Todo を返すサーバーを起動する。
+func newFlakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"id":1,"title":"Buy milk","status":"todo"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestClient_Retry(t *testing.T) {
+	// Given: 2回 503 を返すサーバー
+	server, calls := newFlakyServer(t, 2, http.StatusServiceUnavailable)
+	c := New(server.URL)
+	c.Retry = fastRetry
+
+	// When:  Todo を取得する
+	todo, err := c.GetTodo(context.Background(), 1)
+
+	// Then:  3回目で成功する
+	if err != nil || todo.ID != 1 {
+		t.Fatalf("Expected todo 1, got %v, %v", todo, err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestClient_Retry_GiveUp(t *testing.T) {
+	// Given: 503 を返し続けるサーバー
+	server, calls := newFlakyServer(t, 10, http.StatusServiceUnavailable)
+	c := New(server.URL)
+	c.Retry = fastRetry
+
+	// When:  Todo を取得する
+	_, err := c.GetTodo(context.Background(), 1)
+
+	// Then:  MaxAttempts 回で諦め、最後のエラーを返す
+	if !errors.Is(err, ErrServer) {
+		t.Errorf("Expected ErrServer, got %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestClient_Retry_NotIdempotent(t *testing.T) {
+	// Given: 1回 503 を返すサーバー
+	server, calls := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+	c := New(server.URL)
+	c.Retry = fastRetry
+
+	// When:  POST で作成する
+	_, err := c.CreateTodo(context.Background(), "Buy milk")
+
+	// Then:  二重に作成しないよう再試行しない
+	if !errors.Is(err, ErrServer) || calls.Load() != 1 {
+		t.Errorf("Expected 1 call and ErrServer, got %d, %v", calls.Load(), err)
+	}
+}
+
+func TestClient_Retry_ClientError(t *testing.T) {
+	// Given: 1回 404 を返すサーバー
+	server, calls := newFlakyServer(t, 1, http.StatusNotFound)
+	c := New(server.URL)
+	c.Retry = fastRetry
+
+	// When:  Todo を取得する
+	_, err := c.GetTodo(context.Background(), 1)
+
+	// Then:  再試行しても変わらないため再試行しない
+	if !errors.Is(err, ErrNotFound) || calls.Load() != 1 {
+		t.Errorf("Expected 1 call and ErrNotFound, got %d, %v", calls.Load(), err)
+	}
+}
+
+func TestClient_Retry_ConnectionError(t *testing.T) {
+	// Given: 閉じたサーバー
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	c := New(server.URL)
+	c.Retry = fastRetry
+
+	// When:  Todo を取得する
+	_, err := c.GetTodo(context.Background(), 1)
+
+	// Then:  接続のエラーを返す
+	var apiErr *APIError
+	if err == nil || errors.As(err, &apiErr) {
+		t.Errorf("Expected connection error, got %v", err)
+	}
+}
+
+func TestClient_Retry_ContextCanceled(t *testing.T) {
+	// Given: 503 を返し続けるサーバーと、長い待ち時間
+	server, _ := newFlakyServer(t, 10, http.StatusServiceUnavailable)
+	c := New(server.URL)
+	c.Retry = RetryPolicy{MaxAttempts: 5, MinBackoff: time.Hour, MaxBackoff: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// When:  Todo を取得する
+	_, err := c.GetTodo(ctx, 1)
+
+	// Then:  待っている間に ctx が終了したら諦める
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRetryPolicy_RetryAfter(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Second}
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"3"}}}
+
+	retry, wait := policy.next(context.Background(), http.MethodGet, 1, resp, nil)
+
+	if !retry || wait != 3*time.Second {
+		t.Errorf("Expected retry after 3s, got %v, %v", retry, wait)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{6, time.Second},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			if got := policy.backoff(tt.attempt); got < tt.max/2 || got > tt.max {
+				t.Errorf("attempt %d: expected between %v and %v, got %v", tt.attempt, tt.max/2, tt.max, got)
+			}
+		}
+	}
+}